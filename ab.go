@@ -0,0 +1,180 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ABVariant is one side of an A/B comparison: the flag values it runs with
+// on top of the rest of the configuration.
+type ABVariant struct {
+	Name   string
+	Params map[string]string
+}
+
+// parseABVariants parses -ab_a and -ab_b, each a list of flag values such
+// as "bloom_filter=false,write_buffer_size=33554432" in the syntax of
+// per-benchmark parameters. It returns nil when neither is set.
+func parseABVariants(a, b string) ([]ABVariant, error) {
+	if a == "" && b == "" {
+		return nil, nil
+	}
+	if a == "" || b == "" {
+		return nil, fmt.Errorf("-ab_a and -ab_b must be given together")
+	}
+
+	known := flag.NewFlagSet("ab", flag.ContinueOnError)
+	known.SetOutput(io.Discard)
+	registerFlags(known, defaultConfig())
+
+	var variants []ABVariant
+	for _, v := range []struct{ name, params string }{{"A", a}, {"B", b}} {
+		params, rest, err := parseSpecParams(v.params + ")")
+		if err != nil || rest != "" {
+			return nil, fmt.Errorf("%s: expected name=value,... in %q", v.name, v.params)
+		}
+		for name := range params {
+			switch {
+			case name == "benchmarks" || name == "config" || name == "sweep" || name == "repeat" ||
+				name == "pipeline" || name == "ab_a" || name == "ab_b":
+				return nil, fmt.Errorf("%s: %s cannot differ between variants", v.name, name)
+			case known.Lookup(name) == nil:
+				return nil, fmt.Errorf("%s: unknown flag %q", v.name, name)
+			}
+		}
+		variants = append(variants, ABVariant{Name: v.name, Params: params})
+	}
+	return variants, nil
+}
+
+// variantDBPath is where variant v keeps its database: its own -db, or
+// beside config's so the variants never read each other's data.
+func (config *BenchmarkConfig) variantDBPath(v ABVariant) string {
+	if path, ok := v.Params["db"]; ok {
+		return path
+	}
+	return config.DBPath + "_" + strings.ToLower(v.Name)
+}
+
+// withVariant returns config with variant v's flag values applied.
+func (config *BenchmarkConfig) withVariant(v ABVariant) (*BenchmarkConfig, error) {
+	params := make(map[string]string, len(v.Params)+1)
+	for k, value := range v.Params {
+		params[k] = value
+	}
+	params["db"] = config.variantDBPath(v)
+	return config.withOverrides(params)
+}
+
+// databasePaths lists the database directories a run writes to: -db, or
+// each variant's in an A/B comparison.
+func (config *BenchmarkConfig) databasePaths() []string {
+	if len(config.ABVariants) == 0 {
+		return []string{config.DBPath}
+	}
+
+	var paths []string
+	for _, v := range config.ABVariants {
+		paths = append(paths, config.variantDBPath(v))
+	}
+	return paths
+}
+
+// percentChange formats the change from a to b as a signed percentage.
+func percentChange(a, b float64) string {
+	if a == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", (b-a)*100/a)
+}
+
+// printABComparison prints each benchmark's mean ops/sec and latency under
+// both variants side by side, with B's change relative to A and whether the
+// change in throughput is significant across the repeated runs.
+func printABComparison(config *BenchmarkConfig, results []*BenchmarkResult) {
+	if len(config.ABVariants) == 0 {
+		return
+	}
+
+	type side struct {
+		opsPerSec []float64
+		p50, p99  time.Duration
+		runs      int
+	}
+	type row struct {
+		label string
+		sides map[string]*side
+	}
+
+	var rows []*row
+	byLabel := make(map[string]*row)
+	for _, r := range results {
+		label := r.TestName
+		if r.Sweep != "" {
+			label += "[" + r.Sweep + "]"
+		}
+
+		rw := byLabel[label]
+		if rw == nil {
+			rw = &row{label: label, sides: make(map[string]*side)}
+			byLabel[label] = rw
+			rows = append(rows, rw)
+		}
+		s := rw.sides[r.Variant]
+		if s == nil {
+			s = &side{}
+			rw.sides[r.Variant] = s
+		}
+		s.opsPerSec = append(s.opsPerSec, r.OpsPerSecond)
+		s.p50 += r.LatencyP50
+		s.p99 += r.LatencyP99
+		s.runs++
+	}
+
+	a, b := config.ABVariants[0], config.ABVariants[1]
+
+	fmt.Printf("A/B Comparison (%s, alpha %g)\n", config.SigTest, config.SigAlpha)
+	fmt.Printf("==============================\n")
+	fmt.Printf("  %s\n", BenchmarkSpec{Name: a.Name, Params: a.Params})
+	fmt.Printf("  %s\n\n", BenchmarkSpec{Name: b.Name, Params: b.Params})
+	fmt.Printf("%-25s %12s %12s %9s %9s %12s %10s %10s %9s %10s %10s %9s\n",
+		"Test", "A ops/sec", "B ops/sec", "Change", "p-value", "Significant",
+		"A P50", "B P50", "Change", "A P99", "B P99", "Change")
+
+	for _, rw := range rows {
+		sa, sb := rw.sides[a.Name], rw.sides[b.Name]
+		if sa == nil || sb == nil {
+			continue
+		}
+
+		mean := func(s *side, d time.Duration) time.Duration {
+			return d / time.Duration(s.runs)
+		}
+		opsA, opsB := computeRunStat(sa.opsPerSec).Mean, computeRunStat(sb.opsPerSec).Mean
+		p, verdict := formatPValue(significance(config.SigTest, sa.opsPerSec, sb.opsPerSec), config.SigAlpha)
+		p50A, p50B := mean(sa, sa.p50), mean(sb, sb.p50)
+		p99A, p99B := mean(sa, sa.p99), mean(sb, sb.p99)
+
+		fmt.Printf("%-25s %12.2f %12.2f %9s %9s %12s %10s %10s %9s %10s %10s %9s\n",
+			rw.label, opsA, opsB, percentChange(opsA, opsB), p, verdict,
+			formatDuration(p50A), formatDuration(p50B), percentChange(float64(p50A), float64(p50B)),
+			formatDuration(p99A), formatDuration(p99B), percentChange(float64(p99A), float64(p99B)))
+	}
+	fmt.Printf("\n")
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DataAgeTracker buckets read latencies by how long ago the key was last
+// written during the current benchmark. Recently written keys are likely still
+// in a memtable while older ones have been flushed, so the buckets show which
+// tier dominates the tail.
+type DataAgeTracker struct {
+	writtenAt []int64 // UnixNano of the last successful write, indexed by key index
+	bounds    []time.Duration
+	buckets   []*LatencyTracker // one per bound plus one for older data
+	prerun    *LatencyTracker   // keys not written since the benchmark started
+}
+
+func NewDataAgeTracker(tracker *LatencyTracker, keys int64, bounds []time.Duration) *DataAgeTracker {
+	dt := &DataAgeTracker{
+		writtenAt: make([]int64, keys),
+		bounds:    bounds,
+		prerun:    tracker.Class("age:prerun"),
+	}
+
+	for _, b := range bounds {
+		dt.buckets = append(dt.buckets, tracker.Class("age<"+b.String()))
+	}
+	if len(bounds) > 0 {
+		dt.buckets = append(dt.buckets, tracker.Class("age>="+bounds[len(bounds)-1].String()))
+	}
+
+	return dt
+}
+
+// MarkWritten records that keyIndex was successfully written now.
+// Keys beyond the tracked range, such as ones inserted during the run, are
+// ignored.
+func (dt *DataAgeTracker) MarkWritten(keyIndex int64) {
+	if keyIndex >= int64(len(dt.writtenAt)) {
+		return
+	}
+	atomic.StoreInt64(&dt.writtenAt[keyIndex], time.Now().UnixNano())
+}
+
+// Record files a read latency of keyIndex under the bucket matching the
+// key's age at the time the read started.
+func (dt *DataAgeTracker) Record(threadID int, keyIndex int64, readStart time.Time, latency time.Duration) {
+	if keyIndex >= int64(len(dt.writtenAt)) {
+		return
+	}
+
+	written := atomic.LoadInt64(&dt.writtenAt[keyIndex])
+	if written == 0 || len(dt.bounds) == 0 {
+		dt.prerun.Record(threadID, latency)
+		return
+	}
+
+	age := readStart.Sub(time.Unix(0, written))
+	for i, b := range dt.bounds {
+		if age < b {
+			dt.buckets[i].Record(threadID, latency)
+			return
+		}
+	}
+	dt.buckets[len(dt.bounds)].Record(threadID, latency)
+}
+
+// parseDurationList parses a comma-separated list of durations and returns it
+// sorted ascending.
+func parseDurationList(s string) ([]time.Duration, error) {
+	var out []time.Duration
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q: %w", part, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("duration %q must be positive", part)
+		}
+		out = append(out, d)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+// formatDurationList is the inverse of parseDurationList.
+func formatDurationList(ds []time.Duration) string {
+	parts := make([]string, len(ds))
+	for i, d := range ds {
+		parts[i] = d.String()
+	}
+	return strings.Join(parts, ",")
+}
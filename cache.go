@@ -0,0 +1,127 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// PageCacheStats is how the reads of a benchmark were served. WildcatDB has
+// no block cache of its own: it reads SSTable blocks with pread, so the
+// operating system's page cache is what decides whether a read touches
+// storage. Read is the bytes the process read from files, From Storage the
+// bytes the kernel had to fetch from the device for them, both from
+// /proc/self/io, so the stats are Linux only and cover the whole process.
+// Readahead can fetch more than is read, which caps the miss ratio at one.
+type PageCacheStats struct {
+	Read        int64
+	FromStorage int64
+	HitRate     *float64 `json:",omitempty"` // Share of the bytes read served from the page cache; nil below pageCacheMinRead
+}
+
+// pageCacheMinRead is the least a benchmark must read from files for its hit
+// rate to mean anything. rchar also counts the tool's own reads of /proc by
+// its samplers, a few hundred bytes each, so a benchmark served from the
+// memtable would otherwise report a perfect hit rate from those alone.
+const pageCacheMinRead = 1 << 20
+
+// readProcessIO returns the rchar and read_bytes counters of /proc/self/io.
+func readProcessIO() (read, fromStorage int64, ok bool) {
+	data, err := os.ReadFile("/proc/self/io")
+	if err != nil {
+		return 0, 0, false
+	}
+
+	found := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, value, _ := bytes.Cut(scanner.Bytes(), []byte(": "))
+		n, err := strconv.ParseInt(string(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch string(name) {
+		case "rchar":
+			read = n
+			found++
+		case "read_bytes":
+			fromStorage = n
+			found++
+		}
+	}
+	return read, fromStorage, found == 2
+}
+
+// startPageCacheStats captures the process's I/O counters and returns a
+// function that reports the reads since, or nil when the counters cannot be
+// read or nothing was read.
+func startPageCacheStats() func() *PageCacheStats {
+	readBefore, storageBefore, ok := readProcessIO()
+
+	return func() *PageCacheStats {
+		readAfter, storageAfter, okAfter := readProcessIO()
+		if !ok || !okAfter || readAfter <= readBefore {
+			return nil
+		}
+
+		s := &PageCacheStats{
+			Read:        readAfter - readBefore,
+			FromStorage: storageAfter - storageBefore,
+		}
+		if s.Read >= pageCacheMinRead {
+			hitRate := 1 - min(float64(s.FromStorage)/float64(s.Read), 1)
+			s.HitRate = &hitRate
+		}
+		return s
+	}
+}
+
+func printPageCacheStats(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		s := result.PageCache
+		if s == nil || result.BytesRead == 0 {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Page Cache\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %12s %14s %10s %14s\n",
+				"Test", "File reads", "From storage", "Hit rate", "Storage/op")
+			printed = true
+		}
+
+		var perOp float64
+		if result.Operations > 0 {
+			perOp = float64(s.FromStorage) / float64(result.Operations)
+		}
+
+		hitRate := "N/A"
+		if s.HitRate != nil {
+			hitRate = fmt.Sprintf("%.2f%%", *s.HitRate*100)
+		}
+
+		fmt.Printf("%-25s %12s %14s %10s %14.1f\n",
+			result.Label(), formatBytes(s.Read), formatBytes(s.FromStorage), hitRate, perOp)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Checkpoint is a suite's progress as saved to -resume_file, so a run cut
+// short by a crash or reboot can pick up where it left off.
+type Checkpoint struct {
+	Fingerprint string // workloadFingerprint of the configuration
+	Seed        int64
+	StartedAt   time.Time
+	SavedAt     time.Time
+	Completed   []*BenchmarkResult // Benchmark runs finished, in order
+	Current     *PartialRun        `json:",omitempty"` // The benchmark run in progress
+}
+
+// PartialRun is how far a benchmark run had got when it was saved.
+type PartialRun struct {
+	Seq          int // Position in the suite
+	Name         string
+	Elapsed      time.Duration
+	Operations   int64
+	BytesRead    int64
+	BytesWritten int64
+	Errors       int64
+	Latency      *LatencyCounts
+	Schedules    [][]int64 // Operations done by each thread, per OpSchedule in the order they were created
+}
+
+// suiteCheckpoint saves the running suite's progress with -resume_file.
+var suiteCheckpoint *checkpointer
+
+type checkpointer struct {
+	path     string
+	interval time.Duration
+
+	mu     sync.Mutex
+	cp     Checkpoint
+	resume *PartialRun // Loaded from the file, until its run picks it up
+	run    *checkpointRun
+}
+
+// checkpointRun tracks the benchmark run in progress.
+type checkpointRun struct {
+	c         *checkpointer
+	seq       int
+	name      string
+	start     time.Time
+	base      PartialRun // Done before the resume, if any
+	tracker   *LatencyTracker
+	ops       *int64
+	bytesRead *int64
+	written   *int64
+	errors    *int64
+	schedules []*OpSchedule
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// openCheckpoint loads config's -resume_file if there is one, taking its
+// seed so the resumed suite generates the same keys, or starts a new one.
+// It refuses a file saved for a different workload.
+func openCheckpoint(config *BenchmarkConfig) (*checkpointer, error) {
+	c := &checkpointer{path: config.ResumeFile, interval: config.ResumeInterval}
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		c.cp = Checkpoint{Fingerprint: workloadFingerprint(config), Seed: config.Seed, StartedAt: time.Now()}
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, &c.cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", c.path, err)
+	}
+	if c.cp.Fingerprint != workloadFingerprint(config) {
+		return nil, fmt.Errorf("checkpoint %s was saved for a different workload; remove it to start over", c.path)
+	}
+
+	config.Seed = c.cp.Seed
+	c.resume = c.cp.Current
+	fmt.Printf("Resuming from checkpoint %s saved %s: %d benchmark runs done\n",
+		c.path, c.cp.SavedAt.Format(time.RFC3339), len(c.cp.Completed))
+	return c, nil
+}
+
+// startedAt returns when the suite first started.
+func (c *checkpointer) startedAt() time.Time {
+	return c.cp.StartedAt
+}
+
+// completed returns the result of benchmark run seq if it finished before
+// the checkpoint.
+func (c *checkpointer) completed(seq int) *BenchmarkResult {
+	if c == nil || seq >= len(c.cp.Completed) {
+		return nil
+	}
+	return c.cp.Completed[seq]
+}
+
+// resumed reports whether the suite picks up from a saved checkpoint.
+func (c *checkpointer) resumed() bool {
+	return c != nil && (len(c.cp.Completed) > 0 || c.resume != nil)
+}
+
+// resuming reports whether benchmark run seq was in progress when the
+// checkpoint was saved, so its database holds the work done so far.
+func (c *checkpointer) resuming(seq int) bool {
+	return c != nil && c.resume != nil && c.resume.Seq == seq
+}
+
+// complete records a finished benchmark run and saves.
+func (c *checkpointer) complete(result *BenchmarkResult) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cp.Completed = append(c.cp.Completed, result)
+	c.cp.Current = nil
+	c.save()
+}
+
+// finish removes the file once the whole suite has run. A stopped suite
+// keeps it, so it can be resumed.
+func (c *checkpointer) finish() {
+	if c == nil || stopRequested.Load() {
+		return
+	}
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to remove checkpoint: %v", err)
+	}
+}
+
+// begin starts tracking the next benchmark run, saving its progress every
+// interval until stop. A run matching the checkpoint's partial run picks
+// up its latencies into tracker; the rest is added by finish.
+func (c *checkpointer) begin(name string, tracker *LatencyTracker, ops, bytesRead, bytesWritten, errs *int64) *checkpointRun {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	run := &checkpointRun{
+		c:         c,
+		seq:       len(c.cp.Completed),
+		name:      name,
+		start:     time.Now(),
+		tracker:   tracker,
+		ops:       ops,
+		bytesRead: bytesRead,
+		written:   bytesWritten,
+		errors:    errs,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if r := c.resume; r != nil && r.Seq == run.seq && r.Name == name {
+		run.base = *r
+		if r.Latency != nil {
+			h := newHdrHistogram()
+			r.Latency.mergeInto(h)
+			tracker.restore(h)
+		}
+		fmt.Printf("Resuming %s: %d operations done in %s before the checkpoint\n",
+			name, r.Operations, formatDuration(r.Elapsed))
+	}
+	c.resume = nil
+	c.run = run
+
+	go func() {
+		defer close(run.done)
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.mu.Lock()
+				c.cp.Current = run.partial()
+				c.save()
+				c.mu.Unlock()
+			case <-run.stop:
+				return
+			}
+		}
+	}()
+
+	return run
+}
+
+// schedule registers a new OpSchedule with the run in progress and returns
+// how many operations each of its threads had done before the checkpoint.
+// Schedules are matched by the order they are created in.
+func (c *checkpointer) schedule(s *OpSchedule) []int64 {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	run := c.run
+	if run == nil {
+		return nil
+	}
+	n := len(run.schedules)
+	run.schedules = append(run.schedules, s)
+	if n < len(run.base.Schedules) {
+		return run.base.Schedules[n]
+	}
+	return nil
+}
+
+// partial captures the run's progress. Call it with c.mu held.
+func (run *checkpointRun) partial() *PartialRun {
+	p := &PartialRun{
+		Seq:          run.seq,
+		Name:         run.name,
+		Elapsed:      run.base.Elapsed + time.Since(run.start),
+		Operations:   run.base.Operations + atomic.LoadInt64(run.ops),
+		BytesRead:    run.base.BytesRead + atomic.LoadInt64(run.bytesRead),
+		BytesWritten: run.base.BytesWritten + atomic.LoadInt64(run.written),
+		Errors:       run.base.Errors + atomic.LoadInt64(run.errors),
+		Latency:      newLatencyCounts(run.tracker.snapshot()),
+	}
+	for _, s := range run.schedules {
+		p.Schedules = append(p.Schedules, s.Progress())
+	}
+	return p
+}
+
+// finish stops saving and adds what was done before the resume to the
+// run's counters and duration.
+func (run *checkpointRun) finish(duration *time.Duration, ops, bytesRead, bytesWritten, errs *int64) {
+	if run == nil {
+		return
+	}
+	close(run.stop)
+	<-run.done
+
+	run.c.mu.Lock()
+	run.c.run = nil
+	run.c.mu.Unlock()
+
+	*duration += run.base.Elapsed
+	*ops += run.base.Operations
+	*bytesRead += run.base.BytesRead
+	*bytesWritten += run.base.BytesWritten
+	*errs += run.base.Errors
+}
+
+// save writes the checkpoint to a temporary file, syncs it and renames it
+// over the old one, so a crash leaves either. Call it with c.mu held.
+func (c *checkpointer) save() {
+	c.cp.SavedAt = time.Now()
+	data, err := json.Marshal(&c.cp)
+	if err != nil {
+		log.Printf("Failed to save checkpoint: %v", err)
+		return
+	}
+
+	tmp := c.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err == nil {
+		_, err = f.Write(data)
+		if err == nil {
+			err = f.Sync()
+		}
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err == nil {
+		err = os.Rename(tmp, c.path)
+	}
+	if err != nil {
+		log.Printf("Failed to save checkpoint: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/wildcatdb/wildcat/v2"
+)
+
+// DirShape is the file count and size of one directory of the database,
+// which for WildcatDB is one level of SSTables.
+type DirShape struct {
+	Name  string
+	Files int
+	Bytes int64
+}
+
+// CompactRun is what compact and compactwait measured. The flush and the
+// compaction that follows count as one operation whose latency is Elapsed,
+// and the bytes rewritten as bytes written, so both show in every output.
+type CompactRun struct {
+	Settled    bool          // Whether the size held still within -settle_timeout
+	Elapsed    time.Duration // From the flush until the size held still
+	Rewritten  int64         // Bytes in the files written meanwhile, compact only
+	NewFiles   int           // Files written meanwhile, compact only
+	SizeBefore int64         // On-disk size before the flush
+	SizeAfter  int64         // On-disk size once settled
+	Before     []DirShape    `json:",omitempty"` // Per-level shape before, compact only
+	After      []DirShape    `json:",omitempty"` // Per-level shape after, compact only
+}
+
+// record counts cr as one operation of latency Elapsed.
+func (cr *CompactRun) record(tracker *LatencyTracker, opsCompleted *int64) {
+	tracker.Record(0, cr.Elapsed)
+	atomic.AddInt64(opsCompleted, 1)
+}
+
+// runCompact flushes the memtable and waits for background compaction to
+// finish, as compactwait does, then reports how long it took, how much it
+// wrote and the per-level shape before and after. WildcatDB has no call to
+// compact on demand, so the bytes rewritten are estimated from the files
+// that appeared while it ran.
+func runCompact(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64, cr *CompactRun) {
+
+	cr.Before = dbShape(config.DBPath)
+	cr.SizeBefore = dirSize(config.DBPath)
+
+	start := time.Now()
+	if err := db.ForceFlush(); err != nil {
+		countError(errors, 1, err)
+	}
+
+	existing := fileSizes(config.DBPath)
+	written := make(map[string]int64)
+	sample := func() {
+		for path, size := range fileSizes(config.DBPath) {
+			if _, ok := existing[path]; !ok {
+				written[path] = max(written[path], size)
+			}
+		}
+	}
+
+	_, cr.Settled = waitForSettle(config, sample)
+	sample()
+	cr.Elapsed = time.Since(start)
+
+	for _, size := range written {
+		cr.Rewritten += size
+	}
+	cr.NewFiles = len(written)
+	cr.SizeAfter = dirSize(config.DBPath)
+	cr.After = dbShape(config.DBPath)
+	cr.record(tracker, opsCompleted)
+	atomic.AddInt64(bytesWritten, cr.Rewritten)
+
+	if cr.Settled {
+		fmt.Printf("Compaction finished after %s\n", cr.Elapsed.Round(time.Millisecond))
+	} else {
+		fmt.Printf("Compaction did not settle within %s\n", config.SettleTimeout)
+	}
+	fmt.Printf("  Bytes rewritten: %s in %d new files\n", formatBytes(cr.Rewritten), cr.NewFiles)
+	fmt.Printf("  On-disk: %s -> %s\n", formatBytes(cr.SizeBefore), formatBytes(cr.SizeAfter))
+
+	printShapes(cr.Before, cr.After)
+}
+
+// dbShape returns the shape of each subdirectory of dir, in name order.
+func dbShape(dir string) []DirShape {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var shapes []DirShape
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		shape := DirShape{Name: entry.Name()}
+		for _, size := range fileSizes(filepath.Join(dir, entry.Name())) {
+			shape.Files++
+			shape.Bytes += size
+		}
+		shapes = append(shapes, shape)
+	}
+
+	return shapes
+}
+
+// fileSizes maps every regular file under dir to its size, skipping files
+// removed mid-walk as dirSize does.
+func fileSizes(dir string) map[string]int64 {
+	sizes := make(map[string]int64)
+
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		sizes[path] = info.Size()
+		return nil
+	})
+
+	return sizes
+}
+
+func printShapes(before, after []DirShape) {
+	names := make(map[string]bool)
+	byName := func(shapes []DirShape) map[string]DirShape {
+		m := make(map[string]DirShape)
+		for _, s := range shapes {
+			m[s.Name] = s
+			names[s.Name] = true
+		}
+		return m
+	}
+	b, a := byName(before), byName(after)
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	fmt.Printf("  %-12s %8s %12s %8s %12s\n", "Level", "Files", "Before", "Files", "After")
+	for _, name := range sorted {
+		fmt.Printf("  %-12s %8d %12s %8d %12s\n", name,
+			b[name].Files, formatBytes(b[name].Bytes),
+			a[name].Files, formatBytes(a[name].Bytes))
+	}
+}
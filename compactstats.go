@@ -0,0 +1,255 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CompactionStats is the flush and compaction activity in a WildcatDB
+// database during a benchmark, read from the SSTable files in its level
+// directories. Flushes write SSTables to level 1, compaction to the deeper
+// levels, through a temporary file renamed once complete; the sampler counts
+// SSTables by name, sums the size of the ones compaction wrote, and counts a
+// sample as compacting while a temporary file is being written below level
+// 1. Latency is then compared between the -latency_window windows that
+// overlapped compaction and those that did not.
+type CompactionStats struct {
+	Flushes     int64         // SSTables written to level 1
+	Compactions int64         // SSTables written to deeper levels by compaction
+	Removed     int64         // SSTables removed after being compacted
+	Bytes       int64         // Bytes compaction wrote, as sampled
+	Active      time.Duration // Time compaction was seen writing, in whole sampling intervals
+
+	TailPercentile float64       // The highest -percentiles, compared below
+	WindowsDuring  int           // Latency windows in which compaction was seen writing
+	WindowsOutside int           // Latency windows in which it was not
+	TailDuring     time.Duration // Mean TailPercentile latency of the windows during compaction
+	TailOutside    time.Duration // Mean TailPercentile latency of the other windows
+}
+
+// sstFile is a file of one SSTable, named by its level directory and final
+// file name.
+type sstFile struct {
+	level string
+	name  string
+}
+
+// CompactionSampler follows the SSTables of a WildcatDB directory across a
+// benchmark. A nil *CompactionSampler, for the other engines, samples
+// nothing.
+type CompactionSampler struct {
+	dir      string
+	interval time.Duration
+	start    time.Time
+	before   map[sstFile]int64
+	seen     map[sstFile]int64 // Largest size sampled of each file
+	active   []time.Duration   // Offsets of the samples that caught compaction writing
+	stop     chan bool
+	done     chan bool
+}
+
+// sstFiles lists the SSTable files in the level directories of dir, with
+// temporary files under their final name, and reports whether compaction is
+// writing one.
+func sstFiles(dir string) (map[sstFile]int64, bool) {
+	files := make(map[sstFile]int64)
+	compacting := false
+
+	for path, size := range fileSizes(dir) {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			continue
+		}
+		level, name, ok := strings.Cut(rel, string(filepath.Separator))
+		if !ok || !strings.HasPrefix(name, "sst_") {
+			continue
+		}
+		if tmp, isTmp := strings.CutSuffix(name, ".tmp"); isTmp {
+			name = tmp
+			compacting = compacting || level != "l1"
+		}
+		f := sstFile{level: level, name: name}
+		files[f] = max(files[f], size)
+	}
+
+	return files, compacting
+}
+
+// StartCompactionSampler lists the SSTables in dir now and then every
+// interval, which when zero leaves only the listings at the start and end.
+func StartCompactionSampler(dir string, interval time.Duration) *CompactionSampler {
+	cs := &CompactionSampler{
+		dir:      dir,
+		interval: interval,
+		start:    time.Now(),
+		seen:     make(map[sstFile]int64),
+		stop:     make(chan bool),
+		done:     make(chan bool),
+	}
+	cs.before, _ = sstFiles(dir)
+
+	go func() {
+		defer close(cs.done)
+		if interval <= 0 {
+			<-cs.stop
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				cs.sample()
+			case <-cs.stop:
+				return
+			}
+		}
+	}()
+
+	return cs
+}
+
+func (cs *CompactionSampler) sample() map[sstFile]int64 {
+	files, compacting := sstFiles(cs.dir)
+	for f, size := range files {
+		cs.seen[f] = max(cs.seen[f], size)
+	}
+	if compacting {
+		cs.active = append(cs.active, time.Since(cs.start))
+	}
+	return files
+}
+
+// Stop ends sampling and returns the activity since the start, comparing
+// latency across windows, the benchmark's -latency_window series.
+func (cs *CompactionSampler) Stop(windows []LatencyWindow) *CompactionStats {
+	if cs == nil {
+		return nil
+	}
+	cs.stop <- true
+	<-cs.done
+
+	end := cs.sample()
+	stats := &CompactionStats{Active: time.Duration(len(cs.active)) * cs.interval}
+
+	for f, size := range cs.seen {
+		if _, ok := cs.before[f]; ok {
+			continue
+		}
+		if f.level != "l1" {
+			stats.Bytes += size
+		}
+		if strings.HasSuffix(f.name, ".klog") {
+			if f.level == "l1" {
+				stats.Flushes++
+			} else {
+				stats.Compactions++
+			}
+		}
+	}
+	for f := range cs.before {
+		cs.seen[f] = 0
+	}
+	for f := range cs.seen {
+		if _, ok := end[f]; !ok && strings.HasSuffix(f.name, ".klog") {
+			stats.Removed++
+		}
+	}
+
+	// Without samples in between there is no telling which windows compaction
+	// overlapped
+	if cs.interval > 0 {
+		stats.compareWindows(windows, cs.active)
+	}
+	return stats
+}
+
+// compareWindows splits the latency windows by whether a sample in active
+// fell in them and averages the highest percentile of each side.
+func (stats *CompactionStats) compareWindows(windows []LatencyWindow, active []time.Duration) {
+	var during, outside time.Duration
+	var windowStart time.Duration
+	next := 0
+
+	for _, w := range windows {
+		if len(w.Percentiles) == 0 || w.Operations == 0 {
+			windowStart = w.End
+			continue
+		}
+		tail := w.Percentiles[len(w.Percentiles)-1]
+		stats.TailPercentile = tail.Percentile
+
+		compacting := false
+		for next < len(active) && active[next] <= w.End {
+			compacting = compacting || active[next] > windowStart
+			next++
+		}
+		windowStart = w.End
+
+		if compacting {
+			stats.WindowsDuring++
+			during += tail.Latency
+		} else {
+			stats.WindowsOutside++
+			outside += tail.Latency
+		}
+	}
+
+	if stats.WindowsDuring > 0 {
+		stats.TailDuring = during / time.Duration(stats.WindowsDuring)
+	}
+	if stats.WindowsOutside > 0 {
+		stats.TailOutside = outside / time.Duration(stats.WindowsOutside)
+	}
+}
+
+func printCompactionStats(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		c := result.Compaction
+		if c == nil || c.Flushes+c.Compactions+c.Removed == 0 {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Compaction\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %8s %12s %8s %12s %10s %16s %14s %14s\n",
+				"Test", "Flushes", "Compactions", "Removed", "Written", "Active", "Windows (comp)", "Tail during", "Tail outside")
+			printed = true
+		}
+
+		during, outside := "-", "-"
+		if c.WindowsDuring > 0 {
+			during = fmt.Sprintf("P%g %s", c.TailPercentile, formatDuration(c.TailDuring))
+		}
+		if c.WindowsOutside > 0 {
+			outside = fmt.Sprintf("P%g %s", c.TailPercentile, formatDuration(c.TailOutside))
+		}
+
+		fmt.Printf("%-25s %8d %12d %8d %12s %10s %16s %14s %14s\n",
+			result.Label(), c.Flushes, c.Compactions, c.Removed, formatBytes(c.Bytes), formatDuration(c.Active),
+			fmt.Sprintf("%d/%d", c.WindowsDuring, c.WindowsDuring+c.WindowsOutside), during, outside)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// compareMetric is one row of the compare subcommand's table, averaged over
+// the repeated runs of a benchmark.
+type compareMetric struct {
+	key    string // Name for the trend subcommand's -metric
+	name   string
+	value  func(r *BenchmarkResult) float64
+	format func(v float64) string
+}
+
+var compareMetrics = []compareMetric{
+	{"ops", "Ops/sec", opsPerSecond, formatRate},
+	{"p50", "P50", func(r *BenchmarkResult) float64 { return float64(r.LatencyP50) }, formatLatency},
+	{"p95", "P95", func(r *BenchmarkResult) float64 { return float64(r.LatencyP95) }, formatLatency},
+	{"p99", "P99", func(r *BenchmarkResult) float64 { return float64(r.LatencyP99) }, formatLatency},
+	{"max", "Max", func(r *BenchmarkResult) float64 { return float64(r.LatencyMax) }, formatLatency},
+	{"bytes_read", "Bytes read", func(r *BenchmarkResult) float64 { return float64(r.BytesRead) }, formatByteCount},
+	{"bytes_written", "Bytes written", func(r *BenchmarkResult) float64 { return float64(r.BytesWritten) }, formatByteCount},
+	{"errors", "Errors", func(r *BenchmarkResult) float64 { return float64(r.Errors) }, formatCount},
+}
+
+func opsPerSecond(r *BenchmarkResult) float64 { return r.OpsPerSecond }
+
+func formatRate(v float64) string      { return fmt.Sprintf("%.2f", v) }
+func formatLatency(v float64) string   { return formatDuration(time.Duration(v)) }
+func formatByteCount(v float64) string { return formatBytes(int64(v)) }
+func formatCount(v float64) string     { return fmt.Sprintf("%.0f", v) }
+
+// runCompare runs the compare subcommand: it loads two results files and
+// prints how each benchmark found in both changed from the first to the
+// second, without running anything.
+func runCompare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	sigTest := fs.String("sig_test", "welch", "Significance test for throughput across repeated runs: welch, mannwhitney")
+	sigAlpha := fs.Float64("sig_alpha", 0.05, "Significance level for -sig_test")
+	force := fs.Bool("force", false, "Compare benchmarks even if their configuration fingerprints differ")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s compare [flags] old.json new.json\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if !slices.Contains(sigTests, *sigTest) {
+		log.Fatalf("Invalid significance test: %s (must be one of %s)", *sigTest, strings.Join(sigTests, ", "))
+	}
+
+	var files [2]*ResultsFile
+	for i := range files {
+		rf, err := readResultsFile(fs.Arg(i))
+		if err != nil {
+			log.Fatalf("Failed to load results file: %v", err)
+		}
+		files[i] = rf
+	}
+	if !*force {
+		checkFingerprints(files[0], files[1])
+	}
+
+	nameA, nameB := filepath.Base(fs.Arg(0)), filepath.Base(fs.Arg(1))
+	if nameA == nameB {
+		nameA, nameB = fs.Arg(0), fs.Arg(1)
+	}
+
+	fmt.Printf("Comparison\n")
+	fmt.Printf("==========\n")
+	for i, rf := range files {
+		fmt.Printf("  %s: %s, revision %q, wildcat %s\n", fs.Arg(i),
+			rf.StartedAt.Format(time.RFC3339), rf.Version.VCSRevision, rf.Version.WildcatVersion)
+		if rf.Host.OS != "" {
+			fmt.Printf("    %s\n    %s\n", rf.Host, rf.Host.FilesystemString())
+		}
+	}
+	printHostMismatch(files[0].Host, files[1].Host)
+	fmt.Printf("\n")
+
+	printComparison(nameA, nameB, files[0].Results, files[1].Results, *sigTest, *sigAlpha)
+}
+
+// checkFingerprints exits if any benchmark found in both files ran with a
+// different configuration in each, naming the options that differ.
+func checkFingerprints(a, b *ResultsFile) {
+	before, after := groupResults(a.Results), groupResults(b.Results)
+
+	var mismatched []string
+	for _, label := range before.order {
+		if runs := after.runs[label]; runs != nil {
+			if msg := fingerprintMismatch(before.runs[label], runs); msg != "" {
+				mismatched = append(mismatched, label+": "+msg)
+			}
+		}
+	}
+	if len(mismatched) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Results were produced by different configurations:\n")
+	for _, m := range mismatched {
+		fmt.Fprintf(os.Stderr, "  %s\n", m)
+	}
+	if a.Config != nil && b.Config != nil {
+		if diff := configDiff(a.Config, b.Config); len(diff) > 0 {
+			fmt.Fprintf(os.Stderr, "Differing options: %s\n", strings.Join(diff, ", "))
+		}
+	}
+	log.Fatalf("Refusing to compare; pass -force to compare anyway")
+}
+
+// printComparison prints how each benchmark found in both a and b changed
+// from a to b, with whether the change in throughput is significant, then
+// lists the benchmarks found in only one of them.
+func printComparison(nameA, nameB string, a, b []*BenchmarkResult, sigTest string, sigAlpha float64) {
+	before, after := groupResults(a), groupResults(b)
+	width := max(len(nameA), len(nameB), 12)
+
+	for _, label := range before.order {
+		a, b := before.runs[label], after.runs[label]
+		if b == nil {
+			continue
+		}
+
+		fmt.Printf("%s (%d vs %d runs)\n", label, len(a), len(b))
+		if msg := fingerprintMismatch(a, b); msg != "" {
+			fmt.Printf("  Warning: %s\n", msg)
+		}
+		fmt.Printf("  %-14s %*s %*s %9s\n", "Metric", width, nameA, width, nameB, "Change")
+		for _, m := range compareMetrics {
+			va, vb := meanOf(a, m.value), meanOf(b, m.value)
+			fmt.Printf("  %-14s %*s %*s %9s\n", m.name, width, m.format(va), width, m.format(vb), percentChange(va, vb))
+		}
+
+		p, verdict := formatPValue(significance(sigTest, opsOf(a), opsOf(b)), sigAlpha)
+		fmt.Printf("  Throughput change significant: %s (%s p-value %s, alpha %g)\n\n", verdict, sigTest, p, sigAlpha)
+	}
+
+	for _, label := range before.order {
+		if after.runs[label] == nil {
+			fmt.Printf("%s: only in %s\n", label, nameA)
+		}
+	}
+	for _, label := range after.order {
+		if before.runs[label] == nil {
+			fmt.Printf("%s: only in %s\n", label, nameB)
+		}
+	}
+}
+
+// resultGroups holds the runs of each benchmark by label, in the order the
+// labels first appear.
+type resultGroups struct {
+	order []string
+	runs  map[string][]*BenchmarkResult
+}
+
+func groupResults(results []*BenchmarkResult) resultGroups {
+	g := resultGroups{runs: make(map[string][]*BenchmarkResult)}
+	for _, r := range results {
+		label := r.SweepLabel()
+		if _, ok := g.runs[label]; !ok {
+			g.order = append(g.order, label)
+		}
+		g.runs[label] = append(g.runs[label], r)
+	}
+	return g
+}
+
+func meanOf(runs []*BenchmarkResult, value func(r *BenchmarkResult) float64) float64 {
+	var sum float64
+	for _, r := range runs {
+		sum += value(r)
+	}
+	return sum / float64(len(runs))
+}
+
+func opsOf(runs []*BenchmarkResult) []float64 {
+	ops := make([]float64, len(runs))
+	for i, r := range runs {
+		ops[i] = r.OpsPerSecond
+	}
+	return ops
+}
@@ -0,0 +1,1107 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type BenchmarkConfig struct {
+	// Database configuration
+	Engine            string // Store the workloads run against: wildcat, or one compiled in with a build tag
+	DBPath            string
+	WriteBufferSize   int64
+	SyncOption        string
+	LevelCount        int
+	BloomFilter       bool
+	MaxCompactionConc int
+	Compression       string // Block compression for the engines that have it: none, snappy, zstd; "" = engine default
+	ReadOnly          bool   // Run read benchmarks against an existing database without writing to it
+	UseExistingDB     bool   // Skip fills and cleanup, reading the dataset described by the database's manifest
+	WarmCache         string // Cache state before each read benchmark: none, scan, keys, cold
+
+	// WildcatDB tuning, each 0 or false leaves WildcatDB's default
+	SyncInterval                time.Duration // Background sync interval with -sync=partial
+	LevelMultiplier             int
+	BlockManagerLRUSize         int
+	BlockManagerLRUEvictRatio   float64
+	BlockManagerLRUAccessWeight float64
+	CompactionCooldown          time.Duration
+	CompactionBatchSize         int
+	CompactionSizeRatio         float64
+	CompactionSizeThreshold     int
+	CompactionScoreSizeWeight   float64
+	CompactionScoreCountWeight  float64
+	CompactionSimilarityRatio   float64       // Size-tiered similarity ratio
+	CompactionReadWaitBackoff   time.Duration // Backoff while an SSTable being compacted is still read
+	CompactionPartitionRatio    float64
+	CompactionPartitionDist     float64 // Partition distribution ratio
+	CompactorTick               time.Duration
+	FlusherTick                 time.Duration
+	BloomFilterFPR              float64
+	WalAppendRetry              int
+	WalAppendBackoff            time.Duration
+	SSTableBTreeOrder           int
+	MaxConcurrentTxns           int
+	TxnBeginRetry               int
+	TxnBeginBackoff             time.Duration
+	TxnBeginMaxBackoff          time.Duration
+	RecoverUncommittedTxns      bool
+	DBPermission                uint // File mode for database files and directories
+	DBLogging                   bool // Let WildcatDB log to stdout
+
+	// Benchmark parameters
+	NumOperations  int64
+	Duration       time.Duration // Run each benchmark for this long instead of NumOperations ops
+	TargetRate     float64       // Offered load cap in ops/sec across all threads, 0 = unthrottled
+	CorrectLatency bool          // Measure rate-limited latency from the intended start time
+	OpenLoop       bool          // Issue operations from a scheduler into a queue instead of per-thread loops
+	QueueDepth     int           // Open-loop queue capacity
+	KeySize        int
+	ValueSize      int
+	AppendSize     int // Chunk appended per appendrandom operation (0 = ValueSize)
+	LargeValueSize int // Value size for filllarge
+	NumThreads     int
+	BatchSize      int
+	TxnOps         int           // Puts per long_txn transaction
+	TxnHold        time.Duration // How long long_txn and snapshotisolation keep each transaction open
+	TxnRetries     int           // Retries of a conflicted transaction before it is abandoned
+	TxnBackoff     time.Duration // Upper bound of the first retry's random backoff, doubled per retry
+
+	// Test types
+	Benchmarks   []BenchmarkSpec
+	ReadRatio    int     // For mixed workloads (0-100)
+	MissRatio    int     // Percentage of readseq/readrandom lookups aimed at absent keys (0-100)
+	SeekNexts    int     // Next calls after each seekrandom seek
+	ScanLength   int     // Keys read per scanwhilewriting scan
+	HotReadRatio float64 // Percentage of the newest keys readhot reads from
+
+	// Data distribution
+	KeyDistribution string          // sequential, random, zipfian, hotspot
+	KeySpace        int64           // Key universe for the access distributions (0 = ExistingKeys)
+	HotOpRatio      int             // hotspot: percentage of operations aimed at the hot set
+	HotKeyRatio     int             // hotspot: percentage of the key space in the hot set
+	ExistingKeys    int64           // Number of existing keys for read tests
+	MaxKeySize      int             // Longest key used by boundary key tests
+	AgeBuckets      []time.Duration // Data age boundaries for read latency bucketing
+
+	// Reporting
+	ReportInterval     time.Duration
+	TUI                bool // Live terminal dashboard in place of the progress reports
+	Histogram          bool
+	Stats              bool
+	ResultsFile        string
+	History            string        // Append-only JSONL store every run's results are added to
+	OutputFormat       string        // text, json, csv, markdown
+	OutputFile         string        // Destination for -output, stdout when empty
+	HTMLReport         string        // Self-contained HTML report path
+	TimelineFile       string        // CSV of per-second op counts for every benchmark
+	LatencyWindow      time.Duration // Length of each latency-over-time window, 0 disables
+	LatencySeries      string        // CSV of per-window latency percentiles for every benchmark
+	HeatmapFile        string        // Per-window latency bucket counts for heatmaps
+	HeatmapFormat      string        // hlog, csv
+	SpaceInterval      time.Duration // Database size sampling interval during a benchmark, 0 disables
+	WALInterval        time.Duration // WAL file sampling interval during a WildcatDB benchmark, 0 = start and end only
+	CompactionInterval time.Duration // SSTable sampling interval during a WildcatDB benchmark, 0 = start and end only
+	LatencyDump        string        // Directory for raw per-operation latency dumps
+	LatencyDumpFmt     string        // csv, bin
+	Percentiles        []float64     // Latency percentiles reported in every output, ascending
+	InfluxURL          string        // InfluxDB line protocol write endpoint
+	InfluxToken        string
+	OTLPEndpoint       string // OpenTelemetry collector OTLP/HTTP base URL
+	OTLPHeaders        string // Comma-separated key=value headers sent with OTLP requests
+	ExportInterval     time.Duration
+
+	// Notifications
+	NotifyURL        string  // Webhook posted when the suite finishes or a threshold is breached
+	NotifyFormat     string  // json, slack
+	NotifyErrorRate  float64 // Percentage of a benchmark's operations failing that triggers a notification, 0 disables
+	NotifyRegression float64 // Percentage drop in ops/sec against -baseline that triggers a notification, 0 disables
+
+	// Profiling
+	CPUProfileDir      string        // Directory for one pprof CPU profile per benchmark
+	MemProfileDir      string        // Directory for pprof heap profiles taken after each benchmark
+	MemProfilePeak     bool          // Also keep a heap profile from each benchmark's peak heap
+	MutexProfileDir    string        // Directory for pprof mutex contention profiles per benchmark
+	MutexProfileRate   int           // runtime.SetMutexProfileFraction while a benchmark runs
+	BlockProfileDir    string        // Directory for pprof blocking profiles per benchmark
+	BlockProfileRate   int           // runtime.SetBlockProfileRate while a benchmark runs, in nanoseconds
+	ExecTrace          string        // runtime/trace output file
+	ExecTraceBenchmark string        // Benchmark to trace, the first one run when empty
+	ExecTraceDelay     time.Duration // How far into the benchmark the trace starts
+	ExecTraceDuration  time.Duration // How long the trace runs, until the benchmark ends when 0
+
+	// Space reclamation
+	DeleteRatio     int           // Percentage of filled keys deleted by spacereclaim (0-100)
+	ReclaimWait     time.Duration // How long spacereclaim tracks on-disk size after deleting
+	ReclaimInterval time.Duration // On-disk size sampling interval for spacereclaim
+
+	// Endurance mode
+	EnduranceDuration  time.Duration // Total wall-clock time to keep cycling the benchmarks
+	CheckpointInterval time.Duration // How often a checkpointed results file is written
+	EnduranceSample    time.Duration // Resource timeline sampling interval
+	EnduranceDir       string        // Directory for checkpoints, timeline and summaries
+
+	// Checkpoint and resume
+	ResumeFile     string        // Save the suite's progress here, and resume from it if present
+	ResumeInterval time.Duration // How often a running benchmark's progress is saved to ResumeFile
+
+	// Trace replay
+	TraceFile   string  // Trace replayed by the replay benchmark
+	TraceTiming bool    // Honour the trace's inter-arrival times
+	TraceSpeed  float64 // Replay speed multiplier for TraceTiming
+	RecordTrace string  // Write every point operation issued to this trace file
+
+	// Repetition
+	Repeat      int    // Runs of each benchmark, summarized when more than one
+	RepeatFresh bool   // Delete the database before every run
+	FreshDB     string // When the database is deleted: never, per_suite, per_benchmark
+
+	// Parameter sweeps
+	Sweep     string      // name=v1,v2;name=... axes, run as a Cartesian product
+	SweepAxes []SweepAxis `json:"-"`
+
+	// A/B comparison
+	ABVariantA string      // Flag values for variant A, name=value,...
+	ABVariantB string      // Flag values for variant B
+	ABVariants []ABVariant `json:"-"`
+
+	// Significance testing
+	Baseline string  // Results file to compare this run's throughput against
+	SigTest  string  // Test for differences between repeated runs: welch, mannwhitney
+	SigAlpha float64 // Significance level
+
+	// Pipelines
+	Pipeline      bool          // Run the benchmarks as phases against one open database
+	SettleQuiet   time.Duration // How long the on-disk size must hold still for compactwait
+	SettleTimeout time.Duration // Longest compactwait waits for compaction to settle
+
+	// Recovery
+	ReopenSizes      []int64       // Key counts the reopen benchmark fills and reopens
+	CrashAfter       time.Duration // How long the crash writer runs before it is killed
+	CrashSync        string        // Comma-separated sync options the crash subcommand tests
+	DurabilityTrials int           // Kills per sync option in the durability subcommand
+
+	// Workload file
+	ConfigFile string // YAML, TOML or JSON workload file applied beneath CLI flags
+
+	// Advanced options
+	UseTransactions  bool
+	IteratorTests    bool
+	CompressibleData bool
+	Verify           bool // Store values with a checksum and check every value read
+	Validate         bool // Read back every key a fill benchmark wrote; implies Verify
+	CheckConsistency bool // Check reads against the writes acknowledged before them
+	CheckLostWrites  bool // Check the contention benchmarks kept every committed write
+	CheckIterators   bool // Check the iterator benchmarks return keys in order and none missing
+	CheckTimestamps  bool // Check the MVCC timestamps WildcatDB's iterators return
+	Seed             int64
+
+	// Cleanup
+	CleanupAfter bool
+}
+
+// BenchmarkSpec is one entry of the benchmark list: the benchmark to run and
+// the flag values overridden for it alone.
+type BenchmarkSpec struct {
+	Name   string
+	Params map[string]string `json:",omitempty"`
+	Sweep  string            `json:"-"` // Sweep point this run belongs to
+}
+
+// String renders the spec in the -benchmarks syntax, name(key=value,...).
+func (bs BenchmarkSpec) String() string {
+	if len(bs.Params) == 0 {
+		return bs.Name
+	}
+
+	keys := make([]string, 0, len(bs.Params))
+	for k := range bs.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, len(keys))
+	for i, k := range keys {
+		v := bs.Params[k]
+		if v == "" || strings.ContainsAny(v, ",()=\" \t") {
+			v = strconv.Quote(v)
+		}
+		params[i] = k + "=" + v
+	}
+	return bs.Name + "(" + strings.Join(params, ",") + ")"
+}
+
+// parseBenchmarkSpecs parses a -benchmarks list such as
+// "fillseq,readrandom(num=1e6,threads=32)". Values containing commas or
+// parentheses can be double-quoted.
+func parseBenchmarkSpecs(s string) ([]BenchmarkSpec, error) {
+	var specs []BenchmarkSpec
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		end := strings.IndexAny(s, ",(")
+		if end < 0 {
+			end = len(s)
+		}
+		spec := BenchmarkSpec{Name: strings.TrimSpace(s[:end])}
+		s = s[end:]
+
+		if strings.HasPrefix(s, "(") {
+			params, rest, err := parseSpecParams(s[1:])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", spec.Name, err)
+			}
+			spec.Params = params
+			s = strings.TrimSpace(rest)
+		}
+
+		if spec.Name == "" {
+			if spec.Params != nil {
+				return nil, fmt.Errorf("parameters without a benchmark name")
+			}
+		} else {
+			specs = append(specs, spec)
+		}
+
+		if s != "" && !strings.HasPrefix(s, ",") {
+			return nil, fmt.Errorf("%s: expected ',' before %q", spec.Name, s)
+		}
+		s = strings.TrimPrefix(s, ",")
+	}
+	return specs, nil
+}
+
+// parseSpecParams parses "key=value,...)" and returns what follows the
+// closing parenthesis.
+func parseSpecParams(s string) (map[string]string, string, error) {
+	params := make(map[string]string)
+	for {
+		s = strings.TrimSpace(s)
+		if strings.HasPrefix(s, ")") {
+			return params, s[1:], nil
+		}
+
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			return nil, "", fmt.Errorf("expected key=value in %q", s)
+		}
+		key := strings.TrimSpace(s[:eq])
+		s = strings.TrimSpace(s[eq+1:])
+
+		var value string
+		if strings.HasPrefix(s, "\"") {
+			end := closingQuote(s)
+			if end < 0 {
+				return nil, "", fmt.Errorf("unterminated string in %s", key)
+			}
+			unquoted, err := strconv.Unquote(s[:end+1])
+			if err != nil {
+				return nil, "", fmt.Errorf("%s: %w", key, err)
+			}
+			value, s = unquoted, s[end+1:]
+		} else {
+			end := strings.IndexAny(s, ",)")
+			if end < 0 {
+				return nil, "", fmt.Errorf("missing ')'")
+			}
+			value, s = strings.TrimSpace(s[:end]), s[end:]
+		}
+
+		if _, dup := params[key]; dup {
+			return nil, "", fmt.Errorf("%s given more than once", key)
+		}
+		params[key] = value
+
+		s = strings.TrimSpace(s)
+		switch {
+		case strings.HasPrefix(s, ","):
+			s = s[1:]
+		case !strings.HasPrefix(s, ")"):
+			return nil, "", fmt.Errorf("missing ')'")
+		}
+	}
+}
+
+// closingQuote returns the index of the quote closing the string at the start
+// of s, or -1.
+func closingQuote(s string) int {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == '"' && s[i] == '\\':
+			i++
+		case s[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+func formatBenchmarkSpecs(specs []BenchmarkSpec) string {
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		names[i] = spec.String()
+	}
+	return strings.Join(names, ",")
+}
+
+// rawFlags holds flag values that are parsed into config fields after all
+// flags and the workload file have been applied.
+type rawFlags struct {
+	benchmarks  *string
+	ageBuckets  *string
+	reopenSizes *string
+	percentiles *string
+}
+
+func defaultConfig() *BenchmarkConfig {
+	return &BenchmarkConfig{
+		Engine:             "wildcat",
+		DBPath:             "/tmp/wildcat_bench",
+		WriteBufferSize:    64 * 1024 * 1024,
+		SyncOption:         "none",
+		LevelCount:         7,
+		BloomFilter:        true,
+		MaxCompactionConc:  4,
+		WarmCache:          "none",
+		FreshDB:            "never",
+		NumOperations:      10000,
+		KeySize:            16,
+		ValueSize:          100,
+		NumThreads:         runtime.NumCPU(),
+		BatchSize:          1,
+		Benchmarks:         defaultBenchmarks(),
+		ReadRatio:          50,
+		KeyDistribution:    "sequential",
+		HotOpRatio:         80,
+		HotKeyRatio:        20,
+		MaxKeySize:         1024,
+		AgeBuckets:         []time.Duration{time.Second, 10 * time.Second, time.Minute},
+		ReportInterval:     10 * time.Second,
+		Histogram:          true,
+		Stats:              true,
+		OutputFormat:       "text",
+		LatencyDumpFmt:     "csv",
+		Percentiles:        []float64{50, 95, 99},
+		ExportInterval:     time.Second,
+		NotifyFormat:       "json",
+		NotifyErrorRate:    1,
+		NotifyRegression:   10,
+		LatencyWindow:      time.Second,
+		HeatmapFormat:      "hlog",
+		SpaceInterval:      5 * time.Second,
+		MutexProfileRate:   1,
+		BlockProfileRate:   1,
+		DeleteRatio:        50,
+		ReclaimWait:        30 * time.Second,
+		ReclaimInterval:    time.Second,
+		EnduranceDuration:  24 * time.Hour,
+		CheckpointInterval: time.Hour,
+		ResumeInterval:     time.Minute,
+		EnduranceSample:    time.Minute,
+		EnduranceDir:       "endurance",
+		Seed:               time.Now().UnixNano(),
+		CleanupAfter:       true,
+		CorrectLatency:     true,
+		QueueDepth:         1024,
+		Repeat:             1,
+		SigTest:            "welch",
+		SigAlpha:           0.05,
+		HotReadRatio:       1,
+		ScanLength:         1000,
+		TxnOps:             10000,
+		TxnHold:            time.Second,
+		TxnBackoff:         time.Millisecond,
+		ReopenSizes:        []int64{10000, 100000, 1000000},
+		CrashAfter:         5 * time.Second,
+		CrashSync:          "none,partial,full",
+		DurabilityTrials:   5,
+		LargeValueSize:     100 << 10,
+		TraceSpeed:         1,
+		SettleQuiet:        5 * time.Second,
+		SettleTimeout:      5 * time.Minute,
+	}
+}
+
+func defaultBenchmarks() []BenchmarkSpec {
+	var specs []BenchmarkSpec
+	for _, name := range []string{"fillseq", "fillprefixed", "readseq", "readrandom", "iterseq", "iterrandom",
+		"iterprefix", "concurrent_writers", "high_contention_writes", "batch_concurrent_writes"} {
+		specs = append(specs, BenchmarkSpec{Name: name})
+	}
+	return specs
+}
+
+// registerFlags binds every flag to a field of config, using the field's
+// current value as the default. Registering on a copy of a resolved config
+// therefore preserves its values, which is how per-benchmark overrides are
+// applied.
+func registerFlags(fs *flag.FlagSet, config *BenchmarkConfig) *rawFlags {
+	raw := &rawFlags{}
+
+	// Database configuration
+	fs.StringVar(&config.Engine, "engine", config.Engine, "Store to benchmark: wildcat, badger, pebble or bolt (other than wildcat, build with -tags <engine>)")
+	fs.StringVar(&config.DBPath, "db", config.DBPath, "Database directory path")
+	fs.Int64Var(&config.WriteBufferSize, "write_buffer_size", config.WriteBufferSize, "Write buffer size in bytes")
+	fs.StringVar(&config.SyncOption, "sync", config.SyncOption, "Sync option: none, partial, full")
+	fs.IntVar(&config.LevelCount, "levels", config.LevelCount, "Number of LSM levels")
+	fs.BoolVar(&config.BloomFilter, "bloom_filter", config.BloomFilter, "Enable bloom filters")
+	fs.IntVar(&config.MaxCompactionConc, "max_compaction_concurrency", config.MaxCompactionConc, "Max compaction concurrency")
+	fs.BoolVar(&config.ReadOnly, "readonly", config.ReadOnly, "Open an existing database read-only and run only read benchmarks against it (kept after the run)")
+	fs.BoolVar(&config.UseExistingDB, "use_existing_db", config.UseExistingDB, "Run against the dataset an earlier run left in -db, checked against its manifest; skips fills and cleanup")
+	fs.StringVar(&config.WarmCache, "warm_cache", config.WarmCache, "Before each read benchmark: none, scan (iterate the whole database), keys (read every existing key), cold (drop the page cache, needs root)")
+	fs.StringVar(&config.Compression, "compression", config.Compression, "Block compression for -engine=badger or pebble: none, snappy, zstd (empty = engine default)")
+
+	// WildcatDB tuning
+	fs.DurationVar(&config.SyncInterval, "sync_interval", config.SyncInterval, "Background sync interval with -sync=partial (0 = WildcatDB default)")
+	fs.IntVar(&config.LevelMultiplier, "level_multiplier", config.LevelMultiplier, "Size multiplier between LSM levels (0 = WildcatDB default)")
+	fs.IntVar(&config.BlockManagerLRUSize, "block_manager_lru_size", config.BlockManagerLRUSize, "Block manager LRU cache entries (0 = WildcatDB default)")
+	fs.Float64Var(&config.BlockManagerLRUEvictRatio, "block_manager_lru_evict_ratio", config.BlockManagerLRUEvictRatio, "Fraction of the block manager LRU evicted when full (0 = WildcatDB default)")
+	fs.Float64Var(&config.BlockManagerLRUAccessWeight, "block_manager_lru_access_weight", config.BlockManagerLRUAccessWeight, "Weight of access count against recency in LRU eviction (0 = WildcatDB default)")
+	fs.DurationVar(&config.CompactionCooldown, "compaction_cooldown", config.CompactionCooldown, "Minimum time between compactions (0 = WildcatDB default)")
+	fs.IntVar(&config.CompactionBatchSize, "compaction_batch_size", config.CompactionBatchSize, "Max SSTables compacted at once (0 = WildcatDB default)")
+	fs.Float64Var(&config.CompactionSizeRatio, "compaction_size_ratio", config.CompactionSizeRatio, "Level size ratio that triggers compaction (0 = WildcatDB default)")
+	fs.IntVar(&config.CompactionSizeThreshold, "compaction_size_threshold", config.CompactionSizeThreshold, "SSTable count that triggers compaction (0 = WildcatDB default)")
+	fs.Float64Var(&config.CompactionScoreSizeWeight, "compaction_score_size_weight", config.CompactionScoreSizeWeight, "Weight of level size in the compaction score (0 = WildcatDB default)")
+	fs.Float64Var(&config.CompactionScoreCountWeight, "compaction_score_count_weight", config.CompactionScoreCountWeight, "Weight of SSTable count in the compaction score (0 = WildcatDB default)")
+	fs.Float64Var(&config.CompactionSimilarityRatio, "compaction_similarity_ratio", config.CompactionSimilarityRatio, "Size ratio under which SSTables are tiered together (0 = WildcatDB default)")
+	fs.DurationVar(&config.CompactionReadWaitBackoff, "compaction_read_wait_backoff", config.CompactionReadWaitBackoff, "Backoff while an SSTable being compacted is still read (0 = WildcatDB default)")
+	fs.Float64Var(&config.CompactionPartitionRatio, "compaction_partition_ratio", config.CompactionPartitionRatio, "Fraction of the last level moved down when it is partitioned (0 = WildcatDB default)")
+	fs.Float64Var(&config.CompactionPartitionDist, "compaction_partition_distribution", config.CompactionPartitionDist, "Share of a partition sent to the last level rather than the one above (0 = WildcatDB default)")
+	fs.DurationVar(&config.CompactorTick, "compactor_tick", config.CompactorTick, "How often the compactor checks for work (0 = WildcatDB default)")
+	fs.DurationVar(&config.FlusherTick, "flusher_tick", config.FlusherTick, "How often the flusher checks for immutable memtables (0 = WildcatDB default)")
+	fs.Float64Var(&config.BloomFilterFPR, "bloom_filter_fpr", config.BloomFilterFPR, "Bloom filter false positive rate (0 = WildcatDB default)")
+	fs.IntVar(&config.WalAppendRetry, "wal_append_retry", config.WalAppendRetry, "WAL append retries (0 = WildcatDB default)")
+	fs.DurationVar(&config.WalAppendBackoff, "wal_append_backoff", config.WalAppendBackoff, "Backoff between WAL append retries (0 = WildcatDB default)")
+	fs.IntVar(&config.SSTableBTreeOrder, "sstable_btree_order", config.SSTableBTreeOrder, "Order of the B-tree in each SSTable (0 = WildcatDB default)")
+	fs.IntVar(&config.MaxConcurrentTxns, "max_concurrent_txns", config.MaxConcurrentTxns, "Max open transactions (0 = WildcatDB default)")
+	fs.IntVar(&config.TxnBeginRetry, "txn_begin_retry", config.TxnBeginRetry, "Retries when no transaction slot is free (0 = WildcatDB default)")
+	fs.DurationVar(&config.TxnBeginBackoff, "txn_begin_backoff", config.TxnBeginBackoff, "First backoff between transaction begin retries (0 = WildcatDB default)")
+	fs.DurationVar(&config.TxnBeginMaxBackoff, "txn_begin_max_backoff", config.TxnBeginMaxBackoff, "Longest backoff between transaction begin retries (0 = WildcatDB default)")
+	fs.BoolVar(&config.RecoverUncommittedTxns, "recover_uncommitted_txns", config.RecoverUncommittedTxns, "Recover uncommitted transactions from the WAL on open")
+	fs.UintVar(&config.DBPermission, "db_permission", config.DBPermission, "File mode for database files, e.g. 0750 (0 = WildcatDB default)")
+	fs.BoolVar(&config.DBLogging, "db_log", config.DBLogging, "Let WildcatDB log to stdout")
+
+	// Benchmark parameters
+	fs.Int64Var(&config.NumOperations, "num", config.NumOperations, "Number of operations")
+	fs.DurationVar(&config.Duration, "duration", config.Duration, "Run each benchmark for this long instead of -num operations (0 = use -num)")
+	fs.Float64Var(&config.TargetRate, "target_rate", config.TargetRate, "Cap offered load at this many ops/sec across all threads (0 = unthrottled)")
+	fs.BoolVar(&config.CorrectLatency, "correct_latency", config.CorrectLatency, "With -target_rate, measure latency from each operation's intended start to correct for coordinated omission")
+	fs.BoolVar(&config.OpenLoop, "open_loop", config.OpenLoop, "With -target_rate, issue operations from a scheduler into a bounded queue served by the worker threads")
+	fs.IntVar(&config.QueueDepth, "queue_depth", config.QueueDepth, "Capacity of the -open_loop queue")
+	fs.IntVar(&config.KeySize, "key_size", config.KeySize, "Size of keys in bytes")
+	fs.IntVar(&config.ValueSize, "value_size", config.ValueSize, "Size of values in bytes")
+	fs.IntVar(&config.LargeValueSize, "large_value_size", config.LargeValueSize, "Size of the values written by filllarge in bytes")
+	fs.IntVar(&config.AppendSize, "append_size", config.AppendSize, "Bytes appended to a value per appendrandom operation (0 = value_size)")
+	fs.IntVar(&config.NumThreads, "threads", config.NumThreads, "Number of concurrent threads")
+	fs.IntVar(&config.BatchSize, "batch_size", config.BatchSize, "Batch size for operations")
+	fs.IntVar(&config.TxnOps, "txn_ops", config.TxnOps, "Puts in each long_txn transaction")
+	fs.IntVar(&config.TxnRetries, "txn_retries", config.TxnRetries, "Times a conflicted transaction is retried before it is abandoned")
+	fs.DurationVar(&config.TxnBackoff, "txn_backoff", config.TxnBackoff, "Maximum random backoff before the first retry, doubled for each further retry")
+	fs.DurationVar(&config.TxnHold, "txn_hold", config.TxnHold, "How long long_txn keeps each transaction open before committing, and snapshotisolation each snapshot")
+
+	// Test types
+	raw.benchmarks = fs.String("benchmarks", formatBenchmarkSpecs(config.Benchmarks), "Comma-separated list of benchmarks, each optionally with flag overrides: name(key=value,...)")
+	fs.IntVar(&config.ReadRatio, "read_ratio", config.ReadRatio, "Read ratio for mixed workloads (0-100)")
+	fs.IntVar(&config.MissRatio, "miss_ratio", config.MissRatio, "Percentage of readseq/readrandom lookups that target absent keys (0-100)")
+	fs.IntVar(&config.ScanLength, "scan_length", config.ScanLength, "Keys read by each scanwhilewriting range scan")
+	fs.IntVar(&config.SeekNexts, "seek_nexts", config.SeekNexts, "Entries read with Next after each seekrandom seek")
+	fs.Float64Var(&config.HotReadRatio, "hot_read_ratio", config.HotReadRatio, "Percentage of the most recently written keys readhot reads from (0-100]")
+
+	// Data distribution
+	fs.StringVar(&config.KeyDistribution, "key_dist", config.KeyDistribution, "Key distribution: "+strings.Join(keyDistributions, ", "))
+	fs.Int64Var(&config.KeySpace, "key_space", config.KeySpace, "Number of distinct keys the access distributions draw from (0 = existing_keys)")
+	fs.IntVar(&config.HotOpRatio, "hot_op_ratio", config.HotOpRatio, "hotspot: percentage of operations that target the hot set (0-100)")
+	fs.IntVar(&config.HotKeyRatio, "hot_key_ratio", config.HotKeyRatio, "hotspot: percentage of the key space in the hot set (1-100)")
+	fs.Int64Var(&config.ExistingKeys, "existing_keys", config.ExistingKeys, "Number of existing keys (0 = use num)")
+	fs.IntVar(&config.MaxKeySize, "max_key_size", config.MaxKeySize, "Maximum key size in bytes for boundary key tests")
+	raw.ageBuckets = fs.String("age_buckets", formatDurationList(config.AgeBuckets), "Comma-separated data age boundaries for read latency bucketing in mixed workloads")
+
+	// Reporting
+	fs.DurationVar(&config.ReportInterval, "report_interval", config.ReportInterval, "Progress report interval")
+	fs.BoolVar(&config.TUI, "tui", config.TUI, "Show a live terminal dashboard instead of progress reports")
+	fs.BoolVar(&config.Histogram, "histogram", config.Histogram, "Show latency histogram")
+	fs.BoolVar(&config.Stats, "stats", config.Stats, "Show database stats after each benchmark")
+	fs.StringVar(&config.ResultsFile, "results_file", config.ResultsFile, "Write results, resolved config, seed and version to this JSON file")
+	fs.StringVar(&config.History, "history", config.History, "Append results to this JSONL history store for the trend subcommand")
+	fs.StringVar(&config.OutputFormat, "output", config.OutputFormat, "Results output format: "+strings.Join(outputFormats, ", "))
+	fs.StringVar(&config.OutputFile, "output_file", config.OutputFile, "Write -output results to this file instead of stdout")
+	fs.StringVar(&config.HTMLReport, "html_report", config.HTMLReport, "Write a self-contained HTML report with charts to this file")
+	fs.StringVar(&config.TimelineFile, "timeline_file", config.TimelineFile, "Write per-second op counts for every benchmark to this CSV file")
+	fs.DurationVar(&config.LatencyWindow, "latency_window", config.LatencyWindow, "Window for latency percentiles over time (0 disables)")
+	fs.StringVar(&config.LatencySeries, "latency_series_file", config.LatencySeries, "Write per-window latency percentiles for every benchmark to this CSV file")
+	fs.StringVar(&config.HeatmapFile, "heatmap_file", config.HeatmapFile, "Write per-window latency bucket counts for every benchmark to this file for heatmap plotting")
+	fs.StringVar(&config.HeatmapFormat, "heatmap_format", config.HeatmapFormat, "Heatmap file format: hlog (HdrHistogram interval log) or csv (one row per window and bucket)")
+	fs.DurationVar(&config.SpaceInterval, "space_interval", config.SpaceInterval, "Sample the database size this often during each benchmark for peak disk usage (0 disables)")
+	fs.DurationVar(&config.WALInterval, "wal_interval", config.WALInterval, "Sample WildcatDB's WAL files this often during each benchmark for bytes written (0 = start and end only; sampling walks the database directory while the benchmark is timed)")
+	fs.DurationVar(&config.CompactionInterval, "compaction_interval", config.CompactionInterval, "Sample WildcatDB's SSTables this often during each benchmark for flushes and compactions (0 = start and end only; sampling walks the database directory while the benchmark is timed)")
+	fs.StringVar(&config.LatencyDump, "latency_dump", config.LatencyDump, "Write every recorded latency to one file per benchmark in this directory")
+	fs.StringVar(&config.LatencyDumpFmt, "latency_dump_format", config.LatencyDumpFmt, "Raw latency dump format: csv, bin (little-endian int64 nanoseconds)")
+	raw.percentiles = fs.String("percentiles", formatPercentiles(config.Percentiles), "Comma-separated latency percentiles to report in every output, e.g. 50,90,99,99.9,99.99")
+	fs.StringVar(&config.InfluxURL, "influx_url", config.InfluxURL, "Stream samples in InfluxDB line protocol to this write URL")
+	fs.StringVar(&config.InfluxToken, "influx_token", config.InfluxToken, "InfluxDB API token sent as 'Authorization: Token ...'")
+	fs.StringVar(&config.OTLPEndpoint, "otlp_endpoint", config.OTLPEndpoint, "Push metrics to this OpenTelemetry collector OTLP/HTTP endpoint (e.g. http://localhost:4318)")
+	fs.StringVar(&config.OTLPHeaders, "otlp_headers", config.OTLPHeaders, "Comma-separated key=value headers for OTLP requests")
+	fs.DurationVar(&config.ExportInterval, "export_interval", config.ExportInterval, "Interval between progress pushes to InfluxDB/OTLP")
+
+	// Notifications
+	fs.StringVar(&config.NotifyURL, "notify_url", config.NotifyURL, "POST a notification to this URL when the suite finishes or a threshold is breached")
+	fs.StringVar(&config.NotifyFormat, "notify_format", config.NotifyFormat, "Notification payload: "+strings.Join(notifyFormats, ", "))
+	fs.Float64Var(&config.NotifyErrorRate, "notify_error_rate", config.NotifyErrorRate, "Notify when more than this percentage of a benchmark's operations fail (0 disables)")
+	fs.Float64Var(&config.NotifyRegression, "notify_regression", config.NotifyRegression, "Notify when a benchmark's ops/sec falls this many percent below -baseline (0 disables)")
+
+	// Profiling
+	fs.StringVar(&config.CPUProfileDir, "cpuprofile_dir", config.CPUProfileDir, "Write a pprof CPU profile of each benchmark to this directory")
+	fs.StringVar(&config.MemProfileDir, "memprofile_dir", config.MemProfileDir, "Write a pprof heap profile taken after each benchmark to this directory")
+	fs.BoolVar(&config.MemProfilePeak, "memprofile_peak", config.MemProfilePeak, "With -memprofile_dir, also write a heap profile from each benchmark's peak heap size")
+	fs.StringVar(&config.MutexProfileDir, "mutexprofile_dir", config.MutexProfileDir, "Profile mutex contention during each benchmark and write the profiles to this directory")
+	fs.IntVar(&config.MutexProfileRate, "mutexprofile_fraction", config.MutexProfileRate, "Report 1 in this many mutex contention events with -mutexprofile_dir")
+	fs.StringVar(&config.BlockProfileDir, "blockprofile_dir", config.BlockProfileDir, "Profile goroutine blocking during each benchmark and write the profiles to this directory")
+	fs.IntVar(&config.BlockProfileRate, "blockprofile_rate", config.BlockProfileRate, "Sample one blocking event per this many nanoseconds blocked with -blockprofile_dir")
+	fs.StringVar(&config.ExecTrace, "exec_trace", config.ExecTrace, "Write a Go execution trace of one benchmark to this file")
+	fs.StringVar(&config.ExecTraceBenchmark, "exec_trace_benchmark", config.ExecTraceBenchmark, "Benchmark to capture with -exec_trace (default: the first one run)")
+	fs.DurationVar(&config.ExecTraceDelay, "exec_trace_delay", config.ExecTraceDelay, "Start the execution trace this long after the benchmark starts")
+	fs.DurationVar(&config.ExecTraceDuration, "exec_trace_duration", config.ExecTraceDuration, "Stop the execution trace after this long (0 = when the benchmark ends)")
+
+	// Space reclamation
+	fs.IntVar(&config.DeleteRatio, "delete_ratio", config.DeleteRatio, "Percentage of keys deleted by spacereclaim (0-100)")
+	fs.DurationVar(&config.ReclaimWait, "reclaim_wait", config.ReclaimWait, "How long spacereclaim tracks on-disk size after deleting")
+	fs.DurationVar(&config.ReclaimInterval, "reclaim_interval", config.ReclaimInterval, "On-disk size sampling interval for spacereclaim")
+
+	// Endurance mode
+	fs.DurationVar(&config.EnduranceDuration, "endurance_duration", config.EnduranceDuration, "Total run time for the endurance subcommand")
+	fs.DurationVar(&config.CheckpointInterval, "checkpoint_interval", config.CheckpointInterval, "How often endurance writes a checkpointed results file")
+	fs.DurationVar(&config.EnduranceSample, "endurance_sample", config.EnduranceSample, "Endurance resource timeline sampling interval")
+	fs.StringVar(&config.EnduranceDir, "endurance_dir", config.EnduranceDir, "Directory for endurance checkpoints, timeline and summaries")
+
+	// Checkpoint and resume
+	fs.StringVar(&config.ResumeFile, "resume_file", config.ResumeFile, "Save the suite's progress to this file and resume from it if present")
+	fs.DurationVar(&config.ResumeInterval, "resume_interval", config.ResumeInterval, "How often a running benchmark's progress is saved to -resume_file")
+
+	// Trace replay
+	fs.StringVar(&config.TraceFile, "trace", config.TraceFile, "Trace file replayed by the replay benchmark")
+	fs.BoolVar(&config.TraceTiming, "trace_timing", config.TraceTiming, "Honour the trace's original inter-arrival times")
+	fs.StringVar(&config.RecordTrace, "record_trace", config.RecordTrace, "Record every GET/PUT/DELETE issued to this trace file for later replay (.gz to compress)")
+	fs.Float64Var(&config.TraceSpeed, "trace_speed", config.TraceSpeed, "Speed multiplier for -trace_timing (2 = twice as fast)")
+
+	// Repetition
+	fs.IntVar(&config.Repeat, "repeat", config.Repeat, "Run each benchmark this many times and report mean, stddev, min/max and CV")
+	fs.BoolVar(&config.RepeatFresh, "repeat_fresh", config.RepeatFresh, "Delete the database before every run of a benchmark")
+	fs.StringVar(&config.FreshDB, "fresh_db", config.FreshDB, "Delete the database: never, per_suite (once before the benchmarks) or per_benchmark (before each, loading -existing_keys keys for benchmarks that read them)")
+
+	// Parameter sweeps
+	fs.StringVar(&config.Sweep, "sweep", config.Sweep, "Run every benchmark for each combination of flag values, e.g. \"threads=1,4,16;value_size=128,1024\"")
+
+	// A/B comparison
+	fs.StringVar(&config.ABVariantA, "ab_a", config.ABVariantA, "Flag values for variant A of an A/B comparison, e.g. \"bloom_filter=true\"")
+	fs.StringVar(&config.ABVariantB, "ab_b", config.ABVariantB, "Flag values for variant B of an A/B comparison, e.g. \"bloom_filter=false\"")
+
+	// Significance testing
+	fs.StringVar(&config.Baseline, "baseline", config.Baseline, "Compare throughput against the runs in this -results_file")
+	fs.StringVar(&config.SigTest, "sig_test", config.SigTest, "Significance test for run-to-run differences: welch, mannwhitney")
+	fs.Float64Var(&config.SigAlpha, "sig_alpha", config.SigAlpha, "Significance level for -sig_test")
+
+	// Pipelines
+	fs.BoolVar(&config.Pipeline, "pipeline", config.Pipeline, "Run the benchmarks as ordered phases against one open database")
+	fs.DurationVar(&config.SettleQuiet, "settle_quiet", config.SettleQuiet, "How long the on-disk size must stay unchanged for compactwait to finish")
+	fs.DurationVar(&config.SettleTimeout, "settle_timeout", config.SettleTimeout, "Longest compactwait waits for compaction to settle")
+
+	// Recovery
+	raw.reopenSizes = fs.String("reopen_sizes", formatCountList(config.ReopenSizes), "Comma-separated key counts the reopen benchmark fills before timing recovery")
+	fs.DurationVar(&config.CrashAfter, "crash_after", config.CrashAfter, "How long the crash subcommand's writer runs before it is killed")
+	fs.StringVar(&config.CrashSync, "crash_sync", config.CrashSync, "Comma-separated sync options the crash subcommand tests")
+	fs.IntVar(&config.DurabilityTrials, "durability_trials", config.DurabilityTrials, "Kills at random points per sync option in the durability subcommand")
+
+	// Workload file
+	fs.StringVar(&config.ConfigFile, "config", config.ConfigFile, "YAML, TOML or JSON workload file; command-line flags override its values")
+
+	// Advanced options
+	fs.BoolVar(&config.UseTransactions, "use_txn", config.UseTransactions, "Use manual transactions instead of Update/View")
+	fs.BoolVar(&config.IteratorTests, "iterator_tests", config.IteratorTests, "Include iterator benchmarks")
+	fs.BoolVar(&config.CompressibleData, "compressible", config.CompressibleData, "Use compressible test data")
+	fs.BoolVar(&config.Verify, "verify", config.Verify, "Checksum every value written and check every value read")
+	fs.BoolVar(&config.Validate, "validate", config.Validate, "Read back and check every key after each fill benchmark (implies -verify)")
+	fs.BoolVar(&config.CheckConsistency, "check_consistency", config.CheckConsistency, "Flag stale reads in readwhilewriting and concurrent_read_write")
+	fs.BoolVar(&config.CheckLostWrites, "check_lost_writes", config.CheckLostWrites, "Check high_contention_writes and heavy_contention kept every committed write")
+	fs.BoolVar(&config.CheckIterators, "check_iterators", config.CheckIterators, "Check iterator benchmarks return keys in order without skipping seeded keys")
+	fs.BoolVar(&config.CheckTimestamps, "check_timestamps", config.CheckTimestamps, "Check the MVCC timestamps returned by WildcatDB iterators in every benchmark")
+	fs.Int64Var(&config.Seed, "seed", config.Seed, "Random seed")
+
+	// Cleanup
+	fs.BoolVar(&config.CleanupAfter, "cleanup", config.CleanupAfter, "Cleanup database after benchmarks")
+
+	return raw
+}
+
+func parseFlags(args []string) *BenchmarkConfig {
+	config, err := parseConfig(flag.CommandLine, args)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	return config
+}
+
+// parseConfig parses args into a new config with the flags registered on
+// fs, and checks every benchmark's overrides and variants apply. It is
+// parseFlags for callers that must not exit, such as the serve subcommand.
+func parseConfig(fs *flag.FlagSet, args []string) (*BenchmarkConfig, error) {
+	config := defaultConfig()
+	raw := registerFlags(fs, config)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if config.ConfigFile != "" {
+		if err := applyWorkloadFile(fs, config, raw, config.ConfigFile); err != nil {
+			return nil, fmt.Errorf("Failed to load workload file: %v", err)
+		}
+	}
+
+	if err := finalizeConfig(config, raw); err != nil {
+		return nil, fmt.Errorf("Invalid configuration: %v", err)
+	}
+
+	for _, spec := range config.benchmarkRuns() {
+		phase, err := config.withOverrides(spec.Params)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid parameters for %s: %v", spec.Name, err)
+		}
+		if spec.Name == "replay" && phase.TraceFile == "" {
+			return nil, fmt.Errorf("Invalid parameters for replay: -trace is required")
+		}
+		if config.Pipeline && !sameDatabaseOptions(config, phase) {
+			return nil, fmt.Errorf("Invalid parameters for %s: database options cannot change between pipeline phases", spec.Name)
+		}
+		for _, v := range config.ABVariants {
+			if _, err := phase.withVariant(v); err != nil {
+				return nil, fmt.Errorf("Invalid variant %s for %s: %v", v.Name, spec.Name, err)
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// finalizeConfig parses the raw flag values into config and validates it.
+func finalizeConfig(config *BenchmarkConfig, raw *rawFlags) error {
+	specs, err := parseBenchmarkSpecs(*raw.benchmarks)
+	if err != nil {
+		return fmt.Errorf("invalid benchmarks: %w", err)
+	}
+	config.Benchmarks = specs
+
+	if config.ExistingKeys == 0 {
+		config.ExistingKeys = config.NumOperations
+	}
+	if config.Validate {
+		config.Verify = true
+	}
+
+	ageBuckets, err := parseDurationList(*raw.ageBuckets)
+	if err != nil {
+		return fmt.Errorf("invalid age buckets: %w", err)
+	}
+	config.AgeBuckets = ageBuckets
+
+	percentiles, err := parsePercentiles(*raw.percentiles)
+	if err != nil {
+		return fmt.Errorf("invalid percentiles: %w", err)
+	}
+	config.Percentiles = percentiles
+
+	reopenSizes, err := parseCountList(*raw.reopenSizes)
+	if err != nil {
+		return fmt.Errorf("invalid reopen sizes: %w", err)
+	}
+	if len(reopenSizes) == 0 {
+		return fmt.Errorf("invalid reopen sizes: at least one size is required")
+	}
+	config.ReopenSizes = reopenSizes
+
+	var names []string
+	for _, spec := range config.Benchmarks {
+		if !slices.Contains(benchmarkNames, spec.Name) {
+			return fmt.Errorf("unknown benchmark: %s", spec.Name)
+		}
+		names = append(names, spec.Name)
+	}
+	if err := checkEngine(config.Engine, names); err != nil {
+		return fmt.Errorf("invalid engine: %w", err)
+	}
+
+	if !slices.Contains(syncOptions, strings.ToLower(config.SyncOption)) {
+		return fmt.Errorf("invalid sync option: %s (must be one of %s)", config.SyncOption, strings.Join(syncOptions, ", "))
+	}
+
+	if config.Compression != "" {
+		if !slices.Contains(compressions, config.Compression) {
+			return fmt.Errorf("invalid compression: %s (must be one of %s)", config.Compression, strings.Join(compressions, ", "))
+		}
+		if config.Compression != "none" && !slices.Contains(compressingEngines, config.Engine) {
+			return fmt.Errorf("invalid compression: %s stores data uncompressed (-compression needs -engine=%s)",
+				config.Engine, strings.Join(compressingEngines, " or "))
+		}
+	}
+
+	if !slices.Contains(warmCacheModes, config.WarmCache) {
+		return fmt.Errorf("invalid warm cache mode: %s (must be one of %s)", config.WarmCache, strings.Join(warmCacheModes, ", "))
+	}
+
+	if config.UseExistingDB && (config.RepeatFresh || config.FreshDB != "never") {
+		return fmt.Errorf("-repeat_fresh and -fresh_db delete the database and cannot be combined with -use_existing_db")
+	}
+
+	if config.ReadOnly {
+		for _, name := range names {
+			if !slices.Contains(readOnlyBenchmarks, name) {
+				return fmt.Errorf("benchmark %s writes to the database (-readonly runs only %s)", name, strings.Join(readOnlyBenchmarks, ", "))
+			}
+		}
+		if config.RepeatFresh || config.FreshDB != "never" {
+			return fmt.Errorf("-repeat_fresh and -fresh_db delete the database and cannot be combined with -readonly")
+		}
+	}
+
+	if err := checkWildcatTuning(config); err != nil {
+		return fmt.Errorf("invalid WildcatDB option: %w", err)
+	}
+
+	for _, opt := range strings.Split(config.CrashSync, ",") {
+		if !slices.Contains(syncOptions, strings.ToLower(strings.TrimSpace(opt))) {
+			return fmt.Errorf("invalid crash sync option: %q (must be one of %s)", opt, strings.Join(syncOptions, ", "))
+		}
+	}
+
+	if config.CrashAfter <= 0 {
+		return fmt.Errorf("invalid crash after: %s (must be positive)", config.CrashAfter)
+	}
+
+	if config.DurabilityTrials < 1 {
+		return fmt.Errorf("invalid durability trials: %d (must be at least 1)", config.DurabilityTrials)
+	}
+
+	if !slices.Contains(notifyFormats, config.NotifyFormat) {
+		return fmt.Errorf("invalid notify format: %s (must be one of %s)", config.NotifyFormat, strings.Join(notifyFormats, ", "))
+	}
+
+	if config.NotifyErrorRate < 0 || config.NotifyRegression < 0 {
+		return fmt.Errorf("notification thresholds must not be negative")
+	}
+
+	if !slices.Contains(outputFormats, config.OutputFormat) {
+		return fmt.Errorf("invalid output format: %s (must be one of %s)", config.OutputFormat, strings.Join(outputFormats, ", "))
+	}
+
+	if config.LatencyDumpFmt != "csv" && config.LatencyDumpFmt != "bin" {
+		return fmt.Errorf("invalid latency dump format: %s (must be csv or bin)", config.LatencyDumpFmt)
+	}
+
+	if !slices.Contains(keyDistributions, config.KeyDistribution) {
+		return fmt.Errorf("invalid key distribution: %s (must be one of %s)", config.KeyDistribution, strings.Join(keyDistributions, ", "))
+	}
+
+	if config.KeySpace < 0 {
+		return fmt.Errorf("invalid key space: %d (must not be negative)", config.KeySpace)
+	}
+
+	if config.HotOpRatio < 0 || config.HotOpRatio > 100 {
+		return fmt.Errorf("invalid hot op ratio: %d (must be 0-100)", config.HotOpRatio)
+	}
+
+	if config.HotKeyRatio < 1 || config.HotKeyRatio > 100 {
+		return fmt.Errorf("invalid hot key ratio: %d (must be 1-100)", config.HotKeyRatio)
+	}
+
+	if config.MissRatio < 0 || config.MissRatio > 100 {
+		return fmt.Errorf("invalid miss ratio: %d (must be 0-100)", config.MissRatio)
+	}
+
+	if config.HotReadRatio <= 0 || config.HotReadRatio > 100 {
+		return fmt.Errorf("invalid hot read ratio: %g (must be > 0 and <= 100)", config.HotReadRatio)
+	}
+
+	if config.LargeValueSize <= 0 {
+		return fmt.Errorf("invalid large value size: %d (must be > 0)", config.LargeValueSize)
+	}
+
+	if config.AppendSize < 0 {
+		return fmt.Errorf("invalid append size: %d (must be >= 0)", config.AppendSize)
+	}
+
+	if config.TxnOps <= 0 {
+		return fmt.Errorf("invalid txn ops: %d (must be > 0)", config.TxnOps)
+	}
+
+	if config.TxnRetries < 0 {
+		return fmt.Errorf("invalid txn retries: %d (must be >= 0)", config.TxnRetries)
+	}
+
+	if config.TxnBackoff < 0 {
+		return fmt.Errorf("invalid txn backoff: %s (must be >= 0)", config.TxnBackoff)
+	}
+
+	if config.TxnHold < 0 {
+		return fmt.Errorf("invalid txn hold: %s (must be >= 0)", config.TxnHold)
+	}
+
+	if config.ScanLength <= 0 {
+		return fmt.Errorf("invalid scan length: %d (must be > 0)", config.ScanLength)
+	}
+
+	if config.SeekNexts < 0 {
+		return fmt.Errorf("invalid seek nexts: %d (must be >= 0)", config.SeekNexts)
+	}
+
+	if config.DeleteRatio < 0 || config.DeleteRatio > 100 {
+		return fmt.Errorf("invalid delete ratio: %d (must be 0-100)", config.DeleteRatio)
+	}
+
+	if config.CheckpointInterval <= 0 || config.EnduranceSample <= 0 {
+		return fmt.Errorf("endurance checkpoint and sample intervals must be positive")
+	}
+
+	if config.ResumeInterval <= 0 {
+		return fmt.Errorf("invalid resume interval: %s (must be positive)", config.ResumeInterval)
+	}
+
+	if config.ExportInterval <= 0 {
+		return fmt.Errorf("invalid export interval: %s (must be positive)", config.ExportInterval)
+	}
+
+	if config.ExecTraceDelay < 0 || config.ExecTraceDuration < 0 {
+		return fmt.Errorf("-exec_trace_delay and -exec_trace_duration must not be negative")
+	}
+
+	if config.MutexProfileRate < 1 || config.BlockProfileRate < 1 {
+		return fmt.Errorf("-mutexprofile_fraction and -blockprofile_rate must be at least 1")
+	}
+
+	if config.SpaceInterval < 0 {
+		return fmt.Errorf("invalid space interval: %s (must not be negative)", config.SpaceInterval)
+	}
+	if config.WALInterval < 0 {
+		return fmt.Errorf("invalid WAL interval: %s (must not be negative)", config.WALInterval)
+	}
+	if config.CompactionInterval < 0 {
+		return fmt.Errorf("invalid compaction interval: %s (must not be negative)", config.CompactionInterval)
+	}
+	if config.LatencyWindow < 0 {
+		return fmt.Errorf("invalid latency window: %s (must not be negative)", config.LatencyWindow)
+	}
+	if (config.LatencySeries != "" || config.HeatmapFile != "") && config.LatencyWindow == 0 {
+		return fmt.Errorf("-latency_series_file and -heatmap_file require a positive -latency_window")
+	}
+	if !slices.Contains(heatmapFormats, config.HeatmapFormat) {
+		return fmt.Errorf("invalid heatmap format: %s (must be one of %s)", config.HeatmapFormat, strings.Join(heatmapFormats, ", "))
+	}
+
+	if config.Duration < 0 {
+		return fmt.Errorf("invalid duration: %s (must not be negative)", config.Duration)
+	}
+
+	if config.TargetRate < 0 {
+		return fmt.Errorf("invalid target rate: %g (must not be negative)", config.TargetRate)
+	}
+
+	if config.OpenLoop && config.TargetRate <= 0 {
+		return fmt.Errorf("-open_loop requires -target_rate")
+	}
+
+	if config.QueueDepth <= 0 {
+		return fmt.Errorf("invalid queue depth: %d (must be positive)", config.QueueDepth)
+	}
+
+	sweepAxes, err := parseSweep(config.Sweep)
+	if err != nil {
+		return fmt.Errorf("invalid sweep: %w", err)
+	}
+	config.SweepAxes = sweepAxes
+
+	abVariants, err := parseABVariants(config.ABVariantA, config.ABVariantB)
+	if err != nil {
+		return fmt.Errorf("invalid A/B comparison: %w", err)
+	}
+	config.ABVariants = abVariants
+
+	if len(config.ABVariants) > 0 && config.Pipeline {
+		return fmt.Errorf("an A/B comparison cannot run as a -pipeline")
+	}
+
+	if !slices.Contains(sigTests, config.SigTest) {
+		return fmt.Errorf("invalid significance test: %s (must be one of %s)", config.SigTest, strings.Join(sigTests, ", "))
+	}
+
+	if config.SigAlpha <= 0 || config.SigAlpha >= 1 {
+		return fmt.Errorf("invalid significance level: %g (must be between 0 and 1)", config.SigAlpha)
+	}
+
+	if config.TraceSpeed <= 0 {
+		return fmt.Errorf("invalid trace speed: %g (must be positive)", config.TraceSpeed)
+	}
+
+	if config.Repeat < 1 {
+		return fmt.Errorf("invalid repeat count: %d (must be at least 1)", config.Repeat)
+	}
+
+	if config.RepeatFresh && config.Pipeline {
+		return fmt.Errorf("-repeat_fresh cannot delete the database under a running -pipeline")
+	}
+
+	if !slices.Contains(freshDBModes, config.FreshDB) {
+		return fmt.Errorf("invalid fresh db mode: %s (must be one of %s)", config.FreshDB, strings.Join(freshDBModes, ", "))
+	}
+	if config.FreshDB == "per_benchmark" && config.Pipeline {
+		return fmt.Errorf("-fresh_db=per_benchmark cannot delete the database under a running -pipeline")
+	}
+
+	if config.SettleQuiet <= 0 || config.SettleTimeout <= 0 {
+		return fmt.Errorf("settle quiet period and timeout must be positive")
+	}
+
+	if config.ReclaimInterval <= 0 {
+		return fmt.Errorf("invalid reclaim interval: %s (must be positive)", config.ReclaimInterval)
+	}
+
+	return nil
+}
+
+// checkWildcatTuning rejects WildcatDB tuning values that WildcatDB would
+// not quietly replace with its default. Zero always means the default.
+func checkWildcatTuning(config *BenchmarkConfig) error {
+	durations := map[string]time.Duration{
+		"sync_interval":                config.SyncInterval,
+		"compaction_cooldown":          config.CompactionCooldown,
+		"compaction_read_wait_backoff": config.CompactionReadWaitBackoff,
+		"compactor_tick":               config.CompactorTick,
+		"flusher_tick":                 config.FlusherTick,
+		"wal_append_backoff":           config.WalAppendBackoff,
+		"txn_begin_backoff":            config.TxnBeginBackoff,
+		"txn_begin_max_backoff":        config.TxnBeginMaxBackoff,
+	}
+	for name, d := range durations {
+		if d < 0 {
+			return fmt.Errorf("%s: %s (must be >= 0)", name, d)
+		}
+	}
+
+	counts := map[string]int{
+		"level_multiplier":          config.LevelMultiplier,
+		"block_manager_lru_size":    config.BlockManagerLRUSize,
+		"compaction_batch_size":     config.CompactionBatchSize,
+		"compaction_size_threshold": config.CompactionSizeThreshold,
+		"wal_append_retry":          config.WalAppendRetry,
+		"max_concurrent_txns":       config.MaxConcurrentTxns,
+		"txn_begin_retry":           config.TxnBeginRetry,
+	}
+	for name, n := range counts {
+		if n < 0 {
+			return fmt.Errorf("%s: %d (must be >= 0)", name, n)
+		}
+	}
+
+	fractions := map[string]float64{
+		"block_manager_lru_evict_ratio":     config.BlockManagerLRUEvictRatio,
+		"block_manager_lru_access_weight":   config.BlockManagerLRUAccessWeight,
+		"compaction_score_size_weight":      config.CompactionScoreSizeWeight,
+		"compaction_score_count_weight":     config.CompactionScoreCountWeight,
+		"compaction_partition_ratio":        config.CompactionPartitionRatio,
+		"compaction_partition_distribution": config.CompactionPartitionDist,
+	}
+	for name, f := range fractions {
+		if f < 0 || f > 1 {
+			return fmt.Errorf("%s: %g (must be 0-1)", name, f)
+		}
+	}
+
+	if config.CompactionSizeRatio < 0 || config.CompactionSimilarityRatio < 0 {
+		return fmt.Errorf("compaction ratios must not be negative")
+	}
+	if config.BloomFilterFPR < 0 || config.BloomFilterFPR >= 1 {
+		return fmt.Errorf("bloom_filter_fpr: %g (must be >= 0 and < 1)", config.BloomFilterFPR)
+	}
+	if config.SSTableBTreeOrder < 0 || config.SSTableBTreeOrder == 1 {
+		return fmt.Errorf("sstable_btree_order: %d (must be 0 or at least 2)", config.SSTableBTreeOrder)
+	}
+	if config.DBPermission > 0777 {
+		return fmt.Errorf("db_permission: %#o (must be at most 0777)", config.DBPermission)
+	}
+	if config.TxnBeginMaxBackoff > 0 && config.TxnBeginMaxBackoff < config.TxnBeginBackoff {
+		return fmt.Errorf("txn_begin_max_backoff: %s (must not be below txn_begin_backoff %s)", config.TxnBeginMaxBackoff, config.TxnBeginBackoff)
+	}
+
+	return nil
+}
+
+// withOverrides returns a copy of config with the given flag values applied,
+// or config itself when there is nothing to override.
+func (config *BenchmarkConfig) withOverrides(params map[string]string) (*BenchmarkConfig, error) {
+	if len(params) == 0 {
+		return config, nil
+	}
+
+	c := *config
+
+	// existing_keys defaults to num, so follow an overridden num unless the
+	// base config set existing_keys explicitly
+	if _, ok := params["num"]; ok && c.ExistingKeys == c.NumOperations {
+		c.ExistingKeys = 0
+	}
+
+	fs := flag.NewFlagSet("overrides", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	raw := registerFlags(fs, &c)
+
+	for name, value := range params {
+		switch name {
+		case "benchmarks", "config", "sweep":
+			return nil, fmt.Errorf("%s cannot be overridden per benchmark", name)
+		}
+		if err := setFlag(fs, name, value); err != nil {
+			return nil, fmt.Errorf("%s=%s: %w", name, value, err)
+		}
+	}
+
+	if err := finalizeConfig(&c, raw); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// setFlag sets a flag, also accepting integers written in float notation
+// such as 1e6, which integer flags reject on their own.
+func setFlag(fs *flag.FlagSet, name, value string) error {
+	err := fs.Set(name, value)
+	if err == nil {
+		return nil
+	}
+
+	f, ferr := strconv.ParseFloat(value, 64)
+	if ferr != nil || f != math.Trunc(f) || math.Abs(f) > math.MaxInt64 {
+		return err
+	}
+	if fs.Set(name, strconv.FormatInt(int64(f), 10)) != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// consistencyBenchmarks are the benchmarks -check_consistency applies to.
+var consistencyBenchmarks = []string{"readwhilewriting", "concurrent_read_write"}
+
+// versionSize is the length of the version -check_consistency stamps at the
+// start of every value written.
+const versionSize = 8
+
+// Consistency counts what -check_consistency found. A stale read returned
+// a version older than one whose commit had been acknowledged before the
+// read began, and which did not overlap the write that produced it; a lost
+// read found no value at all where such a commit had been acknowledged.
+type Consistency struct {
+	Reads     int64 // Reads checked
+	Stale     int64
+	Lost      int64
+	Anomalies []string `json:",omitempty"` // The first few anomalies found
+}
+
+// ConsistencyOracle tracks the writes of the running benchmark. Every write
+// takes a ticket from one clock, stamped into its value as its version.
+// When a write is acknowledged, the key's floor rises to the oldest version
+// a correct read could still return: that write's own, or that of any other
+// write to the key already in flight when it began, which may yet commit
+// after it. Writes that finished before it began are superseded, so a read
+// starting after the acknowledgement must not return any version below the
+// floor. Writes racing on a key thus never produce a false anomaly.
+type ConsistencyOracle struct {
+	clock   atomic.Uint64
+	stripes [256]oracleStripe
+
+	statsMu sync.Mutex // Guards stats.Anomalies
+	stats   Consistency
+}
+
+type oracleStripe struct {
+	mu   sync.Mutex
+	keys map[string]*oracleKey
+}
+
+type oracleKey struct {
+	floor    uint64
+	inflight []oracleWrite
+}
+
+type oracleWrite struct {
+	version uint64
+	floor   uint64 // Floor to raise the key to once this write commits
+}
+
+// consistencyOracle is the oracle of the running benchmark, nil unless
+// -check_consistency is set. Its methods are safe to call on nil.
+var consistencyOracle *ConsistencyOracle
+
+func newConsistencyOracle() *ConsistencyOracle {
+	o := &ConsistencyOracle{}
+	for i := range o.stripes {
+		o.stripes[i].keys = make(map[string]*oracleKey)
+	}
+	return o
+}
+
+func (o *ConsistencyOracle) stripe(key []byte) *oracleStripe {
+	h := fnv.New32a()
+	_, _ = h.Write(key)
+	return &o.stripes[h.Sum32()%uint32(len(o.stripes))]
+}
+
+// BeginWrite stamps a new version into value and registers the write as in
+// flight. Call it before the transaction begins, and EndWrite with the
+// returned version once the outcome is known. Values too short to hold a
+// version are not tracked.
+func (o *ConsistencyOracle) BeginWrite(key, value []byte) uint64 {
+	if o == nil || len(value) < versionSize {
+		return 0
+	}
+
+	s := o.stripe(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := s.keys[string(key)]
+	if k == nil {
+		k = &oracleKey{}
+		s.keys[string(key)] = k
+	}
+
+	version := o.clock.Add(1)
+	floor := version
+	for _, w := range k.inflight {
+		floor = min(floor, w.version)
+	}
+	k.inflight = append(k.inflight, oracleWrite{version, floor})
+
+	binary.BigEndian.PutUint64(value, version)
+	return version
+}
+
+// EndWrite retires the write BeginWrite returned version for, raising the
+// key's floor if it committed.
+func (o *ConsistencyOracle) EndWrite(key []byte, version uint64, committed bool) {
+	if o == nil || version == 0 {
+		return
+	}
+
+	s := o.stripe(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := s.keys[string(key)]
+	for i, w := range k.inflight {
+		if w.version == version {
+			if committed {
+				k.floor = max(k.floor, w.floor)
+			}
+			k.inflight = append(k.inflight[:i], k.inflight[i+1:]...)
+			break
+		}
+	}
+}
+
+// Floor returns the oldest version a read of key starting now may return.
+func (o *ConsistencyOracle) Floor(key []byte) uint64 {
+	if o == nil {
+		return 0
+	}
+
+	s := o.stripe(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if k := s.keys[string(key)]; k != nil {
+		return k.floor
+	}
+	return 0
+}
+
+// CheckRead checks the outcome of a read of key that began when its floor
+// was floor. Values written before the benchmark carry no version and count
+// as version 0.
+func (o *ConsistencyOracle) CheckRead(key []byte, floor uint64, value []byte, err error) {
+	if o == nil {
+		return
+	}
+
+	atomic.AddInt64(&o.stats.Reads, 1)
+	if floor == 0 {
+		return
+	}
+
+	if err != nil {
+		if isNotFound(err) {
+			atomic.AddInt64(&o.stats.Lost, 1)
+			o.anomaly(fmt.Sprintf("lost: key %x not found, expected version %d or later", key, floor))
+		}
+		return
+	}
+
+	var version uint64
+	if len(value) >= versionSize {
+		version = binary.BigEndian.Uint64(value)
+	}
+	if version < floor {
+		atomic.AddInt64(&o.stats.Stale, 1)
+		o.anomaly(fmt.Sprintf("stale: key %x returned version %d, expected %d or later", key, version, floor))
+	}
+}
+
+func (o *ConsistencyOracle) anomaly(msg string) {
+	o.statsMu.Lock()
+	if len(o.stats.Anomalies) < maxBadKeys {
+		o.stats.Anomalies = append(o.stats.Anomalies, msg)
+	}
+	o.statsMu.Unlock()
+}
+
+// Result returns what the oracle found. Call it once the benchmark is done.
+func (o *ConsistencyOracle) Result() *Consistency {
+	c := o.stats
+	return &c
+}
+
+func printConsistency(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		c := result.Consistency
+		if c == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Consistency Check\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %12s %10s %10s\n", "Test", "Reads", "Stale", "Lost")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %12d %10d %10d\n", result.Label(), c.Reads, c.Stale, c.Lost)
+		for _, a := range c.Anomalies {
+			fmt.Printf("  %s\n", a)
+		}
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
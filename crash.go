@@ -0,0 +1,229 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var syncOptions = []string{"none", "partial", "full"}
+
+// CrashResult is what survived one killed writer.
+type CrashResult struct {
+	Sync     string
+	Acked    int64
+	Survived int64
+	Corrupt  int64 // Acknowledged keys read back with the wrong value
+	Recovery time.Duration
+}
+
+// runCrash runs the crash subcommand. For each sync option in CrashSync it
+// starts a child process writing to a scratch database, kills it with
+// SIGKILL after CrashAfter, then reopens the database and checks how many of
+// the commits the child had acknowledged can still be read. args are the
+// subcommand's own arguments, passed on to the child.
+//
+// Killing the process is the only fault injected. Whatever it had handed to
+// the kernel is still in the page cache and reaches the disk, so this
+// measures loss from a process crash, not from a power failure or OS crash.
+func runCrash(config *BenchmarkConfig, args []string) {
+	printBanner()
+	printConfig(config)
+
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatalf("Failed to locate executable: %v", err)
+	}
+
+	scratch := *config
+	scratch.DBPath = config.DBPath + "_crash"
+
+	var results []CrashResult
+	for _, syncOption := range strings.Split(config.CrashSync, ",") {
+		scratch.SyncOption = strings.TrimSpace(syncOption)
+		if err := os.RemoveAll(scratch.DBPath); err != nil {
+			log.Fatalf("Failed to remove crash database: %v", err)
+		}
+
+		fmt.Printf("Writing with sync=%s, killing the writer after %s...\n", scratch.SyncOption, config.CrashAfter)
+
+		acked, err := runKilledWriter(exe, args, &scratch, config.CrashAfter)
+		if err != nil {
+			log.Fatalf("Crash writer failed: %v", err)
+		}
+
+		results = append(results, recoverAcked(&scratch, acked))
+	}
+
+	if config.CleanupAfter {
+		if err := os.RemoveAll(scratch.DBPath); err != nil {
+			log.Printf("Failed to cleanup database: %v", err)
+		}
+	}
+
+	fmt.Printf("\nCrash Recovery\n")
+	fmt.Printf("==============\n")
+	fmt.Printf("%-10s %12s %12s %12s %12s %12s\n", "Sync", "Acked", "Survived", "Lost", "Corrupt", "Recovery")
+	fmt.Printf("%-10s %12s %12s %12s %12s %12s\n", "----", "-----", "--------", "----", "-------", "--------")
+	for _, r := range results {
+		fmt.Printf("%-10s %12d %12d %12d %12d %12s\n", r.Sync, r.Acked, r.Survived, r.Acked-r.Survived-r.Corrupt, r.Corrupt, formatDuration(r.Recovery))
+	}
+	fmt.Printf("\n%s\n", processCrashNote)
+}
+
+// processCrashNote qualifies the crash and durability reports: the writer is
+// killed, the machine keeps running.
+const processCrashNote = "Note: only the writer process was killed; writes it had handed to the OS survive whatever -sync says.\n" +
+	"This is loss from a process crash, not from a power failure or OS crash."
+
+// recoverAcked reopens a killed writer's database, timing the recovery, and
+// reads back every key it acknowledged. A value of the wrong size, or failing
+// the -verify checksum, counts as corrupt rather than surviving.
+func recoverAcked(config *BenchmarkConfig, acked []int64) CrashResult {
+	result := CrashResult{Sync: config.SyncOption, Acked: int64(len(acked))}
+
+	start := time.Now()
+	db := openDatabase(config)
+	result.Recovery = time.Since(start)
+
+	_ = db.View(func(txn Txn) error {
+		for _, i := range acked {
+			value, err := txn.Get(generateKey(i, config.KeySize, config.KeyDistribution))
+			switch {
+			case err == nil && len(value) == config.ValueSize:
+				result.Survived++
+			case err == nil, errors.Is(err, errCorruptValue):
+				result.Corrupt++
+			}
+		}
+		return nil
+	})
+
+	if err := db.Close(); err != nil {
+		log.Printf("Failed to close database: %v", err)
+	}
+	return result
+}
+
+// runKilledWriter starts the crash-writer child against config's database,
+// kills it after the given time and returns the key indices whose commits it
+// acknowledged before dying. The child's acknowledgements go straight to a
+// side log next to the database, so none are lost with the process.
+func runKilledWriter(exe string, args []string, config *BenchmarkConfig, after time.Duration) ([]int64, error) {
+	// Later flags win, so the child writes where and how this run says
+	childArgs := append([]string{"crash-writer"}, args...)
+	childArgs = append(childArgs, "-db="+config.DBPath, "-sync="+config.SyncOption)
+
+	ackLog := config.DBPath + ".acks"
+	f, err := os.Create(ackLog)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = os.Remove(ackLog)
+	}()
+
+	cmd := exec.Command(exe, childArgs...)
+	cmd.Stdout = f
+	cmd.Stderr = os.Stderr
+	err = cmd.Start()
+	_ = f.Close() // The child has its own descriptor
+	if err != nil {
+		return nil, err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-exited:
+		return nil, fmt.Errorf("writer exited before it was killed: %v", err)
+	case <-time.After(after):
+	}
+
+	if err := cmd.Process.Kill(); err != nil {
+		return nil, err
+	}
+	<-exited
+
+	data, err := os.ReadFile(ackLog)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(data), "\n")
+
+	// Only whole lines count; the last may have been cut short by the kill
+	var acked []int64
+	for _, line := range lines[:len(lines)-1] {
+		if i, err := strconv.ParseInt(line, 10, 64); err == nil {
+			acked = append(acked, i)
+		}
+	}
+
+	return acked, nil
+}
+
+// runCrashWriter is the child side of the crash subcommand: it writes
+// sequentially numbered keys from every thread until it is killed, printing
+// each key index to stdout once its commit has returned.
+func runCrashWriter(config *BenchmarkConfig) {
+	db := openDatabase(config)
+
+	var mu sync.Mutex
+	var next int64
+
+	var wg sync.WaitGroup
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			values := valueRand(config, threadID)
+			for {
+				i := atomic.AddInt64(&next, 1) - 1
+				key := generateKey(i, config.KeySize, config.KeyDistribution)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
+
+				err := db.Update(func(txn Txn) error {
+					return txn.Put(key, value)
+				})
+				if err != nil {
+					log.Printf("Write failed: %v", err)
+					continue
+				}
+
+				// Unbuffered, so an acknowledgement is never lost with the process
+				mu.Lock()
+				_, err = fmt.Fprintf(os.Stdout, "%d\n", i)
+				mu.Unlock()
+				if err != nil {
+					log.Fatalf("Failed to acknowledge write: %v", err)
+				}
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,395 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed cron expression: minute, hour, day of month,
+// month and day of week, each a set of allowed values. As in cron, when both
+// day fields are restricted a time matching either one is due.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+
+	every time.Duration // For @every, instead of the fields
+}
+
+var cronAliases = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// parseCronSchedule parses a five-field cron expression, supporting *,
+// lists, ranges and steps (*/15, 1-5, 0,30), the aliases @hourly, @daily,
+// @weekly and @monthly, and @every <duration>.
+func parseCronSchedule(spec string) (*cronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	if d, ok := strings.CutPrefix(spec, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil || every < time.Minute {
+			return nil, fmt.Errorf("invalid schedule %q: @every needs a duration of at least 1m", spec)
+		}
+		return &cronSchedule{every: every}, nil
+	}
+	if alias, ok := cronAliases[spec]; ok {
+		spec = alias
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: want 5 fields (minute hour day-of-month month day-of-week)", spec)
+	}
+
+	s := &cronSchedule{domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	bounds := []struct {
+		set      *uint64
+		min, max int
+	}{
+		{&s.minute, 0, 59}, {&s.hour, 0, 23}, {&s.dom, 1, 31}, {&s.month, 1, 12}, {&s.dow, 0, 7},
+	}
+	for i, b := range bounds {
+		set, err := parseCronField(fields[i], b.min, b.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %v", spec, err)
+		}
+		*b.set = set
+	}
+
+	// Sunday is both 0 and 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+func parseCronField(field string, lo, hi int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("bad step in %q", part)
+			}
+			step = n
+		}
+
+		first, last := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if first, err = strconv.Atoi(a); err != nil {
+				return 0, fmt.Errorf("bad value in %q", part)
+			}
+			last = first
+			if isRange {
+				if last, err = strconv.Atoi(b); err != nil {
+					return 0, fmt.Errorf("bad value in %q", part)
+				}
+			} else if hasStep {
+				last = hi
+			}
+		}
+		if first < lo || last > hi || first > last {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, lo, hi)
+		}
+
+		for v := first; v <= last; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// Next returns the first time after t the schedule is due, or the zero
+// time if it never is within five years.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every)
+	}
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for limit := t.AddDate(5, 0, 0); t.Before(limit); {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// RegressionAlert is one metric of one benchmark that got worse than the
+// average of its previous runs by more than the daemon's threshold.
+type RegressionAlert struct {
+	Benchmark string
+	Metric    string  // As named by -alert_metrics
+	Baseline  float64 // Mean over the previous runs
+	Current   float64
+	Change    string
+	Runs      int // Previous runs averaged into Baseline
+}
+
+// runDaemon runs the daemon subcommand: it runs the benchmark flags after --
+// on a cron schedule, each time as a child process appending to the
+// -history store, and raises an alert when a benchmark regresses against
+// its previous runs.
+func runDaemon(args []string) {
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	schedule := fs.String("schedule", "@daily", "When to run, as a cron expression (minute hour day month weekday), @hourly, @daily, @weekly, @monthly or @every <duration>")
+	runNow := fs.Bool("run_now", false, "Run once on startup, before the first scheduled time")
+	threshold := fs.Float64("alert_threshold", 10, "Alert when a metric is this many percent worse than its recent mean")
+	window := fs.Int("alert_window", 5, "Number of previous runs averaged for the alert baseline")
+	metricList := fs.String("alert_metrics", "ops,p99", "Metrics checked for regressions: "+strings.Join(compareMetricKeys(), ", "))
+	webhook := fs.String("alert_webhook", "", "POST each alert as JSON to this URL")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s daemon [flags] -- benchmark flags\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	cron, err := parseCronSchedule(*schedule)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *threshold <= 0 || *window < 1 {
+		log.Fatalf("-alert_threshold must be positive and -alert_window at least 1")
+	}
+
+	var metrics []*compareMetric
+	for _, key := range strings.Split(*metricList, ",") {
+		m := compareMetricByKey(strings.TrimSpace(key))
+		if m == nil {
+			log.Fatalf("Unknown metric: %s (must be one of %s)", key, strings.Join(compareMetricKeys(), ", "))
+		}
+		metrics = append(metrics, m)
+	}
+
+	// Fail on bad benchmark flags now rather than at the first run
+	runFs := flag.NewFlagSet("run", flag.ContinueOnError)
+	runFs.SetOutput(io.Discard)
+	config, err := parseConfig(runFs, fs.Args())
+	if err != nil {
+		log.Fatalf("Invalid benchmark flags: %v", err)
+	}
+	if config.History == "" {
+		log.Fatalf("The daemon needs -history among the benchmark flags to track results")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatalf("Failed to locate executable: %v", err)
+	}
+
+	printBanner()
+	fmt.Printf("Running %q on schedule %q, recording to %s\n", strings.Join(fs.Args(), " "), *schedule, config.History)
+
+	next := time.Now()
+	if !*runNow {
+		next = cron.Next(next)
+	}
+	for !next.IsZero() {
+		fmt.Printf("Next run at %s\n", next.Format(time.RFC3339))
+		time.Sleep(time.Until(next))
+
+		launched := time.Now()
+		cmd := exec.Command(exe, fs.Args()...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			log.Printf("Scheduled run failed: %v", err)
+			sendAlert(*webhook, fmt.Sprintf("Scheduled benchmark run failed: %v", err), nil)
+		} else {
+			alerts, err := checkRegressions(config.History, launched, metrics, *threshold, *window)
+			if err != nil {
+				log.Printf("Failed to check for regressions: %v", err)
+			}
+			printRegressionAlerts(alerts)
+			for _, a := range alerts {
+				sendAlert(*webhook, fmt.Sprintf("Benchmark regression: %s %s %s against the mean of its last %d runs",
+					a.Benchmark, compareMetricByKey(a.Metric).name, a.Change, a.Runs), a)
+			}
+		}
+
+		next = cron.Next(time.Now())
+	}
+	log.Fatalf("Schedule %q never comes due again", *schedule)
+}
+
+// checkRegressions compares the history records written since launched
+// against up to window previous runs of the same benchmark and workload,
+// returning every metric worse than their mean by more than threshold
+// percent. Throughput is worse when lower; every other metric when higher.
+func checkRegressions(path string, launched time.Time, metrics []*compareMetric, threshold float64, window int) ([]*RegressionAlert, error) {
+	entries, err := readHistoryIndex(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Records of the new run, and earlier ones of the same benchmarks
+	var current []historyIndexEntry
+	for _, e := range entries {
+		if !e.StartedAt.Before(launched) {
+			current = append(current, e)
+		}
+	}
+	currentRecords, err := readHistoryRecords(path, current)
+	if err != nil {
+		return nil, err
+	}
+	now := make(map[string][]*BenchmarkResult)
+	var order []string
+	fingerprints := make(map[string]string)
+	for _, r := range currentRecords {
+		if _, ok := now[r.Label]; !ok {
+			order = append(order, r.Label)
+		}
+		now[r.Label] = append(now[r.Label], r.Result)
+		fingerprints[r.Label] = r.Fingerprint
+	}
+
+	var alerts []*RegressionAlert
+	for _, label := range order {
+		// The previous runs, newest first, one per invocation
+		var previous []historyIndexEntry
+		var runs []time.Time
+		for i := len(entries) - 1; i >= 0; i-- {
+			e := entries[i]
+			if e.Label != label || e.Fingerprint != fingerprints[label] || !e.StartedAt.Before(launched) {
+				continue
+			}
+			if !slices.ContainsFunc(runs, e.StartedAt.Equal) {
+				if len(runs) == window {
+					break
+				}
+				runs = append(runs, e.StartedAt)
+			}
+			previous = append(previous, e)
+		}
+		if len(previous) == 0 {
+			continue
+		}
+
+		records, err := readHistoryRecords(path, previous)
+		if err != nil {
+			return nil, err
+		}
+		before := make([]*BenchmarkResult, len(records))
+		for i, r := range records {
+			before[i] = r.Result
+		}
+
+		for _, m := range metrics {
+			baseline, value := meanOf(before, m.value), meanOf(now[label], m.value)
+			worse := value > baseline*(1+threshold/100)
+			if m.key == "ops" {
+				worse = value < baseline*(1-threshold/100)
+			}
+			if worse {
+				alerts = append(alerts, &RegressionAlert{
+					Benchmark: label,
+					Metric:    m.key,
+					Baseline:  baseline,
+					Current:   value,
+					Change:    percentChange(baseline, value),
+					Runs:      len(runs),
+				})
+			}
+		}
+	}
+	return alerts, nil
+}
+
+func compareMetricByKey(key string) *compareMetric {
+	for i := range compareMetrics {
+		if compareMetrics[i].key == key {
+			return &compareMetrics[i]
+		}
+	}
+	return nil
+}
+
+func printRegressionAlerts(alerts []*RegressionAlert) {
+	fmt.Printf("\nRegression Alerts\n")
+	fmt.Printf("=========================\n")
+	if len(alerts) == 0 {
+		fmt.Printf("No regressions against the previous runs\n\n")
+		return
+	}
+
+	fmt.Printf("%-25s %-14s %14s %14s %9s %6s\n", "Test", "Metric", "Baseline", "Current", "Change", "Runs")
+	for _, a := range alerts {
+		m := compareMetricByKey(a.Metric)
+		fmt.Printf("%-25s %-14s %14s %14s %9s %6d\n",
+			a.Benchmark, m.name, m.format(a.Baseline), m.format(a.Current), a.Change, a.Runs)
+	}
+	fmt.Printf("\n")
+}
+
+// sendAlert posts an alert to the webhook, if one is set, as JSON with the
+// message under "text", the field Slack and compatible chat hooks display.
+func sendAlert(webhook, text string, alert *RegressionAlert) {
+	if webhook == "" {
+		return
+	}
+	body := struct {
+		Text  string           `json:"text"`
+		Alert *RegressionAlert `json:"alert,omitempty"`
+	}{text, alert}
+	if err := postWebhook(webhook, body); err != nil {
+		log.Printf("Failed to send alert: %v", err)
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// benchmarkBarrier, when set, is called before each benchmark run with the
+// run's position in the session, and returns when the run should start. A
+// worker uses it to start every benchmark together with the other workers.
+var benchmarkBarrier func(seq int)
+
+// WorkerReport is what a worker sends the coordinator once its benchmarks
+// are done.
+type WorkerReport struct {
+	Worker  int
+	Host    string
+	Results []*BenchmarkResult
+}
+
+type joinResponse struct {
+	Worker  int
+	Workers int
+	Args    []string // Benchmark flags for the worker to run with
+}
+
+type barrierRequest struct {
+	Worker int
+	Seq    int
+}
+
+type barrierResponse struct {
+	StartIn time.Duration // Relative, so the workers' clocks need not agree
+}
+
+// coordinator hands the benchmark flags out to a fixed number of workers,
+// starts each benchmark on all of them at once and merges their results.
+type coordinator struct {
+	args       []string
+	config     *BenchmarkConfig
+	workers    int
+	startDelay time.Duration
+
+	mu        sync.Mutex
+	hosts     []string
+	barriers  map[int]*startBarrier
+	reports   []*WorkerReport
+	remaining int
+	startedAt time.Time
+	done      chan struct{}
+}
+
+// startBarrier releases the workers into one benchmark once all of them
+// have reached it.
+type startBarrier struct {
+	arrived int
+	start   time.Time
+	release chan struct{}
+}
+
+// runCoordinator runs the coordinator subcommand. It waits for -workers
+// workers to join, has each run the benchmark flags after -- against its own
+// database, and prints and writes the merged results.
+func runCoordinator(args []string) {
+	fs := flag.NewFlagSet("coordinator", flag.ExitOnError)
+	listen := fs.String("listen", ":7070", "Address to listen for workers on")
+	workers := fs.Int("workers", 2, "Number of workers to wait for")
+	startDelay := fs.Duration("start_delay", 2*time.Second, "Lead time given to the workers before each benchmark starts")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s coordinator [flags] [-- benchmark flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *workers < 1 {
+		log.Fatalf("-workers must be at least 1")
+	}
+
+	// Fail on bad flags now rather than on every worker
+	runFs := flag.NewFlagSet("run", flag.ContinueOnError)
+	runFs.SetOutput(io.Discard)
+	config, err := parseConfig(runFs, fs.Args())
+	if err != nil {
+		log.Fatalf("Invalid benchmark flags: %v", err)
+	}
+
+	c := &coordinator{
+		args:       fs.Args(),
+		config:     config,
+		workers:    *workers,
+		startDelay: *startDelay,
+		barriers:   make(map[int]*startBarrier),
+		reports:    make([]*WorkerReport, *workers),
+		remaining:  *workers,
+		done:       make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /join", c.join)
+	mux.HandleFunc("POST /barrier", c.barrier)
+	mux.HandleFunc("POST /results", c.collect)
+
+	srv := &http.Server{Addr: *listen, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to listen for workers: %v", err)
+		}
+	}()
+
+	printBanner()
+	fmt.Printf("Waiting for %d workers on %s\n", c.workers, *listen)
+
+	<-c.done
+	_ = srv.Shutdown(context.Background())
+
+	c.report()
+}
+
+// workerArgs returns the benchmark flags for worker id: the coordinator's,
+// with a seed and a database directory of the worker's own, so processes
+// sharing a machine or a filesystem do not collide. Files are written by the
+// coordinator alone.
+func (c *coordinator) workerArgs(id int) []string {
+	return append(append([]string{}, c.args...),
+		fmt.Sprintf("-seed=%d", c.config.Seed+int64(id)),
+		fmt.Sprintf("-db=%s_worker%d", c.config.DBPath, id),
+		"-results_file=", "-history=")
+}
+
+func (c *coordinator) join(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Host string
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	c.mu.Lock()
+	if len(c.hosts) == c.workers {
+		c.mu.Unlock()
+		writeJSONError(w, http.StatusConflict, "all %d workers have joined", c.workers)
+		return
+	}
+	c.hosts = append(c.hosts, req.Host)
+	id := len(c.hosts)
+	c.mu.Unlock()
+
+	fmt.Printf("Worker %d joined from %s (%d/%d)\n", id, req.Host, id, c.workers)
+	writeJSON(w, http.StatusOK, joinResponse{Worker: id, Workers: c.workers, Args: c.workerArgs(id)})
+}
+
+// barrier holds a worker until every worker has reached benchmark run Seq,
+// then tells all of them to start after the start delay.
+func (c *coordinator) barrier(w http.ResponseWriter, r *http.Request) {
+	var req barrierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	b := c.barriers[req.Seq]
+	if b == nil {
+		b = &startBarrier{release: make(chan struct{})}
+		c.barriers[req.Seq] = b
+	}
+	b.arrived++
+	if b.arrived == c.workers {
+		b.start = time.Now().Add(c.startDelay)
+		if req.Seq == 0 {
+			c.startedAt = b.start
+		}
+		close(b.release)
+		fmt.Printf("Starting benchmark run %d on all workers\n", req.Seq+1)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-b.release:
+		writeJSON(w, http.StatusOK, barrierResponse{StartIn: time.Until(b.start)})
+	case <-r.Context().Done():
+	}
+}
+
+func (c *coordinator) collect(w http.ResponseWriter, r *http.Request) {
+	var report WorkerReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid report: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if report.Worker < 1 || report.Worker > c.workers {
+		writeJSONError(w, http.StatusBadRequest, "no worker %d", report.Worker)
+		return
+	}
+	if c.reports[report.Worker-1] != nil {
+		writeJSONError(w, http.StatusConflict, "worker %d already reported", report.Worker)
+		return
+	}
+	c.reports[report.Worker-1] = &report
+	c.remaining--
+
+	fmt.Printf("Worker %d finished %d benchmarks\n", report.Worker, len(report.Results))
+	writeJSON(w, http.StatusOK, map[string]int{"remaining": c.remaining})
+	if c.remaining == 0 {
+		close(c.done)
+	}
+}
+
+// report prints every worker's results and the merged ones, and writes the
+// merged ones to the coordinator's outputs.
+func (c *coordinator) report() {
+	stdout := os.Stdout
+	if structuredOutputToStdout(c.config) {
+		os.Stdout = os.Stderr
+		defer func() {
+			os.Stdout = stdout
+		}()
+	}
+
+	names := make([]string, len(c.reports))
+	sets := make([][]*BenchmarkResult, len(c.reports))
+	for i, report := range c.reports {
+		names[i] = fmt.Sprintf("worker %d (%s)", report.Worker, report.Host)
+		sets[i] = report.Results
+	}
+
+	fmt.Printf("\n")
+	printMergeInputs(names, sets)
+
+	results := mergeResults(names, sets)
+	fmt.Printf("Aggregate of %d workers\n\n", c.workers)
+	printResults(results)
+
+	if err := emitOutput(stdout, c.config, c.startedAt, results); err != nil {
+		log.Printf("Failed to write %s output: %v", c.config.OutputFormat, err)
+	}
+
+	if c.config.ResultsFile != "" {
+		if err := writeResultsFile(c.config.ResultsFile, c.config, c.startedAt, results); err != nil {
+			log.Printf("Failed to write results file: %v", err)
+		} else {
+			fmt.Printf("Results written to: %s\n", c.config.ResultsFile)
+		}
+	}
+
+	if c.config.History != "" {
+		if err := appendHistory(c.config.History, c.config, c.startedAt, results); err != nil {
+			log.Printf("Failed to append to history: %v", err)
+		} else {
+			fmt.Printf("Results appended to history: %s\n", c.config.History)
+		}
+	}
+}
+
+// runWorker runs the worker subcommand: it joins the coordinator at -join,
+// runs the benchmarks it is given in step with the other workers and sends
+// back the results. Benchmark flags after -- override the coordinator's for
+// this worker alone, such as a -db on a local disk.
+func runWorker(args []string) {
+	fs := flag.NewFlagSet("worker", flag.ExitOnError)
+	join := fs.String("join", "", "Coordinator address, host:port")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s worker -join=host:port [-- benchmark flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *join == "" {
+		log.Fatalf("-join is required")
+	}
+	base := *join
+	if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+
+	host, _ := os.Hostname()
+	var joined joinResponse
+	if err := postJSON(base+"/join", map[string]string{"Host": host}, &joined); err != nil {
+		log.Fatalf("Failed to join coordinator: %v", err)
+	}
+	fmt.Printf("Joined %s as worker %d of %d\n", *join, joined.Worker, joined.Workers)
+
+	runFs := flag.NewFlagSet("run", flag.ContinueOnError)
+	runFs.SetOutput(io.Discard)
+	config, err := parseConfig(runFs, append(joined.Args, fs.Args()...))
+	if err != nil {
+		log.Fatalf("Invalid benchmark flags: %v", err)
+	}
+
+	benchmarkBarrier = func(seq int) {
+		fmt.Printf("Waiting for the other workers\n")
+		var start barrierResponse
+		if err := postJSON(base+"/barrier", barrierRequest{Worker: joined.Worker, Seq: seq}, &start); err != nil {
+			log.Fatalf("Failed to synchronize with coordinator: %v", err)
+		}
+		time.Sleep(start.StartIn)
+	}
+
+	_, results, err := runBench(config)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	report := WorkerReport{Worker: joined.Worker, Host: host, Results: results}
+	if err := postJSON(base+"/results", report, nil); err != nil {
+		log.Fatalf("Failed to send results to coordinator: %v", err)
+	}
+	fmt.Printf("Results sent to coordinator\n")
+}
+
+// postJSON posts in as JSON to url and decodes the response into out,
+// unless out is nil. A status other than 2xx is returned as an error with
+// the response's message.
+func postJSON(url string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		var e struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		return fmt.Errorf("%s: %s", resp.Status, e.Error)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// keyDistributions lists the accepted -key_dist values. sequential, random
+// and zipfian change how a key index is encoded into key bytes; the rest
+// choose which key index each operation touches.
+var keyDistributions = []string{"sequential", "random", "zipfian", "hotspot", "latest", "uniform", "scrambled_zipfian"}
+
+// KeyChooser picks the key index each operation of a random-access benchmark
+// touches, in place of the default pseudo-random walk over the existing keys.
+type KeyChooser interface {
+	Next(rng *rand.Rand) int64
+}
+
+// KeyInserter is implemented by choosers whose writes add new keys rather
+// than overwrite chosen ones. Acknowledge is called with each key Insert
+// returned once its write has returned, so reads only choose keys written.
+type KeyInserter interface {
+	Insert() int64
+	Acknowledge(keyIndex int64)
+}
+
+// insertKey returns the index a write should use: a fresh key from an
+// inserting chooser, otherwise keyIndex unchanged.
+func insertKey(keys KeyChooser, keyIndex int64) int64 {
+	if ins, ok := keys.(KeyInserter); ok {
+		return ins.Insert()
+	}
+	return keyIndex
+}
+
+// ackKey acknowledges the write of keyIndex to an inserting chooser and does
+// nothing for the others.
+func ackKey(keys KeyChooser, keyIndex int64) {
+	if ins, ok := keys.(KeyInserter); ok {
+		ins.Acknowledge(keyIndex)
+	}
+}
+
+// newKeyChooser returns the chooser for the configured distribution over
+// keys key indices, or -key_space indices when set, or nil when the
+// distribution only affects key encoding.
+func newKeyChooser(config *BenchmarkConfig, keys int64) KeyChooser {
+	if config.KeySpace > 0 {
+		keys = config.KeySpace
+	}
+
+	switch config.KeyDistribution {
+	case "uniform":
+		return uniformChooser(keys)
+	case "scrambled_zipfian":
+		return &scrambledZipfianChooser{keys: keys, zipf: newZipfian(keys)}
+	case "hotspot":
+		hotKeys := max(1, keys*int64(config.HotKeyRatio)/100)
+		return &hotspotChooser{keys: keys, hotKeys: hotKeys, hotOpRatio: config.HotOpRatio}
+	case "latest":
+		l := &latestChooser{zipf: newZipfian(keys), done: make(map[int64]bool)}
+		l.next.Store(keys)
+		l.acked.Store(keys)
+		return l
+	}
+	return nil
+}
+
+// uniformChooser picks every key index with equal probability.
+type uniformChooser int64
+
+func (u uniformChooser) Next(rng *rand.Rand) int64 {
+	return rng.Int63n(int64(u))
+}
+
+// hotspotChooser sends HotOpRatio percent of operations to the first
+// HotKeyRatio percent of the key space and spreads the rest uniformly over
+// the remainder.
+type hotspotChooser struct {
+	keys       int64
+	hotKeys    int64
+	hotOpRatio int
+}
+
+func (h *hotspotChooser) Next(rng *rand.Rand) int64 {
+	if rng.Intn(100) < h.hotOpRatio || h.hotKeys >= h.keys {
+		return rng.Int63n(h.hotKeys)
+	}
+	return h.hotKeys + rng.Int63n(h.keys-h.hotKeys)
+}
+
+// latestChooser skews reads towards the most recently inserted keys, as in
+// YCSB workload D. Writes append at the insertion frontier, which all threads
+// of a benchmark share, so readers chase what the writers just added. As with
+// YCSB's AcknowledgedCounterGenerator, reads choose below the acknowledged
+// frontier, which only passes a key once it and every key before it have
+// been written, so a read never picks a key whose write is still in flight.
+// A failed write is acknowledged too, having been counted as an error, so
+// that one failure does not hold back the frontier for good.
+type latestChooser struct {
+	next  atomic.Int64 // Next key index to insert
+	acked atomic.Int64 // Keys written, existing plus acknowledged inserts
+
+	mu   sync.Mutex
+	done map[int64]bool // Acknowledged inserts past the frontier
+	zipf *zipfian
+}
+
+func (l *latestChooser) Next(rng *rand.Rand) int64 {
+	n := l.acked.Load()
+	return n - 1 - l.zipf.Next(rng)%n
+}
+
+func (l *latestChooser) Insert() int64 {
+	return l.next.Add(1) - 1
+}
+
+func (l *latestChooser) Acknowledge(keyIndex int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.done[keyIndex] = true
+	n := l.acked.Load()
+	for l.done[n] {
+		delete(l.done, n)
+		n++
+	}
+	l.acked.Store(n)
+}
+
+// scrambledZipfianChooser keeps the Zipfian popularity curve but hashes each
+// rank to a pseudo-random key index, so the hot keys are scattered across the
+// key range instead of clustered at its start. Hash collisions make a few
+// indices unreachable, as in YCSB.
+type scrambledZipfianChooser struct {
+	keys int64
+	zipf *zipfian
+}
+
+func (s *scrambledZipfianChooser) Next(rng *rand.Rand) int64 {
+	return int64(fnv64(uint64(s.zipf.Next(rng))) % uint64(s.keys))
+}
+
+// fnv64 is FNV-1a over the little-endian bytes of v.
+func fnv64(v uint64) uint64 {
+	h := uint64(0xCBF29CE484222325)
+	for i := 0; i < 8; i++ {
+		h ^= v & 0xFF
+		h *= 0x100000001B3
+		v >>= 8
+	}
+	return h
+}
+
+// zipfianTheta is the skew YCSB uses: a few items get most of the accesses.
+const zipfianTheta = 0.99
+
+// zipfian draws ranks 0..n-1 with rank 0 the most popular, using the method
+// from Gray et al., "Quickly Generating Billion-Record Synthetic Databases",
+// as YCSB does.
+type zipfian struct {
+	n     int64
+	zetan float64
+	alpha float64
+	eta   float64
+	half  float64 // 1 + 0.5^theta, the cumulative weight of ranks 0 and 1
+}
+
+var (
+	zetaMu    sync.Mutex
+	zetaCache = make(map[int64]float64)
+)
+
+// zeta is linear in n, so it is computed once per key count and reused
+// across benchmarks.
+func zeta(n int64) float64 {
+	zetaMu.Lock()
+	defer zetaMu.Unlock()
+
+	if z, ok := zetaCache[n]; ok {
+		return z
+	}
+	var z float64
+	for i := int64(1); i <= n; i++ {
+		z += 1 / math.Pow(float64(i), zipfianTheta)
+	}
+	zetaCache[n] = z
+	return z
+}
+
+func newZipfian(n int64) *zipfian {
+	n = max(n, 1)
+	zetan := zeta(n)
+	zeta2 := 1 + 1/math.Pow(2, zipfianTheta)
+	return &zipfian{
+		n:     n,
+		zetan: zetan,
+		alpha: 1 / (1 - zipfianTheta),
+		eta:   (1 - math.Pow(2/float64(n), 1-zipfianTheta)) / (1 - zeta2/zetan),
+		half:  1 + math.Pow(0.5, zipfianTheta),
+	}
+}
+
+func (z *zipfian) Next(rng *rand.Rand) int64 {
+	u := rng.Float64()
+	uz := u * z.zetan
+	switch {
+	case uz < 1:
+		return 0
+	case uz < z.half:
+		return min(1, z.n-1)
+	}
+	return min(int64(float64(z.n)*math.Pow(z.eta*u-z.eta+1, z.alpha)), z.n-1)
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+)
+
+var latencyDumpSeq int64
+
+// dumpLatencies writes every latency recorded by tracker, which must keep
+// raw latencies, to a new file in dir: thread by thread, in recording order
+// within each thread. The csv format is one latency in nanoseconds per line
+// under a latency_ns header; bin is a flat array of little-endian int64
+// nanoseconds. Files are numbered so repeated benchmarks never overwrite each
+// other.
+func dumpLatencies(dir, format, benchmarkName string, tracker *LatencyTracker) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	seq := atomic.AddInt64(&latencyDumpSeq, 1)
+	path := filepath.Join(dir, fmt.Sprintf("%03d_%s.%s", seq, benchmarkName, format))
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	w := bufio.NewWriter(f)
+
+	if format == "csv" {
+		_, _ = w.WriteString("latency_ns\n")
+	}
+	for i := range tracker.recorders {
+		r := &tracker.recorders[i]
+		r.rawMu.Lock()
+		switch format {
+		case "csv":
+			buf := make([]byte, 0, 24)
+			for _, l := range r.raw {
+				buf = strconv.AppendInt(buf[:0], int64(l), 10)
+				buf = append(buf, '\n')
+				_, _ = w.Write(buf)
+			}
+		case "bin":
+			var buf [8]byte
+			for _, l := range r.raw {
+				binary.LittleEndian.PutUint64(buf[:], uint64(l))
+				_, _ = w.Write(buf[:])
+			}
+		}
+		r.rawMu.Unlock()
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+
+	return path, f.Close()
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+)
+
+// DurabilityResult sums the trials of one sync option.
+type DurabilityResult struct {
+	Sync       string
+	Trials     int
+	Acked      int64
+	Lost       int64 // Acknowledged commits missing after recovery
+	Corrupt    int64
+	WorstLost  int64         // Most commits lost in one trial
+	LossWindow time.Duration // Mean time's worth of acknowledged commits lost per trial
+	Recovery   time.Duration // Mean
+}
+
+// runDurability runs the durability subcommand, the crash subcommand
+// repeated to put a number on loss from a killed writer under each sync
+// option. Like the crash subcommand it kills only the process, so it does
+// not show what a sync option buys against a power failure. For every option
+// in CrashSync it runs DurabilityTrials trials, each starting the writer on a
+// fresh scratch database and killing it at a random point up to CrashAfter,
+// then counts the acknowledged commits lost. args are the subcommand's own
+// arguments, passed on to the child.
+func runDurability(config *BenchmarkConfig, args []string) {
+	printBanner()
+	printConfig(config)
+
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatalf("Failed to locate executable: %v", err)
+	}
+
+	scratch := *config
+	scratch.DBPath = config.DBPath + "_durability"
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	var results []DurabilityResult
+	for _, syncOption := range strings.Split(config.CrashSync, ",") {
+		scratch.SyncOption = strings.TrimSpace(syncOption)
+		result := DurabilityResult{Sync: scratch.SyncOption, Trials: config.DurabilityTrials}
+
+		var window, recovery time.Duration
+		for trial := 1; trial <= config.DurabilityTrials; trial++ {
+			if err := os.RemoveAll(scratch.DBPath); err != nil {
+				log.Fatalf("Failed to remove durability database: %v", err)
+			}
+
+			// At least a tenth of CrashAfter, so the writer is past opening
+			after := config.CrashAfter/10 + time.Duration(rng.Int63n(int64(config.CrashAfter)*9/10+1))
+			fmt.Printf("sync=%s trial %d/%d: killing the writer after %s...\n",
+				scratch.SyncOption, trial, config.DurabilityTrials, after.Round(time.Millisecond))
+
+			acked, err := runKilledWriter(exe, args, &scratch, after)
+			if err != nil {
+				log.Fatalf("Durability writer failed: %v", err)
+			}
+			r := recoverAcked(&scratch, acked)
+
+			lost := r.Acked - r.Survived - r.Corrupt
+			result.Acked += r.Acked
+			result.Lost += lost
+			result.Corrupt += r.Corrupt
+			result.WorstLost = max(result.WorstLost, lost)
+			if r.Acked > 0 {
+				window += time.Duration(float64(after) * float64(lost) / float64(r.Acked))
+			}
+			recovery += r.Recovery
+		}
+
+		result.LossWindow = window / time.Duration(config.DurabilityTrials)
+		result.Recovery = recovery / time.Duration(config.DurabilityTrials)
+		results = append(results, result)
+	}
+
+	if config.CleanupAfter {
+		if err := os.RemoveAll(scratch.DBPath); err != nil {
+			log.Printf("Failed to cleanup database: %v", err)
+		}
+	}
+
+	fmt.Printf("\nDurability\n")
+	fmt.Printf("==========\n")
+	fmt.Printf("%-10s %8s %12s %10s %9s %10s %10s %12s %12s\n",
+		"Sync", "Trials", "Acked", "Lost", "Lost %", "Worst", "Corrupt", "Loss window", "Recovery")
+	fmt.Printf("%-10s %8s %12s %10s %9s %10s %10s %12s %12s\n",
+		"----", "------", "-----", "----", "------", "-----", "-------", "-----------", "--------")
+	for _, r := range results {
+		lostPct := 0.0
+		if r.Acked > 0 {
+			lostPct = float64(r.Lost) * 100 / float64(r.Acked)
+		}
+		fmt.Printf("%-10s %8d %12d %10d %8.3f%% %10d %10d %12s %12s\n",
+			r.Sync, r.Trials, r.Acked, r.Lost, lostPct, r.WorstLost, r.Corrupt,
+			formatDuration(r.LossWindow), formatDuration(r.Recovery))
+	}
+	fmt.Printf("\n%s\n", processCrashNote)
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// runEndurance cycles through the configured benchmarks against the same
+// database until EnduranceDuration has elapsed. It samples resource usage into
+// a timeline, writes a checkpointed results file every CheckpointInterval and
+// a summary every 24 hours, so slow degradations show up as trends.
+func runEndurance(config *BenchmarkConfig) {
+	printBanner()
+	printConfig(config)
+
+	stopTrace, err := startTraceRecording(config)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	defer stopTrace()
+
+	if err := os.MkdirAll(config.EnduranceDir, 0755); err != nil {
+		log.Fatalf("Failed to create endurance report directory: %v", err)
+	}
+
+	if config.CleanupAfter {
+		defer func() {
+			if err := os.RemoveAll(config.DBPath); err != nil {
+				log.Printf("Failed to cleanup database: %v", err)
+			}
+		}()
+	}
+
+	timeline, err := os.Create(filepath.Join(config.EnduranceDir, "timeline.csv"))
+	if err != nil {
+		log.Fatalf("Failed to create timeline: %v", err)
+	}
+	defer func() {
+		_ = timeline.Close()
+	}()
+	fmt.Fprintf(timeline, "time,elapsed_sec,db_bytes,heap_alloc_bytes,heap_sys_bytes,num_gc,goroutines\n")
+
+	startedAt := time.Now()
+	deadline := startedAt.Add(config.EnduranceDuration)
+
+	stopSampling := make(chan bool)
+	samplingDone := make(chan bool)
+	go func() {
+		defer close(samplingDone)
+		ticker := time.NewTicker(config.EnduranceSample)
+		defer ticker.Stop()
+
+		writeEnduranceSample(timeline, config, startedAt)
+		for {
+			select {
+			case <-ticker.C:
+				writeEnduranceSample(timeline, config, startedAt)
+			case <-stopSampling:
+				return
+			}
+		}
+	}()
+
+	var all, day []*BenchmarkResult
+	nextCheckpoint := startedAt.Add(config.CheckpointInterval)
+	nextDaily := startedAt.Add(24 * time.Hour)
+	checkpoint, dayNum := 0, 1
+	logical := make(map[string]int64)
+
+	for cycle := 1; time.Now().Before(deadline); cycle++ {
+		fmt.Printf("Endurance cycle %d (%s elapsed)\n", cycle, time.Since(startedAt).Round(time.Second))
+
+		for _, spec := range config.benchmarkRuns() {
+			if !time.Now().Before(deadline) {
+				break
+			}
+
+			benchmark := spec.Name
+			benchConfig, err := config.withOverrides(spec.Params)
+			if err != nil {
+				log.Fatalf("Invalid parameters for %s: %v", benchmark, err)
+			}
+
+			result, err := runSingleBenchmark(benchConfig, benchmark)
+			if err != nil {
+				log.Fatalf("%v", err)
+			}
+			result.Sweep = spec.Sweep
+			accountSpace(logical, benchConfig.DBPath, result)
+			fmt.Printf("Completed %s: %.2f ops/sec\n", benchmark, result.OpsPerSecond)
+
+			all = append(all, result)
+			day = append(day, result)
+
+			if time.Now().After(nextCheckpoint) {
+				checkpoint++
+				writeEnduranceCheckpoint(config, startedAt, checkpoint, all)
+				nextCheckpoint = nextCheckpoint.Add(config.CheckpointInterval)
+			}
+
+			if time.Now().After(nextDaily) {
+				writeEnduranceSummary(config, fmt.Sprintf("day-%03d", dayNum), day)
+				day = nil
+				dayNum++
+				nextDaily = nextDaily.Add(24 * time.Hour)
+			}
+		}
+	}
+
+	stopSampling <- true
+	<-samplingDone
+
+	checkpoint++
+	writeEnduranceCheckpoint(config, startedAt, checkpoint, all)
+	if len(day) > 0 {
+		writeEnduranceSummary(config, fmt.Sprintf("day-%03d", dayNum), day)
+	}
+	writeEnduranceSummary(config, "final", all)
+}
+
+func writeEnduranceSample(w io.Writer, config *BenchmarkConfig, startedAt time.Time) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	now := time.Now()
+	fmt.Fprintf(w, "%s,%.0f,%d,%d,%d,%d,%d\n",
+		now.Format(time.RFC3339), now.Sub(startedAt).Seconds(), dirSize(config.DBPath),
+		m.HeapAlloc, m.HeapSys, m.NumGC, runtime.NumGoroutine())
+}
+
+func writeEnduranceCheckpoint(config *BenchmarkConfig, startedAt time.Time, n int, results []*BenchmarkResult) {
+	path := filepath.Join(config.EnduranceDir, fmt.Sprintf("checkpoint-%04d.json", n))
+	if err := writeResultsFile(path, config, startedAt, results); err != nil {
+		log.Printf("Failed to write endurance checkpoint: %v", err)
+		return
+	}
+	fmt.Printf("Endurance checkpoint written to: %s\n", path)
+}
+
+// writeEnduranceSummary prints and stores a per-benchmark summary of results,
+// comparing the first and last run of each benchmark to expose drift.
+func writeEnduranceSummary(config *BenchmarkConfig, name string, results []*BenchmarkResult) {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Endurance Summary (%s)\n", name)
+	fmt.Fprintf(&sb, "=========================\n")
+	fmt.Fprintf(&sb, "%-25s %6s %12s %12s %12s %12s %9s %12s %12s %8s\n",
+		"Test", "Runs", "Mean ops/s", "Min ops/s", "Max ops/s", "Last ops/s", "Drift", "First P99", "Last P99", "Errors")
+
+	var order []string
+	byName := make(map[string][]*BenchmarkResult)
+	for _, r := range results {
+		if _, ok := byName[r.SweepLabel()]; !ok {
+			order = append(order, r.SweepLabel())
+		}
+		byName[r.SweepLabel()] = append(byName[r.SweepLabel()], r)
+	}
+
+	for _, testName := range order {
+		runs := byName[testName]
+		first, last := runs[0], runs[len(runs)-1]
+
+		var sum float64
+		var errors int64
+		minOps, maxOps := first.OpsPerSecond, first.OpsPerSecond
+		for _, r := range runs {
+			sum += r.OpsPerSecond
+			errors += r.Errors
+			minOps = min(minOps, r.OpsPerSecond)
+			maxOps = max(maxOps, r.OpsPerSecond)
+		}
+
+		drift := 0.0
+		if first.OpsPerSecond > 0 {
+			drift = 100 * (last.OpsPerSecond - first.OpsPerSecond) / first.OpsPerSecond
+		}
+
+		fmt.Fprintf(&sb, "%-25s %6d %12.2f %12.2f %12.2f %12.2f %8.1f%% %12s %12s %8d\n",
+			testName, len(runs), sum/float64(len(runs)), minOps, maxOps, last.OpsPerSecond, drift,
+			formatDuration(first.LatencyP99), formatDuration(last.LatencyP99), errors)
+	}
+
+	fmt.Printf("\n%s\n", sb.String())
+
+	path := filepath.Join(config.EnduranceDir, "summary-"+name+".txt")
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		log.Printf("Failed to write endurance summary: %v", err)
+	}
+}
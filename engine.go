@@ -0,0 +1,349 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/wildcatdb/wildcat/v2"
+)
+
+// Engine is a key-value store the workloads run against. WildcatDB is always
+// available; the adapters for other stores are compiled in with build tags,
+// so comparing against them does not make their modules a dependency of
+// every build.
+type Engine interface {
+	// Begin starts a transaction; a read-only one sees a consistent snapshot
+	Begin(writable bool) (Txn, error)
+	// Update runs fn in a read-write transaction, committing if it succeeds
+	Update(fn func(txn Txn) error) error
+	// View runs fn in a read-only transaction
+	View(fn func(txn Txn) error) error
+	Stats() string
+	Close() error
+}
+
+// Txn is a transaction of an Engine. Get returns errKeyNotFound for a
+// missing key whatever the engine, since the workloads tell misses apart
+// from failures by it.
+type Txn interface {
+	Put(key, value []byte) error
+	Get(key []byte) ([]byte, error)
+	Delete(key []byte) error
+	NewIterator(ascending bool) (Iterator, error)
+	NewRangeIterator(start, end []byte, ascending bool) (Iterator, error)
+	NewPrefixIterator(prefix []byte, ascending bool) (Iterator, error)
+	Commit() error
+	Rollback() error
+}
+
+// Iterator walks the entries of a Txn in key order. It stays valid until
+// the transaction ends.
+type Iterator interface {
+	Next() (key, value []byte, ok bool)
+}
+
+// errKeyNotFound carries WildcatDB's message for a missing key, which the
+// adapters return in place of their own.
+var errKeyNotFound = errors.New("key not found")
+
+// isNotFound reports whether err is a missing key, as Txn.Get reports one
+// with errKeyNotFound. Most runners name their error counter errors, which
+// hides the package, so they call this rather than errors.Is.
+func isNotFound(err error) bool {
+	return errors.Is(err, errKeyNotFound)
+}
+
+// errConflict is what an adapter returns from Commit in place of its own
+// error when the transaction lost a write conflict. Only Badger aborts on
+// conflicts: WildcatDB commits are last-writer-wins, Pebble batches apply
+// unconditionally and bbolt runs one writer at a time.
+var errConflict = errors.New("transaction conflict")
+
+// engines opens a database for each engine compiled in, by -engine name.
+var engines = map[string]func(config *BenchmarkConfig) (Engine, error){
+	"wildcat": openWildcat,
+}
+
+// optionalEngines are the engines behind a build tag of the same name.
+var optionalEngines = []string{"badger", "pebble", "bolt"}
+
+// compressions are the -compression settings, and compressingEngines the
+// engines that have them. WildcatDB and bbolt store data uncompressed.
+var (
+	compressions       = []string{"none", "snappy", "zstd"}
+	compressingEngines = []string{"badger", "pebble"}
+)
+
+// errEmptyValue is what an adapter's Put returns in place of its own error
+// when it refuses an empty value instead of storing it. Only WildcatDB does.
+var errEmptyValue = errors.New("value cannot be empty")
+
+// isEmptyValueRefused reports whether err is the engine refusing an empty
+// value, as the adapters report it with errEmptyValue.
+func isEmptyValueRefused(err error) bool {
+	return errors.Is(err, errEmptyValue)
+}
+
+// wildcatOnly are the benchmarks that exercise WildcatDB internals, such as
+// flushing the memtable, and have no counterpart in other engines.
+var wildcatOnly = []string{"compact", "compactwait", "spacereclaim"}
+
+// checkEngine reports whether the benchmarks in benchmarks can run on the
+// named engine in this build.
+func checkEngine(name string, benchmarks []string) error {
+	if _, ok := engines[name]; !ok {
+		var built []string
+		for engine := range engines {
+			built = append(built, engine)
+		}
+		sort.Strings(built)
+
+		if slices.Contains(optionalEngines, name) {
+			return fmt.Errorf("%s is not compiled in (build with -tags %s; compiled in: %s)", name, name, strings.Join(built, ", "))
+		}
+		return fmt.Errorf("%s (must be one of %s)", name, strings.Join(built, ", "))
+	}
+
+	if name != "wildcat" {
+		for _, benchmark := range benchmarks {
+			if slices.Contains(wildcatOnly, benchmark) {
+				return fmt.Errorf("benchmark %s needs -engine=wildcat", benchmark)
+			}
+		}
+	}
+
+	return nil
+}
+
+// prefixEnd returns the smallest key greater than every key starting with
+// prefix, or nil if there is none.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// inRange reports where key falls against the half-open range [lower,
+// upper): -1 before it, 0 inside and 1 past it. A nil bound is open.
+func inRange(key, lower, upper []byte) int {
+	if lower != nil && bytes.Compare(key, lower) < 0 {
+		return -1
+	}
+	if upper != nil && bytes.Compare(key, upper) >= 0 {
+		return 1
+	}
+	return 0
+}
+
+// update and view implement Engine.Update and Engine.View over Begin for
+// engines without their own.
+func update(db Engine, fn func(txn Txn) error) error {
+	txn, err := db.Begin(true)
+	if err != nil {
+		return err
+	}
+	if err := fn(txn); err != nil {
+		_ = txn.Rollback()
+		return err
+	}
+	return txn.Commit()
+}
+
+func view(db Engine, fn func(txn Txn) error) error {
+	txn, err := db.Begin(false)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = txn.Rollback()
+	}()
+	return fn(txn)
+}
+
+// wildcatEngine runs the workloads against WildcatDB.
+type wildcatEngine struct {
+	db *wildcat.DB
+}
+
+type wildcatTxn struct {
+	*wildcat.Txn
+}
+
+type wildcatIterator struct {
+	*wildcat.MergeIterator
+	snapshot int64 // Timestamp of the transaction, for -check_timestamps
+}
+
+func openWildcat(config *BenchmarkConfig) (Engine, error) {
+	var syncOpt wildcat.SyncOption
+	switch strings.ToLower(config.SyncOption) {
+	case "none":
+		syncOpt = wildcat.SyncNone
+	case "partial":
+		syncOpt = wildcat.SyncPartial
+	case "full":
+		syncOpt = wildcat.SyncFull
+	default:
+		return nil, fmt.Errorf("invalid sync option: %s", config.SyncOption)
+	}
+
+	opts := &wildcat.Options{
+		Directory:                config.DBPath,
+		WriteBufferSize:          config.WriteBufferSize,
+		SyncOption:               syncOpt,
+		LevelCount:               config.LevelCount,
+		BloomFilter:              config.BloomFilter,
+		MaxCompactionConcurrency: config.MaxCompactionConc,
+		STDOutLogging:            config.DBLogging,
+
+		SyncInterval:                         config.SyncInterval,
+		LevelMultiplier:                      config.LevelMultiplier,
+		BlockManagerLRUSize:                  config.BlockManagerLRUSize,
+		BlockManagerLRUEvictRatio:            config.BlockManagerLRUEvictRatio,
+		BlockManagerLRUAccesWeight:           config.BlockManagerLRUAccessWeight,
+		Permission:                           os.FileMode(config.DBPermission),
+		CompactionCooldownPeriod:             config.CompactionCooldown,
+		CompactionBatchSize:                  config.CompactionBatchSize,
+		CompactionSizeRatio:                  config.CompactionSizeRatio,
+		CompactionSizeThreshold:              config.CompactionSizeThreshold,
+		CompactionScoreSizeWeight:            config.CompactionScoreSizeWeight,
+		CompactionScoreCountWeight:           config.CompactionScoreCountWeight,
+		CompactionSizeTieredSimilarityRatio:  config.CompactionSimilarityRatio,
+		CompactionActiveSSTReadWaitBackoff:   config.CompactionReadWaitBackoff,
+		CompactionPartitionRatio:             config.CompactionPartitionRatio,
+		CompactionPartitionDistributionRatio: config.CompactionPartitionDist,
+		CompactorTickerInterval:              config.CompactorTick,
+		FlusherTickerInterval:                config.FlusherTick,
+		BloomFilterFPR:                       config.BloomFilterFPR,
+		WalAppendRetry:                       config.WalAppendRetry,
+		WalAppendBackoff:                     config.WalAppendBackoff,
+		SSTableBTreeOrder:                    config.SSTableBTreeOrder,
+		MaxConcurrentTxns:                    config.MaxConcurrentTxns,
+		TxnBeginRetry:                        config.TxnBeginRetry,
+		TxnBeginBackoff:                      config.TxnBeginBackoff,
+		TxnBeginMaxBackoff:                   config.TxnBeginMaxBackoff,
+		RecoverUncommittedTxns:               config.RecoverUncommittedTxns,
+	}
+
+	db, err := wildcat.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wildcatEngine{db: db}, nil
+}
+
+// wildcatDB returns the WildcatDB under db, for the wildcatOnly benchmarks.
+func wildcatDB(db Engine) *wildcat.DB {
+	if v, ok := db.(verifyingEngine); ok {
+		db = v.Engine
+	}
+	if r, ok := db.(readOnlyEngine); ok {
+		db = r.Engine
+	}
+	return db.(*wildcatEngine).db
+}
+
+func (e *wildcatEngine) Begin(writable bool) (Txn, error) {
+	txn, err := e.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return wildcatTxn{txn}, nil
+}
+
+func (e *wildcatEngine) Update(fn func(txn Txn) error) error {
+	return e.db.Update(func(txn *wildcat.Txn) error {
+		return fn(wildcatTxn{txn})
+	})
+}
+
+func (e *wildcatEngine) View(fn func(txn Txn) error) error {
+	return e.db.View(func(txn *wildcat.Txn) error {
+		return fn(wildcatTxn{txn})
+	})
+}
+
+func (e *wildcatEngine) Stats() string {
+	return e.db.Stats()
+}
+
+func (e *wildcatEngine) Close() error {
+	return e.db.Close()
+}
+
+// Put returns errEmptyValue for an empty value, which WildcatDB refuses by
+// message only.
+func (t wildcatTxn) Put(key, value []byte) error {
+	err := t.Txn.Put(key, value)
+	if err != nil && err.Error() == errEmptyValue.Error() {
+		return errEmptyValue
+	}
+	return err
+}
+
+// Get returns errKeyNotFound for a missing key, which WildcatDB reports by
+// message only.
+func (t wildcatTxn) Get(key []byte) ([]byte, error) {
+	value, err := t.Txn.Get(key)
+	if err != nil && err.Error() == errKeyNotFound.Error() {
+		return nil, errKeyNotFound
+	}
+	return value, err
+}
+
+func (t wildcatTxn) NewIterator(ascending bool) (Iterator, error) {
+	iter, err := t.Txn.NewIterator(ascending)
+	if err != nil {
+		return nil, err
+	}
+	return wildcatIterator{iter, t.Txn.Timestamp}, nil
+}
+
+func (t wildcatTxn) NewRangeIterator(start, end []byte, ascending bool) (Iterator, error) {
+	iter, err := t.Txn.NewRangeIterator(start, end, ascending)
+	if err != nil {
+		return nil, err
+	}
+	return wildcatIterator{iter, t.Txn.Timestamp}, nil
+}
+
+func (t wildcatTxn) NewPrefixIterator(prefix []byte, ascending bool) (Iterator, error) {
+	iter, err := t.Txn.NewPrefixIterator(prefix, ascending)
+	if err != nil {
+		return nil, err
+	}
+	return wildcatIterator{iter, t.Txn.Timestamp}, nil
+}
+
+func (i wildcatIterator) Next() ([]byte, []byte, bool) {
+	began := tsChecker.Now()
+	key, value, ts, ok := i.MergeIterator.Next()
+	if ok {
+		tsChecker.Check(i.snapshot, began, key, value, ts)
+	}
+	return key, value, ok
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build badger
+
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/options"
+)
+
+var badgerCompression = map[string]options.CompressionType{
+	"none":   options.None,
+	"snappy": options.Snappy,
+	"zstd":   options.ZSTD,
+}
+
+func init() {
+	engines["badger"] = openBadger
+}
+
+// badgerEngine runs the workloads against Badger. Its transactions abort on
+// write conflicts, which WildcatDB's never do, so under contention Badger
+// retries and abandons transactions that WildcatDB commits outright; the
+// contention benchmarks do not compare like for like.
+type badgerEngine struct {
+	db *badger.DB
+}
+
+type badgerTxn struct {
+	txn   *badger.Txn
+	iters []*badger.Iterator // Closed when the transaction ends, as Badger requires
+}
+
+type badgerIterator struct {
+	it           *badger.Iterator
+	lower, upper []byte
+	ascending    bool
+	started      bool
+}
+
+func openBadger(config *BenchmarkConfig) (Engine, error) {
+	opts := badger.DefaultOptions(config.DBPath).
+		WithSyncWrites(strings.ToLower(config.SyncOption) == "full").
+		WithMemTableSize(config.WriteBufferSize).
+		WithMaxLevels(config.LevelCount).
+		WithReadOnly(config.ReadOnly).
+		WithLogger(nil)
+	if c, ok := badgerCompression[config.Compression]; ok {
+		opts = opts.WithCompression(c)
+	}
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &badgerEngine{db: db}, nil
+}
+
+func (e *badgerEngine) Begin(writable bool) (Txn, error) {
+	return &badgerTxn{txn: e.db.NewTransaction(writable)}, nil
+}
+
+func (e *badgerEngine) Update(fn func(txn Txn) error) error {
+	return update(e, fn)
+}
+
+func (e *badgerEngine) View(fn func(txn Txn) error) error {
+	return view(e, fn)
+}
+
+func (e *badgerEngine) Stats() string {
+	return e.db.LevelsToString()
+}
+
+func (e *badgerEngine) Close() error {
+	return e.db.Close()
+}
+
+func (t *badgerTxn) Put(key, value []byte) error {
+	return t.txn.Set(key, value)
+}
+
+func (t *badgerTxn) Get(key []byte) ([]byte, error) {
+	item, err := t.txn.Get(key)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, errKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func (t *badgerTxn) Delete(key []byte) error {
+	return t.txn.Delete(key)
+}
+
+func (t *badgerTxn) NewIterator(ascending bool) (Iterator, error) {
+	return t.NewRangeIterator(nil, nil, ascending)
+}
+
+func (t *badgerTxn) NewRangeIterator(start, end []byte, ascending bool) (Iterator, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = !ascending
+	it := t.txn.NewIterator(opts)
+	t.iters = append(t.iters, it)
+	return &badgerIterator{it: it, lower: start, upper: end, ascending: ascending}, nil
+}
+
+func (t *badgerTxn) NewPrefixIterator(prefix []byte, ascending bool) (Iterator, error) {
+	return t.NewRangeIterator(prefix, prefixEnd(prefix), ascending)
+}
+
+func (t *badgerTxn) Commit() error {
+	t.closeIterators()
+	err := t.txn.Commit()
+	if errors.Is(err, badger.ErrConflict) {
+		return errConflict
+	}
+	return err
+}
+
+func (t *badgerTxn) Rollback() error {
+	t.closeIterators()
+	t.txn.Discard()
+	return nil
+}
+
+func (t *badgerTxn) closeIterators() {
+	for _, it := range t.iters {
+		it.Close()
+	}
+	t.iters = nil
+}
+
+func (i *badgerIterator) Next() ([]byte, []byte, bool) {
+	if !i.started {
+		i.started = true
+		switch {
+		case i.ascending && i.lower != nil:
+			i.it.Seek(i.lower)
+		case !i.ascending && i.upper != nil:
+			i.it.Seek(i.upper)
+		default:
+			i.it.Rewind()
+		}
+	} else {
+		i.it.Next()
+	}
+
+	for ; i.it.Valid(); i.it.Next() {
+		item := i.it.Item()
+		switch inRange(item.Key(), i.lower, i.upper) {
+		case -1:
+			if !i.ascending {
+				return nil, nil, false
+			}
+			continue
+		case 1:
+			if i.ascending {
+				return nil, nil, false
+			}
+			continue
+		}
+
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return nil, nil, false
+		}
+		return item.KeyCopy(nil), value, true
+	}
+
+	return nil, nil, false
+}
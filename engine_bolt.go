@@ -0,0 +1,181 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build bolt
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func init() {
+	engines["bolt"] = openBolt
+}
+
+// boltBucket holds every key; bbolt keeps keys in named buckets.
+var boltBucket = []byte("bench")
+
+// boltEngine runs the workloads against bbolt, a B+tree rather than an LSM.
+// It allows one read-write transaction at a time, so concurrent writers
+// queue instead of conflicting.
+type boltEngine struct {
+	db *bolt.DB
+}
+
+type boltTxn struct {
+	tx     *bolt.Tx
+	bucket *bolt.Bucket
+}
+
+type boltIterator struct {
+	cursor       *bolt.Cursor
+	lower, upper []byte
+	ascending    bool
+	started      bool
+}
+
+func openBolt(config *BenchmarkConfig) (Engine, error) {
+	if err := os.MkdirAll(config.DBPath, 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(filepath.Join(config.DBPath, "bolt.db"), 0644, &bolt.Options{
+		NoSync:   strings.ToLower(config.SyncOption) == "none",
+		ReadOnly: config.ReadOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ReadOnly {
+		// The bucket cannot be created read-only, so it must be there
+		err = db.View(func(tx *bolt.Tx) error {
+			if tx.Bucket(boltBucket) == nil {
+				return fmt.Errorf("no benchmark data in %s", db.Path())
+			}
+			return nil
+		})
+	} else {
+		err = db.Update(func(tx *bolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(boltBucket)
+			return err
+		})
+	}
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &boltEngine{db: db}, nil
+}
+
+func (e *boltEngine) Begin(writable bool) (Txn, error) {
+	tx, err := e.db.Begin(writable)
+	if err != nil {
+		return nil, err
+	}
+	return &boltTxn{tx: tx, bucket: tx.Bucket(boltBucket)}, nil
+}
+
+func (e *boltEngine) Update(fn func(txn Txn) error) error {
+	return update(e, fn)
+}
+
+func (e *boltEngine) View(fn func(txn Txn) error) error {
+	return view(e, fn)
+}
+
+func (e *boltEngine) Stats() string {
+	return fmt.Sprintf("%+v", e.db.Stats())
+}
+
+func (e *boltEngine) Close() error {
+	return e.db.Close()
+}
+
+func (t *boltTxn) Put(key, value []byte) error {
+	return t.bucket.Put(key, value)
+}
+
+func (t *boltTxn) Get(key []byte) ([]byte, error) {
+	value := t.bucket.Get(key)
+	if value == nil {
+		return nil, errKeyNotFound
+	}
+	// Only valid for the life of the transaction
+	return bytes.Clone(value), nil
+}
+
+func (t *boltTxn) Delete(key []byte) error {
+	return t.bucket.Delete(key)
+}
+
+func (t *boltTxn) NewIterator(ascending bool) (Iterator, error) {
+	return t.NewRangeIterator(nil, nil, ascending)
+}
+
+func (t *boltTxn) NewRangeIterator(start, end []byte, ascending bool) (Iterator, error) {
+	return &boltIterator{cursor: t.bucket.Cursor(), lower: start, upper: end, ascending: ascending}, nil
+}
+
+func (t *boltTxn) NewPrefixIterator(prefix []byte, ascending bool) (Iterator, error) {
+	return t.NewRangeIterator(prefix, prefixEnd(prefix), ascending)
+}
+
+func (t *boltTxn) Commit() error {
+	if !t.tx.Writable() {
+		return t.tx.Rollback()
+	}
+	return t.tx.Commit()
+}
+
+func (t *boltTxn) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func (i *boltIterator) Next() ([]byte, []byte, bool) {
+	var key, value []byte
+	switch {
+	case !i.started && i.ascending && i.lower != nil:
+		key, value = i.cursor.Seek(i.lower)
+	case !i.started && i.ascending:
+		key, value = i.cursor.First()
+	case !i.started && i.upper != nil:
+		// Seek lands on the first key at or after upper, one past the range
+		if key, _ = i.cursor.Seek(i.upper); key == nil {
+			key, value = i.cursor.Last()
+		} else {
+			key, value = i.cursor.Prev()
+		}
+	case !i.started:
+		key, value = i.cursor.Last()
+	case i.ascending:
+		key, value = i.cursor.Next()
+	default:
+		key, value = i.cursor.Prev()
+	}
+	i.started = true
+
+	if key == nil || inRange(key, i.lower, i.upper) != 0 {
+		return nil, nil, false
+	}
+	return bytes.Clone(key), bytes.Clone(value), true
+}
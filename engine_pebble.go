@@ -0,0 +1,209 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build pebble
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+)
+
+var pebbleCompression = map[string]pebble.Compression{
+	"none":   pebble.NoCompression,
+	"snappy": pebble.SnappyCompression,
+	"zstd":   pebble.ZstdCompression,
+}
+
+func init() {
+	engines["pebble"] = openPebble
+}
+
+// pebbleEngine runs the workloads against Pebble. Pebble has no
+// transactions: a read-write one is an indexed batch, applied atomically on
+// commit without conflict detection, and a read-only one reads a snapshot.
+type pebbleEngine struct {
+	db   *pebble.DB
+	sync *pebble.WriteOptions
+}
+
+type pebbleReader interface {
+	Get(key []byte) ([]byte, io.Closer, error)
+	NewIter(o *pebble.IterOptions) (*pebble.Iterator, error)
+}
+
+type pebbleTxn struct {
+	engine   *pebbleEngine
+	batch    *pebble.Batch    // Read-write transactions
+	snapshot *pebble.Snapshot // Read-only transactions
+	reader   pebbleReader
+	iters    []*pebble.Iterator
+}
+
+type pebbleIterator struct {
+	it        *pebble.Iterator
+	ascending bool
+	started   bool
+}
+
+func openPebble(config *BenchmarkConfig) (Engine, error) {
+	opts := &pebble.Options{
+		MemTableSize: uint64(config.WriteBufferSize),
+		ReadOnly:     config.ReadOnly,
+	}
+	if config.BloomFilter || config.Compression != "" {
+		opts.Levels = make([]pebble.LevelOptions, config.LevelCount)
+		for i := range opts.Levels {
+			if config.BloomFilter {
+				opts.Levels[i].FilterPolicy = bloom.FilterPolicy(10)
+			}
+			if c, ok := pebbleCompression[config.Compression]; ok {
+				opts.Levels[i].Compression = c
+			}
+		}
+	}
+
+	db, err := pebble.Open(config.DBPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sync := pebble.NoSync
+	if strings.ToLower(config.SyncOption) == "full" {
+		sync = pebble.Sync
+	}
+
+	return &pebbleEngine{db: db, sync: sync}, nil
+}
+
+func (e *pebbleEngine) Begin(writable bool) (Txn, error) {
+	if writable {
+		batch := e.db.NewIndexedBatch()
+		return &pebbleTxn{engine: e, batch: batch, reader: batch}, nil
+	}
+	snapshot := e.db.NewSnapshot()
+	return &pebbleTxn{engine: e, snapshot: snapshot, reader: snapshot}, nil
+}
+
+func (e *pebbleEngine) Update(fn func(txn Txn) error) error {
+	return update(e, fn)
+}
+
+func (e *pebbleEngine) View(fn func(txn Txn) error) error {
+	return view(e, fn)
+}
+
+func (e *pebbleEngine) Stats() string {
+	return e.db.Metrics().String()
+}
+
+func (e *pebbleEngine) Close() error {
+	return e.db.Close()
+}
+
+func (t *pebbleTxn) Put(key, value []byte) error {
+	if t.batch == nil {
+		return errors.New("put in a read-only transaction")
+	}
+	return t.batch.Set(key, value, nil)
+}
+
+func (t *pebbleTxn) Get(key []byte) ([]byte, error) {
+	value, closer, err := t.reader.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, errKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	return bytes.Clone(value), nil
+}
+
+func (t *pebbleTxn) Delete(key []byte) error {
+	if t.batch == nil {
+		return errors.New("delete in a read-only transaction")
+	}
+	return t.batch.Delete(key, nil)
+}
+
+func (t *pebbleTxn) NewIterator(ascending bool) (Iterator, error) {
+	return t.NewRangeIterator(nil, nil, ascending)
+}
+
+func (t *pebbleTxn) NewRangeIterator(start, end []byte, ascending bool) (Iterator, error) {
+	it, err := t.reader.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end})
+	if err != nil {
+		return nil, err
+	}
+	t.iters = append(t.iters, it)
+	return &pebbleIterator{it: it, ascending: ascending}, nil
+}
+
+func (t *pebbleTxn) NewPrefixIterator(prefix []byte, ascending bool) (Iterator, error) {
+	return t.NewRangeIterator(prefix, prefixEnd(prefix), ascending)
+}
+
+func (t *pebbleTxn) Commit() error {
+	if t.batch == nil {
+		return t.Rollback()
+	}
+	t.closeIterators()
+	err := t.batch.Commit(t.engine.sync)
+	_ = t.batch.Close()
+	return err
+}
+
+func (t *pebbleTxn) Rollback() error {
+	t.closeIterators()
+	if t.batch != nil {
+		return t.batch.Close()
+	}
+	return t.snapshot.Close()
+}
+
+func (t *pebbleTxn) closeIterators() {
+	for _, it := range t.iters {
+		_ = it.Close()
+	}
+	t.iters = nil
+}
+
+func (i *pebbleIterator) Next() ([]byte, []byte, bool) {
+	var ok bool
+	switch {
+	case !i.started && i.ascending:
+		ok = i.it.First()
+	case !i.started:
+		ok = i.it.Last()
+	case i.ascending:
+		ok = i.it.Next()
+	default:
+		ok = i.it.Prev()
+	}
+	i.started = true
+
+	if !ok {
+		return nil, nil, false
+	}
+	return bytes.Clone(i.it.Key()), bytes.Clone(i.it.Value()), true
+}
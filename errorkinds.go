@@ -0,0 +1,133 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// ErrorKinds breaks a benchmark's error count down by cause.
+type ErrorKinds struct {
+	NotFound int64 // Key not found where the key should exist
+	Conflict int64 // Transaction conflicts counted as errors
+	Commit   int64 // Commits failing for a reason other than a conflict or I/O
+	IO       int64 // Filesystem and system call errors
+	Corrupt  int64 // Values failing the -verify checksum
+	Other    int64
+}
+
+// commitError marks an error returned by Txn.Commit, so it can be told apart
+// from one returned by an operation inside the transaction.
+type commitError struct {
+	err error
+}
+
+func (e *commitError) Error() string { return "commit: " + e.err.Error() }
+func (e *commitError) Unwrap() error { return e.err }
+
+// wrapCommit marks a non-nil error from Txn.Commit as a commitError.
+func wrapCommit(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &commitError{err}
+}
+
+// errorKindCounters maps a benchmark's error counter to its ErrorKinds, so
+// countError can classify without every benchmark passing both around.
+var errorKindCounters sync.Map // *int64 -> *ErrorKinds
+
+// trackErrorKinds starts classifying the errors counted against errors and
+// returns the breakdown. The returned function stops tracking.
+func trackErrorKinds(errors *int64) (*ErrorKinds, func()) {
+	kinds := &ErrorKinds{}
+	errorKindCounters.Store(errors, kinds)
+	return kinds, func() { errorKindCounters.Delete(errors) }
+}
+
+// classifyError adds n to the counter in errors tracked by trackErrorKinds
+// that matches err. A conflict or I/O failure during a commit counts as such
+// rather than as a commit failure. Misses and conflicts are recognised by the
+// errKeyNotFound and errConflict the engine adapters return, not by message,
+// so WildcatDB's "transaction with ID ... not found" is not taken for a miss.
+func classifyError(counter *int64, n int64, err error) {
+	v, ok := errorKindCounters.Load(counter)
+	if !ok {
+		return
+	}
+	kinds := v.(*ErrorKinds)
+
+	var pathErr *fs.PathError
+	var syscallErr *os.SyscallError
+	var errno syscall.Errno
+	var commitErr *commitError
+
+	switch {
+	case err == nil:
+		atomic.AddInt64(&kinds.Other, n)
+	case errors.Is(err, errCorruptValue):
+		atomic.AddInt64(&kinds.Corrupt, n)
+	case isConflict(err):
+		atomic.AddInt64(&kinds.Conflict, n)
+	case errors.Is(err, errKeyNotFound):
+		atomic.AddInt64(&kinds.NotFound, n)
+	case errors.As(err, &pathErr), errors.As(err, &syscallErr), errors.As(err, &errno),
+		errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.ErrShortWrite):
+		atomic.AddInt64(&kinds.IO, n)
+	case errors.As(err, &commitErr):
+		atomic.AddInt64(&kinds.Commit, n)
+	default:
+		atomic.AddInt64(&kinds.Other, n)
+	}
+}
+
+func printErrorKinds(results []*BenchmarkResult) {
+	var labels []string
+	for _, result := range results {
+		if result.ErrorKinds != nil {
+			labels = append(labels, result.Label())
+		}
+	}
+	w := labelWidth(labels)
+
+	printed := false
+	for _, result := range results {
+		k := result.ErrorKinds
+		if k == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Errors by Kind\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-*s %10s %10s %10s %10s %10s %10s %10s\n",
+				w, "Test", "Errors", "Not found", "Conflict", "Commit", "I/O", "Corrupt", "Other")
+			printed = true
+		}
+
+		fmt.Printf("%-*s %10d %10d %10d %10d %10d %10d %10d\n",
+			w, result.Label(), result.Errors, k.NotFound, k.Conflict, k.Commit, k.IO, k.Corrupt, k.Other)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import "time"
+
+// MetricsExporter pushes live samples and final results of a benchmark to an
+// external metrics system.
+type MetricsExporter interface {
+	// Stream pushes progress every interval until the returned function is
+	// called.
+	Stream(run *LiveRun, interval time.Duration) (stop func())
+
+	// WriteResult pushes the final result of the benchmark.
+	WriteResult(r *BenchmarkResult)
+}
+
+// newExporters returns every exporter enabled by the configuration.
+func newExporters(config *BenchmarkConfig, benchmarkName string) []MetricsExporter {
+	var exporters []MetricsExporter
+
+	if e := newInfluxExporter(config, benchmarkName); e != nil {
+		exporters = append(exporters, e)
+	}
+	if e := newOTLPExporter(config, benchmarkName); e != nil {
+		exporters = append(exporters, e)
+	}
+
+	return exporters
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import "fmt"
+
+// Fairness describes how evenly work was served across worker threads. Each
+// thread's throughput is its op count divided by the time it spent inside
+// operations, so threads given equal work still differ when some of them were
+// starved under contention.
+type Fairness struct {
+	Threads    int
+	MinOpsSec  float64
+	MaxOpsSec  float64
+	MinMaxRate float64 // slowest / fastest thread throughput, 1.0 is perfectly fair
+	JainIndex  float64 // Jain's fairness index over thread throughputs, 1.0 is perfectly fair
+}
+
+// computeFairness returns nil unless at least two threads did work.
+func computeFairness(threads []ThreadProgress) *Fairness {
+	var rates []float64
+	for _, t := range threads {
+		if t.Ops == 0 || t.Busy == 0 {
+			continue
+		}
+		rates = append(rates, float64(t.Ops)/(float64(t.Busy)/1e9))
+	}
+
+	if len(rates) < 2 {
+		return nil
+	}
+
+	f := &Fairness{Threads: len(rates), MinOpsSec: rates[0], MaxOpsSec: rates[0]}
+
+	var sum, sumSquares float64
+	for _, r := range rates {
+		sum += r
+		sumSquares += r * r
+		f.MinOpsSec = min(f.MinOpsSec, r)
+		f.MaxOpsSec = max(f.MaxOpsSec, r)
+	}
+
+	f.MinMaxRate = f.MinOpsSec / f.MaxOpsSec
+	f.JainIndex = (sum * sum) / (float64(len(rates)) * sumSquares)
+
+	return f
+}
+
+func printFairness(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		if result.Fairness == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Thread Fairness\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %8s %14s %14s %10s %10s\n",
+				"Test", "Threads", "Min ops/sec", "Max ops/sec", "Min/Max", "Jain")
+			printed = true
+		}
+
+		f := result.Fairness
+		fmt.Printf("%-25s %8d %14.2f %14.2f %10.3f %10.3f\n",
+			result.Label(), f.Threads, f.MinOpsSec, f.MaxOpsSec, f.MinMaxRate, f.JainIndex)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
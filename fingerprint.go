@@ -0,0 +1,111 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// fingerprintIgnored are the configuration fields that do not change what
+// is measured: where output goes, sampling and profiling.
+var fingerprintIgnored = []string{
+	"DBPath", "ReportInterval", "Histogram", "Stats", "ResultsFile", "OutputFormat", "OutputFile",
+	"HTMLReport", "TimelineFile", "LatencyWindow", "LatencySeries", "HeatmapFile", "HeatmapFormat",
+	"SpaceInterval", "WALInterval", "CompactionInterval", "LatencyDump", "LatencyDumpFmt", "Percentiles", "InfluxURL", "InfluxToken",
+	"OTLPEndpoint", "OTLPHeaders", "ExportInterval", "CPUProfileDir", "MemProfileDir", "MemProfilePeak",
+	"MutexProfileDir", "MutexProfileRate", "BlockProfileDir", "BlockProfileRate", "ExecTrace",
+	"ExecTraceBenchmark", "ExecTraceDelay", "ExecTraceDuration", "EnduranceDir", "CheckpointInterval",
+	"EnduranceSample", "RecordTrace", "Repeat", "Baseline", "SigTest", "SigAlpha", "History",
+	"ConfigFile", "CleanupAfter", "ResumeFile", "ResumeInterval",
+	"NotifyURL", "NotifyFormat", "NotifyErrorRate", "NotifyRegression",
+}
+
+// configFingerprint hashes the configuration fields that shape the
+// workload, seed included, so results are only compared with results that
+// did exactly the same work. Every result carries the fingerprint of the
+// configuration it ran with.
+func configFingerprint(config *BenchmarkConfig) string {
+	return hashFields(fingerprintFields(config))
+}
+
+// workloadFingerprint is configFingerprint without the seed, which changes
+// the keys and values generated but not the shape of the workload. The
+// -history store groups by it, since the seed is random unless set.
+func workloadFingerprint(config *BenchmarkConfig) string {
+	fields := fingerprintFields(config)
+	delete(fields, "Seed")
+	return hashFields(fields)
+}
+
+// fingerprintFields returns the fields of config that go into its
+// fingerprint, as decoded from its JSON form.
+func fingerprintFields(config *BenchmarkConfig) map[string]any {
+	// The configuration is plain data, written whole to every results
+	// file, so it always round-trips
+	data, _ := json.Marshal(config)
+
+	// Numbers are kept as written, since a seed does not fit a float64
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var fields map[string]any
+	_ = dec.Decode(&fields)
+	for _, name := range fingerprintIgnored {
+		delete(fields, name)
+	}
+	return fields
+}
+
+func hashFields(fields map[string]any) string {
+	// Maps marshal with sorted keys, so equal configs hash equally
+	data, _ := json.Marshal(fields)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// configDiff lists the fingerprinted fields that differ between a and b,
+// to explain a fingerprint mismatch.
+func configDiff(a, b *BenchmarkConfig) []string {
+	fa, fb := fingerprintFields(a), fingerprintFields(b)
+
+	var diff []string
+	for name, va := range fa {
+		if vb, ok := fb[name]; !ok || !reflect.DeepEqual(va, vb) {
+			diff = append(diff, name)
+		}
+	}
+	for name := range fb {
+		if _, ok := fa[name]; !ok {
+			diff = append(diff, name)
+		}
+	}
+	slices.Sort(diff)
+	return diff
+}
+
+// fingerprintMismatch describes how the fingerprints of two sets of runs of
+// one benchmark differ, or returns "" when they match. Results written
+// before fingerprints were recorded are taken to match.
+func fingerprintMismatch(a, b []*BenchmarkResult) string {
+	fa, fb := a[0].Fingerprint, b[0].Fingerprint
+	if fa == "" || fb == "" || fa == fb {
+		return ""
+	}
+	return fmt.Sprintf("configurations differ (fingerprint %s vs %s)", fa, fb)
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// freshDBModes are the -fresh_db settings: when the database directory is
+// deleted so benchmarks start from a known state.
+var freshDBModes = []string{"never", "per_suite", "per_benchmark"}
+
+// existingDataBenchmarks are the benchmarks that work on the -existing_keys
+// keys a fill wrote. A fresh database is loaded with them first.
+var existingDataBenchmarks = []string{
+	"readseq", "readrandom", "readhot", "readmissing", "readwhilewriting", "mixedworkload",
+	"iterseq", "readreverse", "iterrandom", "seekrandom", "iterprefix",
+	"concurrent_read_write", "snapshotread", "updaterandom", "appendrandom",
+	"scanwhilewriting", "deletewhilewriting", "deleteseq", "deleterandom",
+	"readyourwrites", "snapshotisolation",
+}
+
+// loadBatch is how many keys each transaction of a dataset load puts.
+const loadBatch = 1000
+
+// freshDatabase deletes config's database and, when benchmarkName needs
+// existing data, writes the keys a fill would have left: fillprefixed's for
+// iterprefix, fillseq's for the rest, and records them in the manifest. It
+// returns the logical bytes loaded.
+func freshDatabase(config *BenchmarkConfig, benchmarkName string) (int64, error) {
+	if err := os.RemoveAll(config.DBPath); err != nil {
+		return 0, fmt.Errorf("failed to remove database for a fresh run: %w", err)
+	}
+	if !slices.Contains(existingDataBenchmarks, benchmarkName) {
+		return 0, nil
+	}
+
+	start := time.Now()
+	db, err := openEngine(config)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func(db Engine) {
+		_ = db.Close()
+	}(db)
+
+	key := func(i int64) []byte {
+		return generateKey(i, config.KeySize, config.KeyDistribution)
+	}
+	if benchmarkName == "iterprefix" {
+		key = func(i int64) []byte {
+			return generateKeyWithPrefix(i, config.KeySize, keyPrefixes[i%int64(len(keyPrefixes))], config.KeyDistribution)
+		}
+	}
+
+	var next, loaded int64
+	var failed atomic.Bool
+	var loadErr error
+	var wg sync.WaitGroup
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			values := valueRand(config, threadID)
+			for {
+				first := atomic.AddInt64(&next, loadBatch) - loadBatch
+				if first >= config.ExistingKeys || failed.Load() {
+					return
+				}
+
+				var written int64
+				err := db.Update(func(txn Txn) error {
+					written = 0
+					for i := first; i < min(first+loadBatch, config.ExistingKeys); i++ {
+						k, value := key(i), generateValue(values, config.ValueSize, config.CompressibleData)
+						if err := txn.Put(k, value); err != nil {
+							return err
+						}
+						written += int64(len(k) + len(value))
+					}
+					return nil
+				})
+				if err != nil {
+					if failed.CompareAndSwap(false, true) {
+						loadErr = err
+					}
+					return
+				}
+				atomic.AddInt64(&loaded, written)
+			}
+		}(t)
+	}
+	wg.Wait()
+	if loadErr != nil {
+		return 0, fmt.Errorf("failed to load the fresh database: %w", loadErr)
+	}
+
+	m := &DatasetManifest{}
+	m.describe(config)
+	if benchmarkName == "iterprefix" {
+		m.PrefixedKeys = config.ExistingKeys
+	} else {
+		m.Keys = config.ExistingKeys
+	}
+	writeManifest(config.DBPath, m)
+
+	fmt.Printf("Loaded a fresh database with %d keys (%s) in %s\n",
+		config.ExistingKeys, formatBytes(loaded), formatDuration(time.Since(start)))
+	return loaded, nil
+}
@@ -0,0 +1,443 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// fuzzPrefix starts every key the fuzz benchmark writes, keeping them apart
+// from the keys of the other benchmarks.
+const fuzzPrefix = "fuzz_"
+
+// Fuzz is what the fuzz benchmark did and the divergences it found from its
+// model. Like ReadYourWrites they are kept apart from errors.
+type Fuzz struct {
+	Puts      int64
+	Deletes   int64
+	Gets      int64
+	Scans     int64
+	Txns      int64 // Multi-operation transactions, committed or rolled back
+	Rollbacks int64
+
+	Keys       int64    // Keys the model holds at the end
+	Mismatches int64    // Gets and scans during the run disagreeing with the model
+	Missing    int64    // Keys in the model but not the database at the end
+	Unexpected int64    // Keys in the database but not the model at the end
+	WrongValue int64    // Keys whose final value is not the model's
+	Anomalies  []string `json:",omitempty"` // The first few divergences found
+
+	mu sync.Mutex // Guards Anomalies
+}
+
+func (f *Fuzz) anomaly(counter *int64, format string, args ...any) {
+	atomic.AddInt64(counter, 1)
+	f.mu.Lock()
+	if len(f.Anomalies) < maxBadKeys {
+		f.Anomalies = append(f.Anomalies, fmt.Sprintf(format, args...))
+	}
+	f.mu.Unlock()
+}
+
+// fuzzModel is one thread's model of its keys: the values each key may
+// hold, nil for absent. A key has one possible state unless an operation on
+// it failed, after which it may have been applied or not.
+type fuzzModel map[string][][]byte
+
+func (m fuzzModel) set(key, value []byte, applied bool) {
+	if applied {
+		m[string(key)] = [][]byte{value}
+		return
+	}
+	states, ok := m[string(key)]
+	if !ok {
+		states = [][]byte{nil}
+	}
+	m[string(key)] = append(states, value)
+}
+
+// matches reports whether value, nil for absent, is a state key may hold.
+func (m fuzzModel) matches(key, value []byte) bool {
+	states, ok := m[string(key)]
+	if !ok {
+		return value == nil
+	}
+	for _, s := range states {
+		if (s == nil) == (value == nil) && bytes.Equal(s, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// runFuzz runs a random interleaving of puts, deletes, gets, range scans and
+// multi-operation transactions drawn from Seed, mirroring every change into
+// an in-memory model, then diffs the database against the model. Each
+// thread owns the fuzz keys whose index is congruent to its ID, so its model
+// holds whatever the other threads do, and checks its gets and scans against
+// it as it goes. Fuzz keys left by an earlier run are deleted first.
+func runFuzz(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64, fz *Fuzz) {
+
+	classes := map[string]*LatencyTracker{}
+	for _, op := range []string{"put", "delete", "get", "scan", "txn"} {
+		classes[op] = tracker.Class(op)
+	}
+
+	threads := int64(config.NumThreads)
+	keysPerThread := max(config.ExistingKeys/threads, 1)
+	keySize := max(config.KeySize, len(fuzzPrefix)+16)
+	fuzzKey := func(keyIndex int64) []byte {
+		return generateKeyWithPrefix(keyIndex, keySize, fuzzPrefix, "sequential")
+	}
+
+	clearFuzzKeys(db, errors)
+
+	var version uint64
+	newValue := func(values *rand.Rand) []byte {
+		value := generateValue(values, config.ValueSize, config.CompressibleData)
+		// Tell apart the values of different puts even when compressible
+		if len(value) >= 8 {
+			binary.BigEndian.PutUint64(value, atomic.AddUint64(&version, 1))
+		}
+		return value
+	}
+
+	models := make([]fuzzModel, config.NumThreads)
+
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		models[t] = fuzzModel{}
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
+			nextValue := func() []byte { return newValue(values) }
+			model := models[threadID]
+			ownIndex := func() int64 {
+				return rng.Int63n(keysPerThread)*threads + int64(threadID)
+			}
+
+			for range ops.Thread(threadID) {
+				startTime := ops.StartTime(threadID)
+
+				var op string
+				var err error
+				switch r := rng.Intn(100); {
+				case r < 35:
+					op = "put"
+					key, value := fuzzKey(ownIndex()), nextValue()
+					err = db.Update(func(txn Txn) error {
+						opTrace.Record("PUT", key, len(value))
+						return txn.Put(key, value)
+					})
+					model.set(key, value, err == nil)
+					atomic.AddInt64(&fz.Puts, 1)
+					if err == nil {
+						atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+					}
+
+				case r < 50:
+					op = "delete"
+					key := fuzzKey(ownIndex())
+					err = db.Update(func(txn Txn) error {
+						opTrace.Record("DELETE", key, 0)
+						return txn.Delete(key)
+					})
+					model.set(key, nil, err == nil)
+					atomic.AddInt64(&fz.Deletes, 1)
+
+				case r < 75:
+					op = "get"
+					key := fuzzKey(ownIndex())
+					var value []byte
+					err = db.View(func(txn Txn) error {
+						var err error
+						opTrace.Record("GET", key, 0)
+						value, err = txn.Get(key)
+						return err
+					})
+					if isNotFound(err) {
+						err, value = nil, nil
+					}
+					if err == nil {
+						if !model.matches(key, value) {
+							fz.anomaly(&fz.Mismatches, "get: key %x returned a value (present %t) the model does not hold",
+								key, value != nil)
+						}
+						atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+					}
+					atomic.AddInt64(&fz.Gets, 1)
+
+				case r < 85:
+					op = "scan"
+					first := ownIndex()
+					last := min(first+rng.Int63n(100)*threads, keysPerThread*threads-1)
+					err = db.View(func(txn Txn) error {
+						iter, err := txn.NewRangeIterator(fuzzKey(first), fuzzKey(last+1), true)
+						if err != nil {
+							return err
+						}
+
+						got := map[string][]byte{}
+						for {
+							key, value, ok := iter.Next()
+							if !ok {
+								break
+							}
+							atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+							got[string(key)] = bytes.Clone(value)
+						}
+
+						for keyIndex := first; keyIndex <= last; keyIndex += threads {
+							key := fuzzKey(keyIndex)
+							if !model.matches(key, got[string(key)]) {
+								fz.anomaly(&fz.Mismatches, "scan: key %x (present %t) disagrees with the model",
+									key, got[string(key)] != nil)
+							}
+						}
+						return nil
+					})
+					atomic.AddInt64(&fz.Scans, 1)
+
+				default:
+					op = "txn"
+					err = fuzzTxn(db, rng, model, fuzzKey, ownIndex, nextValue, fz, bytesWritten)
+					atomic.AddInt64(&fz.Txns, 1)
+				}
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+				classes[op].Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+
+	diffFuzz(db, models, fz)
+}
+
+// fuzzTxn puts and deletes up to 8 random keys in one transaction, and
+// commits it or, one time in four, rolls it back. Later operations in the
+// transaction see its earlier ones, so each get in it is checked against a
+// copy of the model that the transaction's own writes are applied to.
+func fuzzTxn(db Engine, rng *rand.Rand, model fuzzModel, fuzzKey func(int64) []byte, ownIndex func() int64,
+	newValue func() []byte, fz *Fuzz, bytesWritten *int64) error {
+
+	txn, err := db.Begin(true)
+	if err != nil {
+		return err
+	}
+
+	type write struct {
+		key, value []byte // nil value for a delete
+	}
+	var writes []write
+	pending := func(key []byte) ([]byte, bool) {
+		for i := len(writes) - 1; i >= 0; i-- {
+			if bytes.Equal(writes[i].key, key) {
+				return writes[i].value, true
+			}
+		}
+		return nil, false
+	}
+
+	for n := 1 + rng.Intn(8); n > 0; n-- {
+		key := fuzzKey(ownIndex())
+		switch rng.Intn(3) {
+		case 0:
+			value := newValue()
+			opTrace.Record("PUT", key, len(value))
+			err = txn.Put(key, value)
+			writes = append(writes, write{key, value})
+		case 1:
+			opTrace.Record("DELETE", key, 0)
+			err = txn.Delete(key)
+			writes = append(writes, write{key, nil})
+		default:
+			var value []byte
+			opTrace.Record("GET", key, 0)
+			value, err = txn.Get(key)
+			if isNotFound(err) {
+				err, value = nil, nil
+			}
+			if err != nil {
+				break
+			}
+
+			if want, ok := pending(key); ok {
+				if (want == nil) != (value == nil) || !bytes.Equal(want, value) {
+					fz.anomaly(&fz.Mismatches, "txn get: key %x (present %t) disagrees with the transaction's own writes",
+						key, value != nil)
+				}
+			} else if !model.matches(key, value) {
+				fz.anomaly(&fz.Mismatches, "txn get: key %x (present %t) disagrees with the model", key, value != nil)
+			}
+		}
+		if err != nil {
+			_ = txn.Rollback()
+			return err
+		}
+	}
+
+	if rng.Intn(4) == 0 {
+		atomic.AddInt64(&fz.Rollbacks, 1)
+		return txn.Rollback()
+	}
+
+	err = wrapCommit(txn.Commit())
+	for _, w := range writes {
+		model.set(w.key, w.value, err == nil)
+		if err == nil {
+			atomic.AddInt64(bytesWritten, int64(len(w.key)+len(w.value)))
+		}
+	}
+	return err
+}
+
+// clearFuzzKeys deletes the fuzz keys of earlier runs, a thousand to a
+// transaction.
+func clearFuzzKeys(db Engine, errors *int64) {
+	for {
+		var keys [][]byte
+		err := db.View(func(txn Txn) error {
+			iter, err := txn.NewPrefixIterator([]byte(fuzzPrefix), true)
+			if err != nil {
+				return err
+			}
+			for len(keys) < 1000 {
+				key, _, ok := iter.Next()
+				if !ok {
+					break
+				}
+				keys = append(keys, bytes.Clone(key))
+			}
+			return nil
+		})
+		if err == nil && len(keys) > 0 {
+			err = db.Update(func(txn Txn) error {
+				for _, key := range keys {
+					if err := txn.Delete(key); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+		if err != nil {
+			countError(errors, 1, err)
+			return
+		}
+		if len(keys) < 1000 {
+			return
+		}
+	}
+}
+
+// mayExist reports whether any of a key's possible states is present.
+func mayExist(states [][]byte) bool {
+	return slices.ContainsFunc(states, func(s []byte) bool { return s != nil })
+}
+
+// diffFuzz compares every fuzz key in the database with the thread models.
+func diffFuzz(db Engine, models []fuzzModel, fz *Fuzz) {
+	want := fuzzModel{}
+	for _, model := range models {
+		for key, states := range model {
+			want[key] = states
+		}
+	}
+
+	seen := map[string]bool{}
+	err := db.View(func(txn Txn) error {
+		iter, err := txn.NewPrefixIterator([]byte(fuzzPrefix), true)
+		if err != nil {
+			return err
+		}
+		for {
+			key, value, ok := iter.Next()
+			if !ok {
+				return nil
+			}
+			seen[string(key)] = true
+
+			switch {
+			case !mayExist(want[string(key)]):
+				fz.anomaly(&fz.Unexpected, "final: key %x is present but the model does not hold it", key)
+			case !want.matches(key, value):
+				fz.anomaly(&fz.WrongValue, "final: key %x holds a value the model does not", key)
+			}
+		}
+	})
+	if err != nil {
+		fz.anomaly(&fz.Missing, "final: scan failed: %v", err)
+		return
+	}
+
+	for key, states := range want {
+		if mayExist(states) {
+			fz.Keys++
+		}
+		if !seen[key] && !want.matches([]byte(key), nil) {
+			fz.anomaly(&fz.Missing, "final: key %x is in the model but not the database", []byte(key))
+		}
+	}
+}
+
+func printFuzz(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		fz := result.Fuzz
+		if fz == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Fuzz\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %10s %10s %10s %10s %12s %10s %12s %10s\n",
+				"Test", "Ops", "Keys", "Mismatches", "Missing", "Unexpected", "Wrong", "Rollbacks", "Txns")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %10d %10d %10d %10d %12d %10d %12d %10d\n", result.Label(),
+			fz.Puts+fz.Deletes+fz.Gets+fz.Scans+fz.Txns, fz.Keys, fz.Mismatches, fz.Missing,
+			fz.Unexpected, fz.WrongValue, fz.Rollbacks, fz.Txns)
+		for _, a := range fz.Anomalies {
+			fmt.Printf("  %s\n", a)
+		}
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
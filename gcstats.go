@@ -0,0 +1,111 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+// GCStats is the Go runtime's allocation and garbage collection activity
+// during a benchmark. It covers the whole process, so allocations made by the
+// benchmark's own bookkeeping are included alongside WildcatDB's.
+type GCStats struct {
+	Cycles        uint32
+	AllocsPerOp   float64
+	BytesPerOp    float64
+	PauseTotal    time.Duration
+	PauseMax      time.Duration // Longest stop-the-world pause, from the last 256 cycles
+	GCCPUFraction float64       // Share of the process's CPU time spent in the GC
+}
+
+// gcCPUMetrics are the runtime/metrics samples behind GCCPUFraction.
+var gcCPUMetrics = []string{"/cpu/classes/gc/total:cpu-seconds", "/cpu/classes/total:cpu-seconds"}
+
+func readGCCPU() (gc, total float64) {
+	samples := make([]metrics.Sample, len(gcCPUMetrics))
+	for i, name := range gcCPUMetrics {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+
+	for _, s := range samples {
+		if s.Value.Kind() != metrics.KindFloat64 {
+			return 0, 0
+		}
+	}
+	return samples[0].Value.Float64(), samples[1].Value.Float64()
+}
+
+// startGCStats captures the runtime's counters and returns a function that
+// reports the activity since, given the operations completed.
+func startGCStats() func(operations int64) *GCStats {
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	gcBefore, totalBefore := readGCCPU()
+
+	return func(operations int64) *GCStats {
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		gcAfter, totalAfter := readGCCPU()
+
+		s := &GCStats{
+			Cycles:     after.NumGC - before.NumGC,
+			PauseTotal: time.Duration(after.PauseTotalNs - before.PauseTotalNs),
+		}
+		if operations > 0 {
+			s.AllocsPerOp = float64(after.Mallocs-before.Mallocs) / float64(operations)
+			s.BytesPerOp = float64(after.TotalAlloc-before.TotalAlloc) / float64(operations)
+		}
+		if cpu := totalAfter - totalBefore; cpu > 0 {
+			s.GCCPUFraction = (gcAfter - gcBefore) / cpu
+		}
+
+		// PauseNs is a ring buffer holding the most recent 256 pauses
+		for i := uint32(0); i < min(s.Cycles, uint32(len(after.PauseNs))); i++ {
+			pause := time.Duration(after.PauseNs[(after.NumGC-1-i)%uint32(len(after.PauseNs))])
+			s.PauseMax = max(s.PauseMax, pause)
+		}
+
+		return s
+	}
+}
+
+func printGCStats(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		s := result.GC
+		if s == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Go Runtime\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %8s %12s %12s %12s %12s %8s\n",
+				"Test", "GCs", "Allocs/op", "Bytes/op", "Pause total", "Pause max", "GC CPU")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %8d %12.2f %12.1f %12s %12s %7.2f%%\n",
+			result.Label(), s.Cycles, s.AllocsPerOp, s.BytesPerOp,
+			formatDuration(s.PauseTotal), formatDuration(s.PauseMax), 100*s.GCCPUFraction)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
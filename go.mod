@@ -2,7 +2,11 @@ module bench
 
 go 1.24
 
-require github.com/wildcatdb/wildcat/v2 v2.3.5
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/wildcatdb/wildcat/v2 v2.3.5
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	go.mongodb.org/mongo-driver v1.17.3 // indirect
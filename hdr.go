@@ -0,0 +1,179 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+)
+
+// Latencies are counted in an HDR-style histogram: exact below 1024ns, then
+// 512 linear sub-buckets per power of two, so any recorded value is off by
+// at most 0.2%. Values up to about 39 hours are distinguished; larger ones
+// land in the top bucket.
+const (
+	hdrSubBucketBits = 10
+	hdrSubBuckets    = 1 << hdrSubBucketBits
+	hdrHalf          = hdrSubBuckets / 2
+	hdrMaxBits       = 47
+	hdrCounts        = (hdrMaxBits - hdrSubBucketBits + 2) * hdrHalf
+)
+
+// hdrHistogram is a fixed-size latency histogram in nanoseconds. record is
+// safe for concurrent use; the other methods are meant for a snapshot.
+type hdrHistogram struct {
+	counts [hdrCounts]int64
+	total  int64
+	sum    int64
+	min    int64
+	max    int64
+}
+
+func newHdrHistogram() *hdrHistogram {
+	return &hdrHistogram{min: math.MaxInt64}
+}
+
+// hdrIndex returns the bucket holding v.
+func hdrIndex(v int64) int {
+	v = min(max(v, 0), 1<<hdrMaxBits-1)
+	if v < hdrSubBuckets {
+		return int(v)
+	}
+	shift := bits.Len64(uint64(v)) - hdrSubBucketBits
+	return (shift+1)*hdrHalf + int(v>>shift) - hdrHalf
+}
+
+// hdrRange returns the smallest value in bucket i and the bucket's width.
+func hdrRange(i int) (lower, width int64) {
+	if i < hdrSubBuckets {
+		return int64(i), 1
+	}
+	shift := i/hdrHalf - 1
+	return int64(i%hdrHalf+hdrHalf) << shift, 1 << shift
+}
+
+func (h *hdrHistogram) record(v int64) {
+	atomic.AddInt64(&h.counts[hdrIndex(v)], 1)
+	atomic.AddInt64(&h.total, 1)
+	atomic.AddInt64(&h.sum, v)
+
+	for {
+		cur := atomic.LoadInt64(&h.min)
+		if v >= cur || atomic.CompareAndSwapInt64(&h.min, cur, v) {
+			break
+		}
+	}
+	for {
+		cur := atomic.LoadInt64(&h.max)
+		if v <= cur || atomic.CompareAndSwapInt64(&h.max, cur, v) {
+			break
+		}
+	}
+}
+
+// mergeFrom adds a live histogram's counts into h, which must not be shared.
+func (h *hdrHistogram) mergeFrom(o *hdrHistogram) {
+	for i := range o.counts {
+		h.counts[i] += atomic.LoadInt64(&o.counts[i])
+	}
+	h.total += atomic.LoadInt64(&o.total)
+	h.sum += atomic.LoadInt64(&o.sum)
+	h.min = min(h.min, atomic.LoadInt64(&o.min))
+	h.max = max(h.max, atomic.LoadInt64(&o.max))
+}
+
+// percentile returns the latency at or below which fraction q of the
+// recorded values fall, as the highest value of the bucket reaching that rank.
+func (h *hdrHistogram) percentile(q float64) int64 {
+	if h.total == 0 {
+		return 0
+	}
+
+	rank := min(int64(float64(h.total)*q)+1, h.total)
+	var seen int64
+	for i, c := range h.counts {
+		seen += c
+		if seen >= rank {
+			lower, width := hdrRange(i)
+			return min(max(lower+width-1, h.min), h.max)
+		}
+	}
+	return h.max
+}
+
+// mean returns the exact average of the recorded values.
+func (h *hdrHistogram) mean() float64 {
+	if h.total == 0 {
+		return 0
+	}
+	return float64(h.sum) / float64(h.total)
+}
+
+// stddev returns the population standard deviation, taking each value to be
+// the middle of its bucket.
+func (h *hdrHistogram) stddev() float64 {
+	if h.total == 0 {
+		return 0
+	}
+
+	mean := h.mean()
+	var sumSq float64
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		lower, width := hdrRange(i)
+		d := float64(lower) + float64(width-1)/2 - mean
+		sumSq += d * d * float64(c)
+	}
+	return math.Sqrt(sumSq / float64(h.total))
+}
+
+// LatencyCounts is an HDR histogram with only its non-empty buckets, the
+// form latencies are kept in results so they can be merged.
+type LatencyCounts struct {
+	Buckets []int
+	Counts  []int64
+	Total   int64
+	Sum     int64
+	Min     int64
+	Max     int64
+}
+
+func newLatencyCounts(h *hdrHistogram) *LatencyCounts {
+	lc := &LatencyCounts{Total: h.total, Sum: h.sum, Min: h.min, Max: h.max}
+	for i, c := range h.counts {
+		if c > 0 {
+			lc.Buckets = append(lc.Buckets, i)
+			lc.Counts = append(lc.Counts, c)
+		}
+	}
+	return lc
+}
+
+// mergeInto adds the counts into h, ignoring buckets out of range.
+func (lc *LatencyCounts) mergeInto(h *hdrHistogram) {
+	for i, b := range lc.Buckets {
+		if b >= 0 && b < hdrCounts && i < len(lc.Counts) {
+			h.counts[b] += lc.Counts[i]
+		}
+	}
+	h.total += lc.Total
+	h.sum += lc.Sum
+	if lc.Total > 0 {
+		h.min = min(h.min, lc.Min)
+		h.max = max(h.max, lc.Max)
+	}
+}
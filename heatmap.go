@@ -0,0 +1,216 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// heatmapFormats lists the formats accepted by -heatmap_format.
+var heatmapFormats = []string{"hlog", "csv"}
+
+// writeHeatmap writes the latency bucket counts of every window of every
+// benchmark to path, for plotting latency over time as a heatmap.
+func writeHeatmap(path, format string, results []*BenchmarkResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if format == "csv" {
+		err = writeHeatmapCSV(f, results)
+	} else {
+		err = writeHeatmapHlog(f, results)
+	}
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeHeatmapCSV writes one benchmark,start_sec,end_sec,lower_ns,upper_ns,count
+// row per occupied bucket of each window, offsets relative to the benchmark
+// start.
+func writeHeatmapCSV(w io.Writer, results []*BenchmarkResult) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"benchmark", "start_sec", "end_sec", "lower_ns", "upper_ns", "count"})
+
+	for _, r := range results {
+		for _, win := range r.LatencySeries {
+			start := strconv.FormatFloat((win.End - win.length).Seconds(), 'f', 3, 64)
+			end := strconv.FormatFloat(win.End.Seconds(), 'f', 3, 64)
+			for _, b := range win.buckets {
+				lower, width := hdrRange(b.index)
+				_ = cw.Write([]string{
+					r.Label(), start, end,
+					strconv.FormatInt(lower, 10),
+					strconv.FormatInt(lower+width-1, 10),
+					strconv.FormatInt(b.count, 10),
+				})
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeHeatmapHlog writes an HdrHistogram interval log (format version 1.3),
+// with each benchmark's windows tagged with its label. The log can be read by
+// HistogramLogProcessor, HdrHistogram's log analyzers and heatmap plotters.
+func writeHeatmapHlog(w io.Writer, results []*BenchmarkResult) error {
+	var base time.Time
+	for _, r := range results {
+		for _, win := range r.LatencySeries {
+			if base.IsZero() || win.started.Before(base) {
+				base = win.started
+			}
+		}
+	}
+	if base.IsZero() {
+		base = time.Now()
+	}
+	baseSec := float64(base.UnixNano()) / 1e9
+
+	var sb strings.Builder
+	sb.WriteString("#[Histogram log format version 1.3]\n")
+	fmt.Fprintf(&sb, "#[StartTime: %.3f (seconds since epoch), %s]\n", baseSec, base.Format(time.UnixDate))
+	fmt.Fprintf(&sb, "#[BaseTime: %.3f (seconds since epoch)]\n", baseSec)
+	sb.WriteString(`"StartTimestamp","Interval_Length","Interval_Max","Interval_Compressed_Histogram"` + "\n")
+
+	// Tags end at the first comma and may not contain spaces
+	tagger := strings.NewReplacer(",", ";", " ", "_")
+	for _, r := range results {
+		tag := tagger.Replace(r.Label())
+		for _, win := range r.LatencySeries {
+			encoded, err := encodeHdrLogHistogram(win.buckets)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&sb, "Tag=%s,%.3f,%.3f,%.3f,%s\n", tag,
+				win.started.Sub(base).Seconds(), win.length.Seconds(),
+				float64(win.Max.Nanoseconds())/1e6, encoded)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// The interval log stores histograms in HdrHistogram's compressed V2
+// encoding: a 40-byte header followed by zig-zag LEB128 counts, where a
+// negative count is a run of empty buckets, all zlib-compressed. hdrHistogram
+// has 1024 sub-buckets per power of two, which no significant-digit setting
+// reproduces, so counts are re-bucketed into a 3-digit (2048 sub-bucket)
+// layout at the highest value of each bucket, as percentiles are reported.
+const (
+	hdrLogEncodingCookie   = 0x1c849303 | 0x10
+	hdrLogCompressedCookie = 0x1c849304 | 0x10
+	hdrLogDigits           = 3
+	hdrLogHalfBits         = 10 // log2 of half the 2048 sub-buckets of 3 digits
+)
+
+// hdrLogIndex returns the index of v in a 3-digit HdrHistogram counts array
+// with a lowest discernible value of 1.
+func hdrLogIndex(v int64) int {
+	bucket := bits.Len64(uint64(v)|(1<<(hdrLogHalfBits+1)-1)) - (hdrLogHalfBits + 1)
+	sub := int(v >> bucket)
+	return (bucket+1)<<hdrLogHalfBits + sub - 1<<hdrLogHalfBits
+}
+
+func encodeHdrLogHistogram(buckets []hdrBucketCount) (string, error) {
+	var counts []int64
+	for _, b := range buckets {
+		lower, width := hdrRange(b.index)
+		i := hdrLogIndex(lower + width - 1)
+		if i >= len(counts) {
+			counts = append(counts, make([]int64, i+1-len(counts))...)
+		}
+		counts[i] += b.count
+	}
+
+	var payload []byte
+	for i := 0; i < len(counts); {
+		c := counts[i]
+		i++
+		if c == 0 {
+			zeros := int64(1)
+			for i < len(counts) && counts[i] == 0 {
+				zeros++
+				i++
+			}
+			if zeros > 1 {
+				c = -zeros
+			}
+		}
+		payload = appendZigZag(payload, c)
+	}
+
+	var raw bytes.Buffer
+	for _, v := range []any{
+		int32(hdrLogEncodingCookie),
+		int32(len(payload)),
+		int32(0), // normalizing index offset
+		int32(hdrLogDigits),
+		int64(1),                 // lowest discernible value
+		int64(1<<hdrMaxBits - 1), // highest trackable value
+		float64(1),               // integer to double conversion ratio
+	} {
+		_ = binary.Write(&raw, binary.BigEndian, v)
+	}
+	raw.Write(payload)
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(raw.Bytes()); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	_ = binary.Write(&out, binary.BigEndian, int32(hdrLogCompressedCookie))
+	_ = binary.Write(&out, binary.BigEndian, int32(compressed.Len()))
+	out.Write(compressed.Bytes())
+
+	return base64.StdEncoding.EncodeToString(out.Bytes()), nil
+}
+
+// appendZigZag appends v in HdrHistogram's zig-zag LEB128 encoding, which
+// stores the ninth byte whole instead of continuing to a tenth.
+func appendZigZag(buf []byte, v int64) []byte {
+	u := uint64(v<<1) ^ uint64(v>>63)
+	for i := 0; i < 8; i++ {
+		if u>>7 == 0 {
+			return append(buf, byte(u))
+		}
+		buf = append(buf, byte(u&0x7f|0x80))
+		u >>= 7
+	}
+	return append(buf, byte(u))
+}
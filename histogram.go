@@ -0,0 +1,132 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// histogramBounds are the upper limits, in nanoseconds, of the log-scaled
+// latency buckets: 1, 1.2, 1.4 ... 9 times each power of ten from 100ns to
+// 100s, the same shape db_bench uses.
+var histogramBounds = func() []int64 {
+	mantissas := []float64{1, 1.2, 1.4, 1.6, 1.8, 2, 2.5, 3, 3.5, 4, 4.5, 5, 6, 7, 8, 9}
+
+	var bounds []int64
+	for decade := int64(100); decade <= int64(100*time.Second); decade *= 10 {
+		for _, m := range mantissas {
+			bounds = append(bounds, int64(m*float64(decade)))
+		}
+	}
+	return append(bounds, math.MaxInt64)
+}()
+
+// HistogramBucket counts the latencies in [LowerNs, UpperNs).
+type HistogramBucket struct {
+	LowerNs int64
+	UpperNs int64
+	Count   int64
+}
+
+// Histogram is a log-scaled latency distribution. Only non-empty buckets are
+// kept.
+type Histogram struct {
+	Count   int64
+	Min     time.Duration
+	Max     time.Duration
+	Mean    time.Duration
+	StdDev  time.Duration
+	Buckets []HistogramBucket
+}
+
+// Histogram regroups the recorded latencies into the db_bench buckets. The
+// standard deviation is computed from the HDR buckets, so it is approximate.
+func (lt *LatencyTracker) Histogram() *Histogram {
+	return lt.snapshot().histogram()
+}
+
+func (h *hdrHistogram) histogram() *Histogram {
+	hist := &Histogram{Count: h.total}
+	if hist.Count == 0 {
+		return hist
+	}
+
+	counts := make([]int64, len(histogramBounds))
+	var sumSquares float64
+
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		lower, width := hdrRange(i)
+		v := min(max(lower+width/2, h.min), h.max)
+
+		b := sort.Search(len(histogramBounds), func(b int) bool { return histogramBounds[b] > v })
+		counts[b] += c
+		sumSquares += float64(c) * float64(v) * float64(v)
+	}
+
+	mean := float64(h.sum) / float64(hist.Count)
+	hist.Min = time.Duration(h.min)
+	hist.Max = time.Duration(h.max)
+	hist.Mean = time.Duration(mean)
+	hist.StdDev = time.Duration(math.Sqrt(math.Max(0, sumSquares/float64(hist.Count)-mean*mean)))
+
+	var lower int64
+	for i, c := range counts {
+		if c > 0 {
+			hist.Buckets = append(hist.Buckets, HistogramBucket{LowerNs: lower, UpperNs: histogramBounds[i], Count: c})
+		}
+		lower = histogramBounds[i]
+	}
+
+	return hist
+}
+
+// printHistogram renders the histogram as an ASCII bar chart in the style of
+// db_bench: one row per non-empty bucket with its share, the cumulative share
+// and a bar of up to 20 marks.
+func printHistogram(name string, h *Histogram) {
+	if h == nil || h.Count == 0 {
+		return
+	}
+
+	us := func(ns int64) float64 { return float64(ns) / 1000.0 }
+
+	fmt.Printf("Latency histogram for %s (microseconds):\n", name)
+	fmt.Printf("Count: %d  Average: %.4f  StdDev: %.2f\n", h.Count, us(int64(h.Mean)), us(int64(h.StdDev)))
+	fmt.Printf("Min: %.4f  Max: %.4f\n", us(int64(h.Min)), us(int64(h.Max)))
+	fmt.Printf("%s\n", strings.Repeat("-", 72))
+
+	var cumulative int64
+	for _, b := range h.Buckets {
+		cumulative += b.Count
+		pct := 100 * float64(b.Count) / float64(h.Count)
+		marks := int(math.Round(20 * float64(b.Count) / float64(h.Count)))
+
+		upper := "inf"
+		if b.UpperNs != math.MaxInt64 {
+			upper = fmt.Sprintf("%.1f", us(b.UpperNs))
+		}
+
+		fmt.Printf("[ %10.1f, %10s ) %10d %7.3f%% %7.3f%% %s\n",
+			us(b.LowerNs), upper, b.Count, pct,
+			100*float64(cumulative)/float64(h.Count), strings.Repeat("#", marks))
+	}
+	fmt.Printf("\n")
+}
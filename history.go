@@ -0,0 +1,367 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// HistoryRecord is one benchmark result in the -history store, with what
+// it takes to find comparable results later: the fingerprint of the
+// configuration and the version of the code that produced it.
+type HistoryRecord struct {
+	Fingerprint string
+	Version     VersionInfo
+	Host        HostInfo
+	StartedAt   time.Time
+	Label       string
+	Result      *BenchmarkResult
+}
+
+// historyIndexEntry locates a record in the history file. The index sits
+// beside it as <history>.idx, so trend reads only the records it charts.
+type historyIndexEntry struct {
+	Offset      int64
+	Length      int64
+	Fingerprint string
+	Label       string
+	StartedAt   time.Time
+}
+
+// appendHistory appends a record for every result to the history file and
+// its index. The per-second timeline, latency series and histograms are
+// left out to keep the store small.
+func appendHistory(path string, config *BenchmarkConfig, startedAt time.Time, results []*BenchmarkResult) error {
+	fingerprint := workloadFingerprint(config)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	idx, err := os.OpenFile(path+".idx", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = idx.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	offset := info.Size()
+	version := collectVersionInfo()
+	host := collectHostInfo(config.DBPath)
+
+	for _, r := range results {
+		slim := *r
+		slim.Timeline, slim.LatencySeries, slim.Histogram, slim.Latency = nil, nil, nil, nil
+
+		record := HistoryRecord{
+			Fingerprint: fingerprint,
+			Version:     version,
+			Host:        host,
+			StartedAt:   startedAt,
+			Label:       r.SweepLabel(),
+			Result:      &slim,
+		}
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+		if _, err := f.Write(line); err != nil {
+			return err
+		}
+
+		entry, err := json.Marshal(historyIndexEntry{
+			Offset:      offset,
+			Length:      int64(len(line)),
+			Fingerprint: fingerprint,
+			Label:       record.Label,
+			StartedAt:   startedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := idx.Write(append(entry, '\n')); err != nil {
+			return err
+		}
+		offset += int64(len(line))
+	}
+
+	return nil
+}
+
+// readHistoryIndex loads the index of a history file, rebuilding it from
+// the records when it is missing.
+func readHistoryIndex(path string) ([]historyIndexEntry, error) {
+	data, err := os.ReadFile(path + ".idx")
+	if errors.Is(err, os.ErrNotExist) {
+		return rebuildHistoryIndex(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []historyIndexEntry
+	for i, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var e historyIndexEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return nil, fmt.Errorf("%s.idx line %d: %w", path, i+1, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func rebuildHistoryIndex(path string) ([]historyIndexEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var entries []historyIndexEntry
+	var index strings.Builder
+	var offset int64
+
+	r := bufio.NewReader(f)
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			var record HistoryRecord
+			if err := json.Unmarshal(line, &record); err != nil {
+				return nil, fmt.Errorf("%s line %d: %w", path, n, err)
+			}
+			e := historyIndexEntry{
+				Offset:      offset,
+				Length:      int64(len(line)),
+				Fingerprint: record.Fingerprint,
+				Label:       record.Label,
+				StartedAt:   record.StartedAt,
+			}
+			entry, _ := json.Marshal(e)
+			index.Write(entry)
+			index.WriteByte('\n')
+			entries = append(entries, e)
+			offset += int64(len(line))
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.WriteFile(path+".idx", []byte(index.String()), 0644); err != nil {
+		log.Printf("Failed to write history index: %v", err)
+	}
+	return entries, nil
+}
+
+// readHistoryRecords reads the records the index entries point at.
+func readHistoryRecords(path string, entries []historyIndexEntry) ([]HistoryRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	records := make([]HistoryRecord, len(entries))
+	for i, e := range entries {
+		line := make([]byte, e.Length)
+		if _, err := f.ReadAt(line, e.Offset); err != nil {
+			return nil, fmt.Errorf("%s at offset %d: %w", path, e.Offset, err)
+		}
+		if err := json.Unmarshal(line, &records[i]); err != nil {
+			return nil, fmt.Errorf("%s at offset %d: %w (is %s.idx stale?)", path, e.Offset, err, path)
+		}
+	}
+	return records, nil
+}
+
+// runTrend runs the trend subcommand: it charts one metric of one benchmark
+// across the runs in a history file that share a configuration, oldest
+// first, to show regressions too gradual to notice between two runs.
+func runTrend(args []string) {
+	fs := flag.NewFlagSet("trend", flag.ExitOnError)
+	benchmark := fs.String("benchmark", "", "Benchmark label to chart, e.g. fillrandom or readrandom[threads=8] (default: the only one stored)")
+	metric := fs.String("metric", "ops", "Metric to chart: "+strings.Join(compareMetricKeys(), ", "))
+	fingerprint := fs.String("fingerprint", "", "Configuration fingerprint to chart (default: that of the latest run)")
+	last := fs.Int("last", 0, "Chart only the latest N runs (0 = all)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s trend [flags] history.jsonl\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	path := fs.Arg(0)
+
+	var m *compareMetric
+	for i := range compareMetrics {
+		if compareMetrics[i].key == *metric {
+			m = &compareMetrics[i]
+		}
+	}
+	if m == nil {
+		log.Fatalf("Unknown metric: %s (must be one of %s)", *metric, strings.Join(compareMetricKeys(), ", "))
+	}
+
+	entries, err := readHistoryIndex(path)
+	if err != nil {
+		log.Fatalf("Failed to read history: %v", err)
+	}
+
+	label := *benchmark
+	if label == "" {
+		labels := make(map[string]bool)
+		for _, e := range entries {
+			labels[e.Label] = true
+		}
+		if len(labels) != 1 {
+			log.Fatalf("%s holds %d benchmarks, choose one with -benchmark", path, len(labels))
+		}
+		label = entries[0].Label
+	}
+
+	var matching []historyIndexEntry
+	for _, e := range entries {
+		if e.Label == label {
+			matching = append(matching, e)
+		}
+	}
+	if len(matching) == 0 {
+		log.Fatalf("No runs of %s in %s", label, path)
+	}
+	if *fingerprint == "" {
+		*fingerprint = matching[len(matching)-1].Fingerprint
+	}
+
+	var selected []historyIndexEntry
+	for _, e := range matching {
+		if e.Fingerprint == *fingerprint {
+			selected = append(selected, e)
+		}
+	}
+
+	records, err := readHistoryRecords(path, selected)
+	if err != nil {
+		log.Fatalf("Failed to read history: %v", err)
+	}
+
+	// One point per invocation, averaging its repeated runs
+	type point struct {
+		startedAt time.Time
+		revision  string
+		host      HostInfo
+		runs      []*BenchmarkResult
+	}
+	var points []*point
+	for _, record := range records {
+		if n := len(points); n > 0 && points[n-1].startedAt.Equal(record.StartedAt) {
+			points[n-1].runs = append(points[n-1].runs, record.Result)
+			continue
+		}
+		revision := record.Version.VCSRevision
+		if len(revision) > 8 {
+			revision = revision[:8]
+		}
+		if record.Version.VCSModified {
+			revision += "+"
+		}
+		if revision == "" {
+			revision = "-"
+		}
+		points = append(points, &point{startedAt: record.StartedAt, revision: revision, host: record.Host,
+			runs: []*BenchmarkResult{record.Result}})
+	}
+	if *last > 0 && len(points) > *last {
+		points = points[len(points)-*last:]
+	}
+
+	values := make([]float64, len(points))
+	var top float64
+	for i, p := range points {
+		values[i] = meanOf(p.runs, m.value)
+		top = max(top, values[i])
+	}
+
+	fmt.Printf("Trend: %s %s (fingerprint %s, %d runs)\n", label, m.name, *fingerprint, len(points))
+	fmt.Printf("=========================\n")
+	const width = 50
+	for i, p := range points {
+		bar := 0
+		if top > 0 {
+			bar = int(values[i] / top * width)
+		}
+		fmt.Printf("%s  %-9s %14s  %s\n", p.startedAt.Local().Format("2006-01-02 15:04"), p.revision,
+			m.format(values[i]), strings.Repeat("#", bar))
+	}
+
+	if len(points) > 1 {
+		first, latest := values[0], values[len(values)-1]
+		fmt.Printf("\nLatest vs first: %s", percentChange(first, latest))
+		if *metric == "ops" {
+			fmt.Printf(", vs best: %s", percentChange(top, latest))
+		}
+		fmt.Printf("\n")
+	}
+
+	hosts := make(map[string]bool)
+	for _, p := range points {
+		hosts[p.host.String()+" "+p.host.FilesystemString()] = true
+	}
+	if len(hosts) > 1 {
+		fmt.Printf("Warning: these runs come from %d different machines or filesystems\n", len(hosts))
+	}
+
+	if other := len(matching) - len(selected); other > 0 {
+		fmt.Printf("%d records of %s with other configurations not shown (see -fingerprint)\n", other, label)
+	}
+}
+
+func compareMetricKeys() []string {
+	keys := make([]string, len(compareMetrics))
+	for i, m := range compareMetrics {
+		keys[i] = m.key
+	}
+	return keys
+}
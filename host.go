@@ -0,0 +1,210 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// HostInfo describes the machine a run was produced on. Results from
+// different hardware, kernels or filesystems are not comparable, so it is
+// stored with every run. Fields read from /proc are empty outside Linux.
+type HostInfo struct {
+	Hostname     string
+	OS           string // GOOS/GOARCH
+	Kernel       string
+	CPUModel     string
+	CPUs         int
+	GOMAXPROCS   int
+	Memory       int64  // Total physical memory in bytes
+	Filesystem   string // Of the database path
+	MountPoint   string
+	MountOptions string // Per-mount and superblock options
+}
+
+// collectHostInfo describes this machine and the filesystem dbPath is on.
+func collectHostInfo(dbPath string) HostInfo {
+	host := HostInfo{
+		OS:         runtime.GOOS + "/" + runtime.GOARCH,
+		CPUs:       runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+	}
+	host.Hostname, _ = os.Hostname()
+
+	if release, err := os.ReadFile("/proc/sys/kernel/osrelease"); err == nil {
+		host.Kernel = strings.TrimSpace(string(release))
+	}
+
+	if f, err := os.Open("/proc/cpuinfo"); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			key, value, ok := strings.Cut(scanner.Text(), ":")
+			if ok && strings.TrimSpace(key) == "model name" {
+				host.CPUModel = strings.TrimSpace(value)
+				break
+			}
+		}
+		_ = f.Close()
+	}
+
+	if f, err := os.Open("/proc/meminfo"); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			// MemTotal:       16314684 kB
+			fields := strings.Fields(scanner.Text())
+			if len(fields) >= 2 && fields[0] == "MemTotal:" {
+				if kb, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+					host.Memory = kb * 1024
+				}
+				break
+			}
+		}
+		_ = f.Close()
+	}
+
+	host.MountPoint, host.Filesystem, host.MountOptions = findMount(dbPath)
+	return host
+}
+
+// findMount returns the mount point, filesystem type and options of the
+// mount holding path, from /proc/self/mountinfo. The database directory
+// may not exist yet, so the nearest existing parent is looked up.
+func findMount(path string) (mountPoint, fsType, options string) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", ""
+	}
+	for {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return "", "", ""
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	// 36 35 98:0 /mnt1 /mnt/parent rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		mount, super, ok := strings.Cut(scanner.Text(), " - ")
+		fields, superFields := strings.Fields(mount), strings.Fields(super)
+		if !ok || len(fields) < 6 || len(superFields) < 1 {
+			continue
+		}
+
+		// Later mounts stack over earlier ones, so the last match wins
+		mp := unescapeMountPath(fields[4])
+		if !within(path, mp) || len(mp) < len(mountPoint) {
+			continue
+		}
+
+		opts := strings.Split(fields[5], ",")
+		if len(superFields) >= 3 {
+			for _, opt := range strings.Split(superFields[2], ",") {
+				if !slices.Contains(opts, opt) {
+					opts = append(opts, opt)
+				}
+			}
+		}
+		mountPoint, fsType, options = mp, superFields[0], strings.Join(opts, ",")
+	}
+
+	return mountPoint, fsType, options
+}
+
+// within reports whether path is dir or inside it.
+func within(path, dir string) bool {
+	return path == dir || dir == "/" || strings.HasPrefix(path, dir+"/")
+}
+
+// unescapeMountPath decodes the octal escapes mountinfo uses for spaces,
+// tabs, newlines and backslashes in paths.
+func unescapeMountPath(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+4 <= len(s) {
+			if n, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// String summarizes the host on one line.
+func (h HostInfo) String() string {
+	cpu := h.CPUModel
+	if cpu == "" {
+		cpu = "unknown CPU"
+	}
+	s := fmt.Sprintf("%s (%s), %s x %d", h.Hostname, h.OS, cpu, h.CPUs)
+	if h.Memory > 0 {
+		s += ", " + formatBytes(h.Memory) + " memory"
+	}
+	if h.Kernel != "" {
+		s += ", kernel " + h.Kernel
+	}
+	return s
+}
+
+// FilesystemString describes the filesystem of the database path.
+func (h HostInfo) FilesystemString() string {
+	if h.Filesystem == "" {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s on %s (%s)", h.Filesystem, h.MountPoint, h.MountOptions)
+}
+
+// printHostMismatch warns about each difference between the machines two
+// runs were recorded on that affects performance. Runs recorded before host
+// information was kept are not checked.
+func printHostMismatch(a, b HostInfo) {
+	if a.OS == "" || b.OS == "" {
+		return
+	}
+
+	if a.CPUModel != b.CPUModel || a.CPUs != b.CPUs {
+		fmt.Printf("Warning: different CPUs: %s x %d vs %s x %d\n", a.CPUModel, a.CPUs, b.CPUModel, b.CPUs)
+	}
+	if a.Memory != b.Memory {
+		fmt.Printf("Warning: different memory: %s vs %s\n", formatBytes(a.Memory), formatBytes(b.Memory))
+	}
+	if a.Kernel != b.Kernel {
+		fmt.Printf("Warning: different kernels: %s vs %s\n", a.Kernel, b.Kernel)
+	}
+	if a.Filesystem != b.Filesystem || a.MountOptions != b.MountOptions {
+		fmt.Printf("Warning: different filesystems: %s vs %s\n", a.FilesystemString(), b.FilesystemString())
+	}
+}
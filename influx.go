@@ -0,0 +1,163 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// InfluxExporter streams benchmark samples to an InfluxDB write endpoint in
+// line protocol. Both the v1 (/write?db=) and v2 (/api/v2/write?org=&bucket=)
+// endpoints accept the same body; the URL is used as given.
+type InfluxExporter struct {
+	url    string
+	token  string
+	tags   string
+	client *http.Client
+	failed atomic.Bool
+}
+
+// newInfluxExporter returns nil when no endpoint is configured.
+func newInfluxExporter(config *BenchmarkConfig, benchmarkName string) *InfluxExporter {
+	if config.InfluxURL == "" {
+		return nil
+	}
+
+	host, _ := os.Hostname()
+	tags := fmt.Sprintf("benchmark=%s,host=%s,seed=%d,threads=%d,sync=%s,fingerprint=%s",
+		escapeInfluxTag(benchmarkName), escapeInfluxTag(host), config.Seed,
+		config.NumThreads, escapeInfluxTag(config.SyncOption), configFingerprint(config))
+
+	return &InfluxExporter{
+		url:    config.InfluxURL,
+		token:  config.InfluxToken,
+		tags:   tags,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func escapeInfluxTag(s string) string {
+	return strings.NewReplacer(",", `\,`, " ", `\ `, "=", `\=`).Replace(s)
+}
+
+// write posts lines to the endpoint. A failing endpoint is reported once and
+// never slows the benchmark down.
+func (e *InfluxExporter) write(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.url, strings.NewReader(strings.Join(lines, "\n")+"\n"))
+	if err != nil {
+		e.reportFailure(err)
+		return
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if e.token != "" {
+		req.Header.Set("Authorization", "Token "+e.token)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		e.reportFailure(err)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		e.reportFailure(fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body))))
+	}
+}
+
+func (e *InfluxExporter) reportFailure(err error) {
+	if e.failed.CompareAndSwap(false, true) {
+		log.Printf("InfluxDB export failed (further failures are not logged): %v", err)
+	}
+}
+
+// Stream pushes a wildcat_bench_progress point every interval until the
+// returned function is called.
+func (e *InfluxExporter) Stream(run *LiveRun, interval time.Duration) (stop func()) {
+	done := make(chan bool)
+	finished := make(chan bool)
+
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var lastOps int64
+		lastAt := run.StartTime
+		for {
+			select {
+			case now := <-ticker.C:
+				ops := atomic.LoadInt64(run.OpsCompleted)
+				rate := float64(ops-lastOps) / now.Sub(lastAt).Seconds()
+				lastOps, lastAt = ops, now
+
+				e.write([]string{fmt.Sprintf("wildcat_bench_progress,%s ops=%di,ops_per_sec=%f,errors=%di %d",
+					e.tags, ops, rate, atomic.LoadInt64(run.Errors), now.UnixNano())})
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// WriteResult pushes the final summary of a benchmark and of each of its
+// latency classes, with one field per -percentiles value.
+func (e *InfluxExporter) WriteResult(r *BenchmarkResult) {
+	now := time.Now().UnixNano()
+	lines := []string{fmt.Sprintf(
+		"wildcat_bench_result,%s operations=%di,duration_sec=%f,ops_per_sec=%f,min_ns=%di,mean_ns=%di,stddev_ns=%di,%smax_ns=%di,bytes_read=%di,bytes_written=%di,errors=%di %d",
+		e.tags, r.Operations, r.Duration.Seconds(), r.OpsPerSecond,
+		r.LatencyMin.Nanoseconds(), r.LatencyMean.Nanoseconds(), r.LatencyStdDev.Nanoseconds(),
+		influxPercentileFields(r.Percentiles), r.LatencyMax.Nanoseconds(),
+		r.BytesRead, r.BytesWritten, r.Errors, now)}
+
+	for _, c := range r.Classes {
+		lines = append(lines, fmt.Sprintf(
+			"wildcat_bench_class,%s,class=%s operations=%di,min_ns=%di,mean_ns=%di,stddev_ns=%di,%smax_ns=%di %d",
+			e.tags, escapeInfluxTag(c.Name), c.Operations,
+			c.LatencyMin.Nanoseconds(), c.LatencyMean.Nanoseconds(), c.LatencyStdDev.Nanoseconds(),
+			influxPercentileFields(c.Percentiles), c.LatencyMax.Nanoseconds(), now))
+	}
+
+	e.write(lines)
+}
+
+// influxPercentileFields renders percentiles as integer nanosecond fields,
+// each followed by a comma.
+func influxPercentileFields(ps []Percentile) string {
+	var sb strings.Builder
+	for _, p := range ps {
+		fmt.Fprintf(&sb, "%s=%di,", percentileField(p.Percentile), p.Latency.Nanoseconds())
+	}
+	return sb.String()
+}
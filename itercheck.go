@@ -0,0 +1,216 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// iteratorBenchmarks are the benchmarks -check_iterators applies to.
+var iteratorBenchmarks = []string{"iterseq", "readreverse", "iterrandom", "seekrandom", "iterprefix"}
+
+// IteratorCheck is what -check_iterators found in the keys the iterators of
+// a benchmark returned.
+type IteratorCheck struct {
+	Scans      int64
+	Keys       int64    // Keys returned
+	Expected   int64    // Seeded keys the scans should have returned before stopping
+	Missing    int64    // Seeded keys skipped over or never returned
+	OutOfOrder int64    // Keys not strictly after the previous one in the iteration order
+	OutOfRange int64    // Keys outside the range or prefix iterated
+	Anomalies  []string `json:",omitempty"` // The first few anomalies found
+}
+
+// IteratorChecker checks the scans of an iterator benchmark. Every scan is
+// checked for order and bounds. Which keys it must return is only known when
+// generated keys sort by index, which the random and zipfian encodings do
+// not, and are long enough to hold the whole index; the seeded keys are then
+// indices [0, ExistingKeys).
+type IteratorChecker struct {
+	byIndex bool
+	keySize int
+
+	statsMu sync.Mutex // Guards stats.Anomalies
+	stats   IteratorCheck
+}
+
+// iterChecker is the checker of the running benchmark, nil unless
+// -check_iterators is set. Its methods are safe to call on nil.
+var iterChecker *IteratorChecker
+
+func newIteratorChecker(config *BenchmarkConfig) *IteratorChecker {
+	return &IteratorChecker{
+		byIndex: config.KeyDistribution != "random" && config.KeyDistribution != "zipfian",
+		keySize: config.KeySize,
+	}
+}
+
+// seededKeys are the keys a scan should return, in the order it returns
+// them: count keys made by key from the indices first, first+step, ...
+type seededKeys struct {
+	first, step, count int64
+	key                func(i int64) []byte
+}
+
+func (s seededKeys) at(j int64) []byte {
+	return s.key(s.first + j*s.step)
+}
+
+// Expect returns the seeded keys with indices in [lo, hi) that are step
+// apart, in ascending or descending order, for keys made by key with a
+// prefix of prefixLen bytes before the index. It expects nothing if the keys
+// do not sort by index.
+func (c *IteratorChecker) Expect(lo, hi, step int64, ascending bool, prefixLen int,
+	key func(i int64) []byte) seededKeys {
+
+	if c == nil || !c.byIndex || c.keySize < prefixLen+16 || hi <= lo {
+		return seededKeys{}
+	}
+
+	s := seededKeys{first: lo, step: step, count: (hi - lo + step - 1) / step, key: key}
+	if !ascending {
+		s.first, s.step = lo+(s.count-1)*step, -step
+	}
+	return s
+}
+
+// ScanCheck checks the keys of one scan as they are returned. It is used by
+// one goroutine.
+type ScanCheck struct {
+	c            *IteratorChecker
+	ascending    bool
+	lower, upper []byte // nil for an open bound
+	expected     seededKeys
+	next         int64 // Position in expected of the next seeded key due
+	prev         []byte
+	keys         int64
+}
+
+// Scan starts checking a scan over [lower, upper) in the given direction
+// that should return the expected keys among any others.
+func (c *IteratorChecker) Scan(ascending bool, lower, upper []byte, expected seededKeys) *ScanCheck {
+	if c == nil {
+		return nil
+	}
+	return &ScanCheck{c: c, ascending: ascending, lower: lower, upper: upper, expected: expected}
+}
+
+// Key checks the next key the scan returned.
+func (s *ScanCheck) Key(key []byte) {
+	if s == nil {
+		return
+	}
+
+	s.keys++
+
+	if s.prev != nil {
+		cmp := bytes.Compare(s.prev, key)
+		if !s.ascending {
+			cmp = -cmp
+		}
+		if cmp >= 0 {
+			atomic.AddInt64(&s.c.stats.OutOfOrder, 1)
+			s.c.anomaly(fmt.Sprintf("out of order: key %x returned after %x", key, s.prev))
+		}
+	}
+	s.prev = bytes.Clone(key)
+
+	if inRange(key, s.lower, s.upper) != 0 {
+		atomic.AddInt64(&s.c.stats.OutOfRange, 1)
+		s.c.anomaly(fmt.Sprintf("out of range: key %x outside [%x, %x)", key, s.lower, s.upper))
+	}
+
+	// Seeded keys that sort before key in the iteration order were skipped
+	for s.next < s.expected.count {
+		want := s.expected.at(s.next)
+		cmp := bytes.Compare(want, key)
+		if !s.ascending {
+			cmp = -cmp
+		}
+		if cmp > 0 {
+			break
+		}
+		if cmp < 0 {
+			atomic.AddInt64(&s.c.stats.Missing, 1)
+			s.c.anomaly(fmt.Sprintf("missing: key %x skipped, next returned was %x", want, key))
+		}
+		s.next++
+	}
+}
+
+// Done ends the scan. exhausted is whether the iterator ran out of keys
+// rather than the scan stopping early, in which case every seeded key not
+// yet returned is missing.
+func (s *ScanCheck) Done(exhausted bool) {
+	if s == nil {
+		return
+	}
+
+	if exhausted && s.next < s.expected.count {
+		missing := s.expected.count - s.next
+		atomic.AddInt64(&s.c.stats.Missing, missing)
+		s.c.anomaly(fmt.Sprintf("missing: iterator ended after %d keys, %d seeded keys from %x not returned",
+			s.keys, missing, s.expected.at(s.next)))
+		s.next = s.expected.count
+	}
+
+	atomic.AddInt64(&s.c.stats.Scans, 1)
+	atomic.AddInt64(&s.c.stats.Keys, s.keys)
+	atomic.AddInt64(&s.c.stats.Expected, s.next)
+}
+
+func (c *IteratorChecker) anomaly(msg string) {
+	c.statsMu.Lock()
+	if len(c.stats.Anomalies) < maxBadKeys {
+		c.stats.Anomalies = append(c.stats.Anomalies, msg)
+	}
+	c.statsMu.Unlock()
+}
+
+// Result returns what the checker found. Call it once the benchmark is done.
+func (c *IteratorChecker) Result() *IteratorCheck {
+	r := c.stats
+	return &r
+}
+
+func printIteratorChecks(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		ic := result.Iterators
+		if ic == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Iterator Checks\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %8s %12s %12s %10s %12s %12s\n",
+				"Test", "Scans", "Keys", "Expected", "Missing", "Out of order", "Out of range")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %8d %12d %12d %10d %12d %12d\n", result.Label(), ic.Scans, ic.Keys,
+			ic.Expected, ic.Missing, ic.OutOfOrder, ic.OutOfRange)
+		for _, a := range ic.Anomalies {
+			fmt.Printf("  %s\n", a)
+		}
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
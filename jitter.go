@@ -0,0 +1,105 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ThroughputJitter describes how steady throughput was from second to
+// second. Write stalls show up as a sawtooth in the timeline that the
+// average ops/sec hides; here they raise StdDev and drag P1 far below P50.
+type ThroughputJitter struct {
+	Seconds    int // Full seconds measured, the trailing partial second is left out
+	MeanOpsSec float64
+	StdDev     float64
+	CV         float64 // Coefficient of variation, StdDev/MeanOpsSec
+	Min        float64
+	P1         float64
+	P50        float64
+	P99        float64
+	Max        float64
+}
+
+// computeThroughputJitter returns nil unless the timeline holds at least two
+// full seconds.
+func computeThroughputJitter(timeline []int64, duration float64) *ThroughputJitter {
+	full := min(len(timeline), int(duration))
+	if full < 2 {
+		return nil
+	}
+
+	rates := make([]float64, full)
+	for i, ops := range timeline[:full] {
+		rates[i] = float64(ops)
+	}
+	slices.Sort(rates)
+
+	// Nearest rank, matching the latency percentiles
+	rank := func(q float64) float64 {
+		return rates[min(int(float64(full)*q), full-1)]
+	}
+
+	j := &ThroughputJitter{
+		Seconds: full,
+		Min:     rates[0],
+		P1:      rank(0.01),
+		P50:     rank(0.50),
+		P99:     rank(0.99),
+		Max:     rates[full-1],
+	}
+
+	for _, r := range rates {
+		j.MeanOpsSec += r
+	}
+	j.MeanOpsSec /= float64(full)
+
+	var sumSq float64
+	for _, r := range rates {
+		sumSq += (r - j.MeanOpsSec) * (r - j.MeanOpsSec)
+	}
+	j.StdDev = math.Sqrt(sumSq / float64(full))
+	if j.MeanOpsSec != 0 {
+		j.CV = j.StdDev / j.MeanOpsSec
+	}
+
+	return j
+}
+
+func printThroughputJitter(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		if result.Jitter == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Throughput Jitter (per-second ops)\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %8s %12s %12s %8s %12s %12s %12s %12s %12s\n",
+				"Test", "Seconds", "Mean", "StdDev", "CV", "Min", "P1", "P50", "P99", "Max")
+			printed = true
+		}
+
+		j := result.Jitter
+		fmt.Printf("%-25s %8d %12.0f %12.0f %7.2f%% %12.0f %12.0f %12.0f %12.0f %12.0f\n",
+			result.Label(), j.Seconds, j.MeanOpsSec, j.StdDev, 100*j.CV, j.Min, j.P1, j.P50, j.P99, j.Max)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
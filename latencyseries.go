@@ -0,0 +1,192 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+)
+
+// LatencyWindow holds the latency percentiles of the operations recorded
+// during one -latency_window of a benchmark.
+type LatencyWindow struct {
+	End         time.Duration // Offset of the window's end from the benchmark start
+	Operations  int64
+	Percentiles []Percentile
+	Max         time.Duration
+
+	started time.Time        // Wall clock start of the window, for -heatmap_file
+	length  time.Duration    // Window length, the last one may be partial
+	buckets []hdrBucketCount // Occupied buckets, only kept for -heatmap_file
+}
+
+// hdrBucketCount is the count of one occupied hdrHistogram bucket.
+type hdrBucketCount struct {
+	index int
+	count int64
+}
+
+// LatencySampler snapshots a tracker's histograms every window and keeps the
+// percentiles of what was recorded in between, so latency spikes can be lined
+// up with the moment they happened instead of disappearing into the totals.
+type LatencySampler struct {
+	tracker     *LatencyTracker
+	percentiles []float64
+	keepBuckets bool
+	start       time.Time
+	lastAt      time.Time
+	last        *hdrHistogram
+	windows     []LatencyWindow
+	stop        chan bool
+	done        chan bool
+}
+
+// StartLatencySampler samples tracker every window, which disables sampling
+// when zero. keepBuckets keeps each window's bucket counts for heatmaps.
+func StartLatencySampler(tracker *LatencyTracker, window time.Duration, percentiles []float64, keepBuckets bool) *LatencySampler {
+	now := time.Now()
+	ls := &LatencySampler{
+		tracker:     tracker,
+		percentiles: percentiles,
+		keepBuckets: keepBuckets,
+		start:       now,
+		lastAt:      now,
+		last:        tracker.snapshot(), // Leaves out latencies restored from -resume_file
+		stop:        make(chan bool),
+		done:        make(chan bool),
+	}
+
+	go func() {
+		defer close(ls.done)
+		if window <= 0 {
+			<-ls.stop
+			return
+		}
+
+		ticker := time.NewTicker(window)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ls.sample()
+			case <-ls.stop:
+				// Keep the trailing partial window, as the timeline does
+				ls.sample()
+				return
+			}
+		}
+	}()
+
+	return ls
+}
+
+func (ls *LatencySampler) sample() {
+	now := time.Now()
+	cur := ls.tracker.snapshot()
+	h := cur.since(ls.last)
+	started := ls.lastAt
+	ls.last, ls.lastAt = cur, now
+	if h.total == 0 {
+		return
+	}
+
+	w := LatencyWindow{
+		End:         now.Sub(ls.start),
+		Operations:  h.total,
+		Percentiles: h.percentiles(ls.percentiles),
+		Max:         time.Duration(h.max),
+		started:     started,
+		length:      now.Sub(started),
+	}
+	if ls.keepBuckets {
+		for i, c := range h.counts {
+			if c > 0 {
+				w.buckets = append(w.buckets, hdrBucketCount{i, c})
+			}
+		}
+	}
+	ls.windows = append(ls.windows, w)
+}
+
+// Stop ends sampling and returns the windows that recorded any latency.
+func (ls *LatencySampler) Stop() []LatencyWindow {
+	ls.stop <- true
+	<-ls.done
+	return ls.windows
+}
+
+// since returns the values recorded in h after the snapshot prev was taken.
+// Min and max are only known to the bucket, so they are the lowest and
+// highest values of the first and last occupied buckets.
+func (h *hdrHistogram) since(prev *hdrHistogram) *hdrHistogram {
+	out := newHdrHistogram()
+	out.max = 0
+	for i := range h.counts {
+		c := h.counts[i] - prev.counts[i]
+		if c <= 0 {
+			continue
+		}
+
+		lower, width := hdrRange(i)
+		out.counts[i] = c
+		out.total += c
+		out.min = min(out.min, lower)
+		out.max = lower + width - 1
+	}
+	out.sum = h.sum - prev.sum
+	if out.total > 0 {
+		out.min = max(out.min, h.min)
+		out.max = min(out.max, h.max)
+	}
+	return out
+}
+
+// writeLatencySeriesCSV writes every benchmark's latency windows as
+// benchmark,end_sec,operations,<percentiles>,max_ns rows, one file for the
+// whole run.
+func writeLatencySeriesCSV(path string, results []*BenchmarkResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	cols := percentileColumns(results)
+	nanos := func(d time.Duration) string { return strconv.FormatInt(d.Nanoseconds(), 10) }
+
+	cw := csv.NewWriter(f)
+	header := []string{"benchmark", "end_sec", "operations"}
+	for _, p := range cols {
+		header = append(header, percentileField(p))
+	}
+	_ = cw.Write(append(header, "max_ns"))
+
+	for _, r := range results {
+		for _, w := range r.LatencySeries {
+			row := []string{r.Label(), strconv.FormatFloat(w.End.Seconds(), 'f', 3, 64), strconv.FormatInt(w.Operations, 10)}
+			row = append(row, percentileCells(w.Percentiles, cols, nanos)...)
+			_ = cw.Write(append(row, nanos(w.Max)))
+		}
+	}
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// runLongTxn holds write transactions open while short ones run beside them.
+// A quarter of the threads (at least one) each repeatedly put TxnOps keys in
+// one transaction, spread over TxnHold, and commit; the others run
+// single-put transactions, first alone as a baseline and then alongside the
+// long ones. Heap growth is sampled while the long transactions are open.
+func runLongTxn(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64) {
+
+	longTxns := tracker.Class("long_txn")
+	commits := tracker.Class("commit")
+	shortAlone := tracker.Class("short_alone")
+	shortTxns := tracker.Class("short")
+
+	longThreads := max(config.NumThreads/4, 1)
+	shortThreads := max(config.NumThreads-longThreads, 1)
+
+	nextKey := config.ExistingKeys
+
+	put := func(txn Txn, values *rand.Rand) (int64, error) {
+		key := generateKey(atomic.AddInt64(&nextKey, 1)-1, config.KeySize, config.KeyDistribution)
+		value := generateValue(values, config.ValueSize, config.CompressibleData)
+		opTrace.Record("PUT", key, len(value))
+		return int64(len(key) + len(value)), txn.Put(key, value)
+	}
+
+	// short runs n single-put transactions across the short threads
+	short := func(n int64, class *LatencyTracker) {
+		var wg sync.WaitGroup
+		ops := NewOpSchedule(config, n, shortThreads)
+
+		for t := 0; t < shortThreads; t++ {
+			wg.Add(1)
+			go func(threadID int) {
+				defer wg.Done()
+
+				values := valueRand(config, threadID)
+
+				for range ops.Thread(threadID) {
+					startTime := ops.StartTime(threadID)
+
+					var n int64
+					err := db.Update(func(txn Txn) error {
+						var err error
+						n, err = put(txn, values)
+						return err
+					})
+
+					latency := time.Since(startTime)
+					tracker.Record(threadID, latency)
+					class.Record(threadID, latency)
+
+					if err != nil {
+						countError(errors, 1, err)
+					} else {
+						atomic.AddInt64(bytesWritten, n)
+					}
+
+					atomic.AddInt64(opsCompleted, 1)
+				}
+			}(t)
+		}
+
+		wg.Wait()
+	}
+
+	short(max(config.NumOperations/10, int64(shortThreads)), shortAlone)
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	heapBefore := m.HeapAlloc
+	var heapPeak uint64
+
+	stop := make(chan struct{})
+	var longWG sync.WaitGroup
+
+	for t := 0; t < longThreads; t++ {
+		longWG.Add(1)
+		go func(threadID int) {
+			defer longWG.Done()
+
+			values := valueRand(config, threadID)
+
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				startTime := time.Now()
+
+				txn, err := db.Begin(true)
+				if err != nil {
+					countError(errors, 1, err)
+					continue
+				}
+
+				var written int64
+				for i := 0; i < config.TxnOps && err == nil; i++ {
+					var n int64
+					n, err = put(txn, values)
+					written += n
+
+					// Pace the puts so the transaction stays open for TxnHold
+					if due := startTime.Add(config.TxnHold * time.Duration(i+1) / time.Duration(config.TxnOps)); time.Until(due) > 0 {
+						time.Sleep(time.Until(due))
+					}
+				}
+				if err != nil {
+					_ = txn.Rollback()
+					countError(errors, 1, err)
+					continue
+				}
+
+				var m runtime.MemStats
+				runtime.ReadMemStats(&m)
+				for {
+					peak := atomic.LoadUint64(&heapPeak)
+					if m.HeapAlloc <= peak || atomic.CompareAndSwapUint64(&heapPeak, peak, m.HeapAlloc) {
+						break
+					}
+				}
+
+				commitStart := time.Now()
+				err = wrapCommit(txn.Commit())
+				commits.Record(threadID, time.Since(commitStart))
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+				longTxns.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesWritten, written)
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(shortThreads + t)
+	}
+
+	short(config.NumOperations, shortTxns)
+
+	close(stop)
+	longWG.Wait()
+
+	fmt.Printf("Long transactions (%d puts over %s, %d threads)\n", config.TxnOps, config.TxnHold, longThreads)
+	fmt.Printf("  Heap before: %s, peak with transactions open: %s\n",
+		formatBytes(int64(heapBefore)), formatBytes(int64(max(heapPeak, heapBefore))))
+}
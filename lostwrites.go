@@ -0,0 +1,225 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// lostWriteBenchmarks are the benchmarks -check_lost_writes applies to.
+// heavy_contention appends to its values, so every committed chunk must be
+// found; high_contention_writes overwrites them, so the final value must be
+// one that was committed.
+var lostWriteBenchmarks = []string{"high_contention_writes", "heavy_contention"}
+
+// writeTagSize is the length of the tag -check_lost_writes stamps at the
+// start of every value or chunk: the run's nonce, the thread and a sequence
+// number.
+const writeTagSize = 16
+
+// LostWrites is what -check_lost_writes found when reading back the keys a
+// contention benchmark wrote.
+type LostWrites struct {
+	Keys        int64 // Keys with at least one committed write
+	Commits     int64
+	Lost        int64 // Committed writes missing from the final values
+	Uncommitted int64 // Writes found that never reported a commit
+	Duplicated  int64 // Chunks found more than once
+	Duration    time.Duration
+	Anomalies   []string `json:",omitempty"` // The first few anomalies found
+}
+
+// WriteTagger tags the values of a contention benchmark and remembers which
+// tagged writes committed. A fresh nonce per benchmark keeps values left by
+// earlier runs from being taken for this run's writes.
+type WriteTagger struct {
+	nonce   [8]byte
+	threads []taggerThread
+}
+
+// taggerThread is only touched by its own worker until the benchmark ends.
+type taggerThread struct {
+	seq       uint64
+	committed map[uint64]int64 // Sequence number to key index
+}
+
+type writeTag struct {
+	thread int
+	seq    uint64
+}
+
+// lostWrites is the tagger of the running benchmark, nil unless
+// -check_lost_writes is set. Its methods are safe to call on nil.
+var lostWrites *WriteTagger
+
+func newWriteTagger(threads int) *WriteTagger {
+	t := &WriteTagger{threads: make([]taggerThread, threads)}
+	_, _ = rand.Read(t.nonce[:])
+	for i := range t.threads {
+		t.threads[i].committed = make(map[uint64]int64)
+	}
+	return t
+}
+
+// Tag stamps the next tag of threadID into value and returns its sequence
+// number, or 0 if value is too short to hold a tag.
+func (t *WriteTagger) Tag(threadID int, value []byte) uint64 {
+	if t == nil || len(value) < writeTagSize {
+		return 0
+	}
+
+	th := &t.threads[threadID]
+	th.seq++
+	copy(value, t.nonce[:])
+	binary.BigEndian.PutUint64(value[8:], uint64(threadID)<<48|th.seq)
+	return th.seq
+}
+
+// Committed records that the write tagged seq by threadID committed to the
+// key at keyIndex.
+func (t *WriteTagger) Committed(threadID int, seq uint64, keyIndex int64) {
+	if t == nil || seq == 0 {
+		return
+	}
+	t.threads[threadID].committed[seq] = keyIndex
+}
+
+// parse returns the tag at the start of b, if it is one of this run's.
+func (t *WriteTagger) parse(b []byte) (writeTag, bool) {
+	if len(b) < writeTagSize || !bytes.Equal(b[:8], t.nonce[:]) {
+		return writeTag{}, false
+	}
+	v := binary.BigEndian.Uint64(b[8:])
+	return writeTag{thread: int(v >> 48), seq: v & (1<<48 - 1)}, true
+}
+
+// checkLostWrites reads back every key the benchmark committed to and
+// compares what it finds with the committed writes. With appended, values
+// are chunks of chunkSize bytes appended after whatever the key held
+// before, and are parsed from the end.
+func checkLostWrites(db Engine, config *BenchmarkConfig, t *WriteTagger, appended bool, chunkSize int) *LostWrites {
+	start := time.Now()
+	lw := &LostWrites{}
+	anomaly := func(counter *int64, n int64, format string, args ...any) {
+		*counter += n
+		if len(lw.Anomalies) < maxBadKeys {
+			lw.Anomalies = append(lw.Anomalies, fmt.Sprintf(format, args...))
+		}
+	}
+
+	expected := make(map[int64][]writeTag)
+	for thread := range t.threads {
+		for seq, keyIndex := range t.threads[thread].committed {
+			expected[keyIndex] = append(expected[keyIndex], writeTag{thread, seq})
+		}
+	}
+	keyIndices := make([]int64, 0, len(expected))
+	for keyIndex := range expected {
+		keyIndices = append(keyIndices, keyIndex)
+	}
+	slices.Sort(keyIndices)
+
+	_ = db.View(func(txn Txn) error {
+		for _, keyIndex := range keyIndices {
+			committed := expected[keyIndex]
+			lw.Keys++
+			lw.Commits += int64(len(committed))
+
+			key := generateKey(keyIndex, config.KeySize, "sequential")
+			value, err := txn.Get(key)
+			if err != nil {
+				anomaly(&lw.Lost, int64(len(committed)), "key %x: %v, %d committed writes lost", key, err, len(committed))
+				continue
+			}
+
+			isCommitted := func(tag writeTag) bool {
+				if tag.thread >= len(t.threads) {
+					return false
+				}
+				k, ok := t.threads[tag.thread].committed[tag.seq]
+				return ok && k == keyIndex
+			}
+
+			if !appended {
+				tag, ok := t.parse(value)
+				switch {
+				case !ok:
+					anomaly(&lw.Lost, int64(len(committed)), "key %x: final value was not written by this run, %d committed writes lost",
+						key, len(committed))
+				case !isCommitted(tag):
+					anomaly(&lw.Uncommitted, 1, "key %x: final value written by thread %d seq %d, which did not commit", key, tag.thread, tag.seq)
+				}
+				continue
+			}
+
+			found := make(map[writeTag]bool)
+			for len(value) >= chunkSize {
+				tag, ok := t.parse(value[len(value)-chunkSize:])
+				if !ok {
+					break
+				}
+				value = value[:len(value)-chunkSize]
+
+				switch {
+				case found[tag]:
+					anomaly(&lw.Duplicated, 1, "key %x: thread %d seq %d appears more than once", key, tag.thread, tag.seq)
+				case !isCommitted(tag):
+					anomaly(&lw.Uncommitted, 1, "key %x: thread %d seq %d is present but did not commit", key, tag.thread, tag.seq)
+				}
+				found[tag] = true
+			}
+			for _, tag := range committed {
+				if !found[tag] {
+					anomaly(&lw.Lost, 1, "key %x: committed write thread %d seq %d is missing", key, tag.thread, tag.seq)
+				}
+			}
+		}
+		return nil
+	})
+
+	lw.Duration = time.Since(start)
+	return lw
+}
+
+func printLostWrites(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		lw := result.LostWrites
+		if lw == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Lost Writes\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %8s %10s %8s %12s %10s\n", "Test", "Keys", "Commits", "Lost", "Uncommitted", "Duplicated")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %8d %10d %8d %12d %10d\n", result.Label(), lw.Keys, lw.Commits,
+			lw.Lost, lw.Uncommitted, lw.Duplicated)
+		for _, a := range lw.Anomalies {
+			fmt.Printf("  %s\n", a)
+		}
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -14,181 +14,514 @@
 package main
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"os"
-	"runtime"
-	"sort"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
-
-	"github.com/wildcatdb/wildcat/v2"
 )
 
-type BenchmarkConfig struct {
-	// Database configuration
-	DBPath            string
-	WriteBufferSize   int64
-	SyncOption        string
-	LevelCount        int
-	BloomFilter       bool
-	MaxCompactionConc int
-
-	// Benchmark parameters
-	NumOperations int64
-	KeySize       int
-	ValueSize     int
-	NumThreads    int
-	BatchSize     int
-
-	// Test types
-	Benchmarks []string
-	ReadRatio  int // For mixed workloads (0-100)
-
-	// Data distribution
-	KeyDistribution string // sequential, random, zipfian
-	ExistingKeys    int64  // Number of existing keys for read tests
-
-	// Reporting
-	ReportInterval time.Duration
-	Histogram      bool
-	Stats          bool
-
-	// Advanced options
-	UseTransactions  bool
-	IteratorTests    bool
-	CompressibleData bool
-	Seed             int64
-
-	// Cleanup
-	CleanupAfter bool
-}
-
 type BenchmarkResult struct {
-	TestName     string
-	Operations   int64
-	Duration     time.Duration
-	OpsPerSecond float64
-	LatencyP50   time.Duration
-	LatencyP95   time.Duration
-	LatencyP99   time.Duration
-	LatencyMax   time.Duration
-	BytesRead    int64
-	BytesWritten int64
-	Errors       int64
+	TestName          string
+	Operations        int64
+	Duration          time.Duration
+	OpsPerSecond      float64
+	LatencyP50        time.Duration
+	LatencyP95        time.Duration
+	LatencyP99        time.Duration
+	LatencyMax        time.Duration
+	LatencyMin        time.Duration
+	LatencyMean       time.Duration
+	LatencyStdDev     time.Duration
+	Percentiles       []Percentile // At each of -percentiles
+	BytesRead         int64
+	BytesWritten      int64
+	Errors            int64
+	EmptyRefused      int64 `json:",omitempty"` // Empty values the engine refused, boundarykeys only; not in Operations or Errors
+	Classes           []*LatencyClass
+	Fairness          *Fairness
+	Jitter            *ThroughputJitter
+	ErrorKinds        *ErrorKinds `json:",omitempty"` // Errors broken down by cause
+	Resources         *ResourceUsage
+	Space             *SpaceUsage
+	WAL               *WALStats        `json:",omitempty"` // WAL rotations and growth, WildcatDB only
+	Compaction        *CompactionStats `json:",omitempty"` // Flushes, compactions and latency during them, WildcatDB only
+	PageCache         *PageCacheStats  `json:",omitempty"` // File reads served from the page cache, Linux only
+	GC                *GCStats
+	Timeline          []int64         // Ops completed in each second of the run
+	LatencySeries     []LatencyWindow // Percentiles per -latency_window
+	Histogram         *Histogram
+	Txn               *TxnStats          `json:",omitempty"` // Conflict and retry counts for transaction benchmarks
+	Run               int                `json:",omitempty"` // Repetition number with -repeat
+	Sweep             string             `json:",omitempty"` // Sweep point, name=value,...
+	Variant           string             `json:",omitempty"` // A/B comparison variant, A or B
+	Fingerprint       string             // Hash of the configuration the benchmark ran with
+	Verify            *VerifyStats       `json:",omitempty"` // Values checked with -verify
+	Validation        *Validation        `json:",omitempty"` // Read-back of a fill with -validate
+	Consistency       *Consistency       `json:",omitempty"` // Anomalies found by -check_consistency
+	LostWrites        *LostWrites        `json:",omitempty"` // Read-back of a contention benchmark with -check_lost_writes
+	Iterators         *IteratorCheck     `json:",omitempty"` // Order and completeness of an iterator benchmark's scans with -check_iterators
+	Timestamps        *TimestampCheck    `json:",omitempty"` // MVCC timestamps returned by iterators with -check_timestamps
+	ReadYourWrites    *ReadYourWrites    `json:",omitempty"` // Violations found by readyourwrites
+	SnapshotIsolation *SnapshotIsolation `json:",omitempty"` // Anomalies found by snapshotisolation
+	Fuzz              *Fuzz              `json:",omitempty"` // Divergences from the model found by fuzz
+	Compact           *CompactRun        `json:",omitempty"` // Time to settle and bytes rewritten by compact and compactwait
+	Reopen            *Reopen            `json:",omitempty"` // Open and WAL replay times by database size from reopen
+	Latency           *LatencyCounts     `json:",omitempty"` // Every latency, kept so results can be merged
 }
 
 type LatencyTracker struct {
-	mu        sync.Mutex
-	latencies []time.Duration
+	recorders []latencyRecorder // One per thread
+	threads   int
+
+	keepRaw bool
+
+	classMu    sync.Mutex
+	classes    map[string]*LatencyTracker
+	classOrder []string
 }
 
-func (lt *LatencyTracker) Record(latency time.Duration) {
-	lt.mu.Lock()
-	lt.latencies = append(lt.latencies, latency)
-	lt.mu.Unlock()
+// latencyRecorder is one worker's share of a LatencyTracker. Only its own
+// worker writes to it, so recording never waits on another thread; the
+// trailing pad keeps neighbouring workers' counters off its cache lines.
+type latencyRecorder struct {
+	progress ThreadProgress
+	hist     atomic.Pointer[hdrHistogram] // Allocated on first use
+
+	// Every latency in recording order, kept only for -latency_dump
+	rawMu sync.Mutex
+	raw   []time.Duration
+
+	_ [64]byte
 }
 
-func (lt *LatencyTracker) GetPercentiles() (p50, p95, p99, max time.Duration) {
-	lt.mu.Lock()
-	defer lt.mu.Unlock()
+// LatencyClass is the latency breakdown for one class of operation within a
+// benchmark, such as hits vs misses.
+type LatencyClass struct {
+	Name       string
+	Operations int64
+	LatencyP50 time.Duration
+	LatencyP95 time.Duration
+	LatencyP99 time.Duration
+	LatencyMax time.Duration
+
+	LatencyMin    time.Duration
+	LatencyMean   time.Duration
+	LatencyStdDev time.Duration
+	Percentiles   []Percentile // At each of -percentiles
+}
+
+// ThreadProgress is a worker's live position, readable while the benchmark runs.
+type ThreadProgress struct {
+	Ops         int64
+	LastLatency int64
+	Busy        int64 // Total nanoseconds spent inside recorded operations
+}
+
+func NewLatencyTracker(threads int) *LatencyTracker {
+	return &LatencyTracker{
+		recorders: make([]latencyRecorder, max(threads, 1)),
+		threads:   threads,
+	}
+}
+
+// restore seeds the tracker with latencies recorded before a resume.
+func (lt *LatencyTracker) restore(h *hdrHistogram) {
+	lt.recorders[0].hist.Store(h)
+}
+
+// KeepRaw makes the tracker also keep every latency for dumpLatencies. That
+// costs memory per operation, so it is off by default.
+func (lt *LatencyTracker) KeepRaw() {
+	lt.keepRaw = true
+}
+
+func (lt *LatencyTracker) Record(threadID int, latency time.Duration) {
+	// Workers outside the thread range share a recorder with another thread,
+	// which is safe, just not contention-free
+	r := &lt.recorders[max(threadID, 0)%len(lt.recorders)]
+
+	if threadID >= 0 && threadID < lt.threads {
+		atomic.AddInt64(&r.progress.Ops, 1)
+		atomic.StoreInt64(&r.progress.LastLatency, int64(latency))
+		atomic.AddInt64(&r.progress.Busy, int64(latency))
+	}
+
+	h := r.hist.Load()
+	if h == nil {
+		r.hist.CompareAndSwap(nil, newHdrHistogram())
+		h = r.hist.Load()
+	}
+	h.record(int64(latency))
+
+	if lt.keepRaw {
+		r.rawMu.Lock()
+		r.raw = append(r.raw, latency)
+		r.rawMu.Unlock()
+	}
+}
+
+// snapshot merges every thread's histogram.
+func (lt *LatencyTracker) snapshot() *hdrHistogram {
+	merged := newHdrHistogram()
+	for i := range lt.recorders {
+		if h := lt.recorders[i].hist.Load(); h != nil {
+			merged.mergeFrom(h)
+		}
+	}
+	return merged
+}
+
+// Class returns the sub-tracker for a named class of operations, creating it on
+// first use. Resolve classes before the hot loop; every call takes a lock.
+func (lt *LatencyTracker) Class(name string) *LatencyTracker {
+	lt.classMu.Lock()
+	defer lt.classMu.Unlock()
+
+	if lt.classes == nil {
+		lt.classes = make(map[string]*LatencyTracker)
+	}
+	if c, ok := lt.classes[name]; ok {
+		return c
+	}
+
+	c := NewLatencyTracker(lt.threads)
+	lt.classes[name] = c
+	lt.classOrder = append(lt.classOrder, name)
+	return c
+}
+
+// Classes summarizes every class that recorded at least one latency, in the
+// order the classes were created, with the latency at each of percentiles.
+func (lt *LatencyTracker) Classes(percentiles []float64) []*LatencyClass {
+	lt.classMu.Lock()
+	defer lt.classMu.Unlock()
+
+	var out []*LatencyClass
+	for _, name := range lt.classOrder {
+		h := lt.classes[name].snapshot()
+		if h.total == 0 {
+			continue
+		}
+
+		out = append(out, &LatencyClass{
+			Name:       name,
+			Operations: h.total,
+			LatencyP50: time.Duration(h.percentile(0.50)),
+			LatencyP95: time.Duration(h.percentile(0.95)),
+			LatencyP99: time.Duration(h.percentile(0.99)),
+			LatencyMax: time.Duration(h.max),
+
+			LatencyMin:    time.Duration(h.min),
+			LatencyMean:   time.Duration(h.mean()),
+			LatencyStdDev: time.Duration(h.stddev()),
+			Percentiles:   h.percentiles(percentiles),
+		})
+	}
+	return out
+}
+
+// Threads returns a snapshot of every worker's progress.
+func (lt *LatencyTracker) Threads() []ThreadProgress {
+	snapshot := make([]ThreadProgress, lt.threads)
+	for i := range snapshot {
+		p := &lt.recorders[i].progress
+		snapshot[i].Ops = atomic.LoadInt64(&p.Ops)
+		snapshot[i].LastLatency = atomic.LoadInt64(&p.LastLatency)
+		snapshot[i].Busy = atomic.LoadInt64(&p.Busy)
+	}
+	return snapshot
+}
 
-	if len(lt.latencies) == 0 {
+func (lt *LatencyTracker) GetPercentiles() (p50, p95, p99, max time.Duration) {
+	h := lt.snapshot()
+	if h.total == 0 {
 		return 0, 0, 0, 0
 	}
 
-	sort.Slice(lt.latencies, func(i, j int) bool {
-		return lt.latencies[i] < lt.latencies[j]
-	})
+	return time.Duration(h.percentile(0.50)), time.Duration(h.percentile(0.95)),
+		time.Duration(h.percentile(0.99)), time.Duration(h.max)
+}
 
-	n := len(lt.latencies)
-	p50 = lt.latencies[int(float64(n)*0.50)]
-	p95 = lt.latencies[int(float64(n)*0.95)]
-	p99 = lt.latencies[int(float64(n)*0.99)]
-	max = lt.latencies[n-1]
+// GetSpread returns the lowest, mean and standard deviation of the recorded
+// latencies.
+func (lt *LatencyTracker) GetSpread() (min, mean, stddev time.Duration) {
+	h := lt.snapshot()
+	if h.total == 0 {
+		return 0, 0, 0
+	}
 
-	return
+	return time.Duration(h.min), time.Duration(h.mean()), time.Duration(h.stddev())
 }
 
 func main() {
-	config := parseFlags()
-	fmt.Println(`
-W)      ww I)iiii L)       D)dddd     C)ccc    A)aa   T)tttttt 
-W)      ww   I)   L)       D)   dd   C)   cc  A)  aa     T)    
-W)  ww  ww   I)   L)       D)    dd C)       A)    aa    T)    
-W)  ww  ww   I)   L)       D)    dd C)       A)aaaaaa    T)    
-W)  ww  ww   I)   L)       D)    dd  C)   cc A)    aa    T)    
- W)ww www  I)iiii L)llllll D)ddddd    C)ccc  A)    aa    T)`)
+	handleDumpSignals()
 
-	fmt.Printf("Benchmark Tool\n\n")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "rerun":
+			if _, _, err := runBench(parseRerunFlags(os.Args[2:])); err != nil {
+				log.Fatalf("%v", err)
+			}
+			return
+		case "endurance":
+			runEndurance(parseFlags(os.Args[2:]))
+			return
+		case "crash":
+			runCrash(parseFlags(os.Args[2:]), os.Args[2:])
+			return
+		case "durability":
+			runDurability(parseFlags(os.Args[2:]), os.Args[2:])
+			return
+		case "crash-writer":
+			runCrashWriter(parseFlags(os.Args[2:]))
+			return
+		case "compare":
+			runCompare(os.Args[2:])
+			return
+		case "trend":
+			runTrend(os.Args[2:])
+			return
+		case "versions":
+			runVersions(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "daemon":
+			runDaemon(os.Args[2:])
+			return
+		case "merge":
+			runMerge(os.Args[2:])
+			return
+		case "coordinator":
+			runCoordinator(os.Args[2:])
+			return
+		case "worker":
+			runWorker(os.Args[2:])
+			return
+		}
+	}
+
+	if _, _, err := runBench(parseFlags(os.Args[1:])); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+// runBench runs the benchmarks of config, prints and writes their results,
+// and returns when the run started and the results. A run that cannot go on,
+// such as one whose database fails to open, stops there and returns the
+// error without writing any results.
+func runBench(config *BenchmarkConfig) (time.Time, []*BenchmarkResult, error) {
+	// Keep stdout clean for the structured document; everything printed for
+	// humans goes to stderr instead
+	stdout := os.Stdout
+	if structuredOutputToStdout(config) {
+		os.Stdout = os.Stderr
+		defer func() {
+			os.Stdout = stdout
+		}()
+	}
+
+	printBanner()
+	if config.UseExistingDB {
+		if err := useExistingDB(config); err != nil {
+			return time.Time{}, nil, err
+		}
+	}
+	if config.ResumeFile != "" {
+		cp, err := openCheckpoint(config)
+		if err != nil {
+			return time.Time{}, nil, err
+		}
+		suiteCheckpoint = cp
+	}
 	printConfig(config)
 
-	if config.CleanupAfter {
+	stopTrace, err := startTraceRecording(config)
+	if err != nil {
+		suiteCheckpoint = nil
+		return time.Time{}, nil, err
+	}
+	defer stopTrace()
+
+	if config.CleanupAfter && !config.ReadOnly && !config.UseExistingDB {
 		defer func() {
-			if err := os.RemoveAll(config.DBPath); err != nil {
-				log.Printf("Failed to cleanup database: %v", err)
-			} else {
-				fmt.Printf("Cleaned up database directory: %s\n", config.DBPath)
+			for _, path := range config.databasePaths() {
+				if err := os.RemoveAll(path); err != nil {
+					log.Printf("Failed to cleanup database: %v", err)
+				} else {
+					fmt.Printf("Cleaned up database directory: %s\n", path)
+				}
 			}
 		}()
 	}
 
-	results := runBenchmarks(config)
+	// Load the baseline up front, so a bad path fails before anything runs
+	var baseline *ResultsFile
+	if config.Baseline != "" {
+		rf, err := readResultsFile(config.Baseline)
+		if err != nil {
+			suiteCheckpoint = nil
+			return time.Time{}, nil, fmt.Errorf("failed to load baseline: %w", err)
+		}
+		baseline = rf
+	}
+
+	startedAt := time.Now()
+	if suiteCheckpoint != nil {
+		startedAt = suiteCheckpoint.startedAt()
+	}
+	if config.NotifyURL != "" {
+		suiteNotifier = newNotifier(config, baseline, startedAt)
+		defer func() {
+			suiteNotifier = nil
+		}()
+	}
+	stopDashboard := startDashboard(config)
+	results, err := runBenchmarks(config)
+	stopDashboard()
+	if err != nil {
+		// The checkpoint keeps what finished, for -resume_file to pick up
+		suiteCheckpoint = nil
+		return startedAt, results, err
+	}
+	if suiteCheckpoint != nil {
+		suiteCheckpoint.finish()
+		suiteCheckpoint = nil
+	}
 
 	printResults(results)
-}
+	printSweepGrid(config, results)
+	printABComparison(config, results)
+	if baseline != nil {
+		printBaselineComparison(config, config.Baseline, baseline.Results, results)
+	}
+
+	if err := emitOutput(stdout, config, startedAt, results); err != nil {
+		log.Printf("Failed to write %s output: %v", config.OutputFormat, err)
+	}
+
+	if config.ResultsFile != "" {
+		if err := writeResultsFile(config.ResultsFile, config, startedAt, results); err != nil {
+			log.Printf("Failed to write results file: %v", err)
+		} else {
+			fmt.Printf("Results written to: %s\n", config.ResultsFile)
+		}
+	}
+
+	if config.History != "" {
+		if err := appendHistory(config.History, config, startedAt, results); err != nil {
+			log.Printf("Failed to append to history: %v", err)
+		} else {
+			fmt.Printf("Results appended to history: %s\n", config.History)
+		}
+	}
+
+	if config.TimelineFile != "" {
+		if err := writeTimelineCSV(config.TimelineFile, results); err != nil {
+			log.Printf("Failed to write timeline: %v", err)
+		} else {
+			fmt.Printf("Timeline written to: %s\n", config.TimelineFile)
+		}
+	}
+
+	if config.LatencySeries != "" {
+		if err := writeLatencySeriesCSV(config.LatencySeries, results); err != nil {
+			log.Printf("Failed to write latency series: %v", err)
+		} else {
+			fmt.Printf("Latency series written to: %s\n", config.LatencySeries)
+		}
+	}
+
+	if config.HeatmapFile != "" {
+		if err := writeHeatmap(config.HeatmapFile, config.HeatmapFormat, results); err != nil {
+			log.Printf("Failed to write heatmap: %v", err)
+		} else {
+			fmt.Printf("Heatmap written to: %s\n", config.HeatmapFile)
+		}
+	}
+
+	if config.HTMLReport != "" {
+		if err := writeHTMLReport(config.HTMLReport, config, startedAt, results); err != nil {
+			log.Printf("Failed to write HTML report: %v", err)
+		} else {
+			fmt.Printf("HTML report written to: %s\n", config.HTMLReport)
+		}
+	}
 
-func parseFlags() *BenchmarkConfig {
-	config := &BenchmarkConfig{}
+	suiteNotifier.finished(results)
 
-	// Database configuration
-	flag.StringVar(&config.DBPath, "db", "/tmp/wildcat_bench", "Database directory path")
-	flag.Int64Var(&config.WriteBufferSize, "write_buffer_size", 64*1024*1024, "Write buffer size in bytes")
-	flag.StringVar(&config.SyncOption, "sync", "none", "Sync option: none, partial, full")
-	flag.IntVar(&config.LevelCount, "levels", 7, "Number of LSM levels")
-	flag.BoolVar(&config.BloomFilter, "bloom_filter", true, "Enable bloom filters")
-	flag.IntVar(&config.MaxCompactionConc, "max_compaction_concurrency", 4, "Max compaction concurrency")
+	return startedAt, results, nil
+}
 
-	// Benchmark parameters
-	flag.Int64Var(&config.NumOperations, "num", 10000, "Number of operations")
-	flag.IntVar(&config.KeySize, "key_size", 16, "Size of keys in bytes")
-	flag.IntVar(&config.ValueSize, "value_size", 100, "Size of values in bytes")
-	flag.IntVar(&config.NumThreads, "threads", runtime.NumCPU(), "Number of concurrent threads")
-	flag.IntVar(&config.BatchSize, "batch_size", 1, "Batch size for operations")
+func printBanner() {
+	fmt.Println(`
+W)      ww I)iiii L)       D)dddd     C)ccc    A)aa   T)tttttt 
+W)      ww   I)   L)       D)   dd   C)   cc  A)  aa     T)    
+W)  ww  ww   I)   L)       D)    dd C)       A)    aa    T)    
+W)  ww  ww   I)   L)       D)    dd C)       A)aaaaaa    T)    
+W)  ww  ww   I)   L)       D)    dd  C)   cc A)    aa    T)    
+ W)ww www  I)iiii L)llllll D)ddddd    C)ccc  A)    aa    T)`)
 
-	// Test types
-	benchmarksStr := flag.String("benchmarks", "fillseq,fillprefixed,readseq,readrandom,iterseq,iterrandom,iterprefix,concurrent_writers,high_contention_writes,batch_concurrent_writes", "Comma-separated list of benchmarks")
-	flag.IntVar(&config.ReadRatio, "read_ratio", 50, "Read ratio for mixed workloads (0-100)")
+	fmt.Printf("Benchmark Tool\n\n")
+}
 
-	// Data distribution
-	flag.StringVar(&config.KeyDistribution, "key_dist", "sequential", "Key distribution: sequential, random, zipfian")
-	flag.Int64Var(&config.ExistingKeys, "existing_keys", 0, "Number of existing keys (0 = use num)")
+// parseRerunFlags loads the configuration recorded in a results file so the
+// exact same workload can be run again. Only the database path and the output
+// file may be changed.
+func parseRerunFlags(args []string) *BenchmarkConfig {
+	fs := flag.NewFlagSet("rerun", flag.ExitOnError)
+	dbPath := fs.String("db", "", "Database directory path (default: the recorded path)")
+	resultsFile := fs.String("results_file", "", "Write the rerun results to this JSON file")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s rerun [flags] results.json\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
 
-	// Reporting
-	flag.DurationVar(&config.ReportInterval, "report_interval", 10*time.Second, "Progress report interval")
-	flag.BoolVar(&config.Histogram, "histogram", true, "Show latency histogram")
-	flag.BoolVar(&config.Stats, "stats", true, "Show database stats after each benchmark")
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
 
-	// Advanced options
-	flag.BoolVar(&config.UseTransactions, "use_txn", false, "Use manual transactions instead of Update/View")
-	flag.BoolVar(&config.IteratorTests, "iterator_tests", false, "Include iterator benchmarks")
-	flag.BoolVar(&config.CompressibleData, "compressible", false, "Use compressible test data")
-	flag.Int64Var(&config.Seed, "seed", time.Now().UnixNano(), "Random seed")
+	rf, err := readResultsFile(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to load results file: %v", err)
+	}
 
-	// Cleanup
-	flag.BoolVar(&config.CleanupAfter, "cleanup", true, "Cleanup database after benchmarks")
+	printVersionMismatch(rf.Version, collectVersionInfo())
 
-	flag.Parse()
+	config := rf.Config
+	config.Seed = rf.Seed
+	config.ResultsFile = *resultsFile
 
-	config.Benchmarks = strings.Split(*benchmarksStr, ",")
+	// The sweep axes and A/B variants are derived from -sweep, -ab_a and
+	// -ab_b and not recorded, so derive them again or the rerun covers only
+	// one point and neither variant
+	sweepAxes, err := parseSweep(config.Sweep)
+	if err != nil {
+		log.Fatalf("Invalid sweep in results file: %v", err)
+	}
+	config.SweepAxes = sweepAxes
 
-	if config.ExistingKeys == 0 {
-		config.ExistingKeys = config.NumOperations
+	abVariants, err := parseABVariants(config.ABVariantA, config.ABVariantB)
+	if err != nil {
+		log.Fatalf("Invalid A/B comparison in results file: %v", err)
+	}
+	config.ABVariants = abVariants
+	if *dbPath != "" {
+		config.DBPath = *dbPath
 	}
+	printHostMismatch(rf.Host, collectHostInfo(config.DBPath))
 
 	return config
 }
@@ -196,57 +529,309 @@ func parseFlags() *BenchmarkConfig {
 func printConfig(config *BenchmarkConfig) {
 	fmt.Printf("Configuration\n")
 	fmt.Printf("=========================\n")
+	host := collectHostInfo(config.DBPath)
+	fmt.Printf("  Host: %s\n", host)
+	fmt.Printf("  Filesystem: %s\n", host.FilesystemString())
+	fmt.Printf("  Engine: %s\n", config.Engine)
 	fmt.Printf("  Database Path: %s\n", config.DBPath)
 	fmt.Printf("  Write Buffer Size: %d MB\n", config.WriteBufferSize/(1024*1024))
 	fmt.Printf("  Sync Option: %s\n", config.SyncOption)
+	if config.SyncInterval > 0 {
+		fmt.Printf("  Sync Interval: %s\n", config.SyncInterval)
+	}
 	fmt.Printf("  Levels: %d\n", config.LevelCount)
 	fmt.Printf("  Bloom Filter: %t\n", config.BloomFilter)
+	if config.ReadOnly {
+		fmt.Printf("  Read Only: %d existing keys\n", config.ExistingKeys)
+	}
+	if config.WarmCache != "none" {
+		fmt.Printf("  Warm Cache: %s\n", config.WarmCache)
+	}
+	if config.Compression != "" {
+		fmt.Printf("  Compression: %s\n", config.Compression)
+	}
 	fmt.Printf("  Operations: %d\n", config.NumOperations)
+	if config.Duration > 0 {
+		fmt.Printf("  Duration: %s\n", config.Duration)
+	}
+	if config.TargetRate > 0 {
+		fmt.Printf("  Target Rate: %.0f ops/sec (latency corrected: %t)\n", config.TargetRate, config.CorrectLatency)
+		if config.OpenLoop {
+			fmt.Printf("  Open Loop: queue depth %d\n", config.QueueDepth)
+		}
+	}
+	if config.Pipeline {
+		fmt.Printf("  Pipeline: %d phases on one database\n", len(config.Benchmarks))
+	}
+	if config.Sweep != "" {
+		fmt.Printf("  Sweep: %s (%d points)\n", config.Sweep, len(sweepPoints(config.SweepAxes)))
+	}
+	for _, v := range config.ABVariants {
+		fmt.Printf("  Variant %s\n", BenchmarkSpec{Name: v.Name, Params: v.Params})
+	}
+	if config.Repeat > 1 {
+		fmt.Printf("  Repeat: %d (fresh database: %t)\n", config.Repeat, config.RepeatFresh)
+	}
+	if config.FreshDB != "never" {
+		fmt.Printf("  Fresh Database: %s\n", config.FreshDB)
+	}
 	fmt.Printf("  Key Size: %d bytes\n", config.KeySize)
 	fmt.Printf("  Value Size: %d bytes\n", config.ValueSize)
 	fmt.Printf("  Threads: %d\n", config.NumThreads)
 	fmt.Printf("  Batch Size: %d\n", config.BatchSize)
-	fmt.Printf("  Benchmarks: %s\n", strings.Join(config.Benchmarks, ", "))
+	fmt.Printf("  Benchmarks: %s\n", formatBenchmarkSpecs(config.Benchmarks))
 	fmt.Printf("  Key Distribution: %s\n", config.KeyDistribution)
+	if config.KeySpace > 0 {
+		fmt.Printf("  Key Space: %d\n", config.KeySpace)
+	}
+	if config.KeyDistribution == "hotspot" {
+		fmt.Printf("  Hotspot: %d%% of ops on %d%% of keys\n", config.HotOpRatio, config.HotKeyRatio)
+	}
+	fmt.Printf("  Seed: %d\n", config.Seed)
+	fmt.Printf("  Fingerprint: %s\n", configFingerprint(config))
 	fmt.Printf("\n")
 }
 
-func runBenchmarks(config *BenchmarkConfig) []*BenchmarkResult {
+// runBenchmarks runs the benchmarks of config and returns their results. On
+// an error it stops and returns the results of the benchmarks that finished.
+func runBenchmarks(config *BenchmarkConfig) ([]*BenchmarkResult, error) {
 	var results []*BenchmarkResult
 
-	for _, benchmark := range config.Benchmarks {
-		benchmark = strings.TrimSpace(benchmark)
-		fmt.Printf("Running benchmark: %s\n", benchmark)
+	if config.FreshDB == "per_suite" && !suiteCheckpoint.resumed() {
+		for _, path := range config.databasePaths() {
+			if err := os.RemoveAll(path); err != nil {
+				return nil, fmt.Errorf("failed to remove database for a fresh suite: %w", err)
+			}
+		}
+	}
+
+	// A pipeline runs every benchmark as a phase against one open database
+	var db Engine
+	var phases []time.Duration
+	if config.Pipeline {
+		var err error
+		db, err = openEngine(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		defer func(db Engine) {
+			_ = db.Close()
+		}(db)
+	}
+	pipelineStart := time.Now()
+
+	// Logical bytes written to each database path, for space amplification
+	logical := make(map[string]int64)
+
+	seq := 0
+
+specs:
+	for _, spec := range config.benchmarkRuns() {
+		benchmark := spec.Name
+
+		benchConfig, err := config.withOverrides(spec.Params)
+		if err != nil {
+			return results, fmt.Errorf("invalid parameters for %s: %w", benchmark, err)
+		}
+
+		// An A/B comparison alternates the variants run by run, so drift over
+		// the session (page cache, thermals, compaction debt) hits both alike
+		variants := []*BenchmarkConfig{benchConfig}
+		if len(config.ABVariants) > 0 {
+			variants = variants[:0]
+			for _, v := range config.ABVariants {
+				variantConfig, err := benchConfig.withVariant(v)
+				if err != nil {
+					return results, fmt.Errorf("invalid variant %s for %s: %w", v.Name, benchmark, err)
+				}
+				variants = append(variants, variantConfig)
+			}
+		}
+
+		for run := 1; run <= benchConfig.Repeat; run++ {
+			for i, runConfig := range variants {
+				if stopRequested.Load() {
+					fmt.Printf("Stop requested, skipping the remaining benchmarks\n\n")
+					break specs
+				}
+
+				name := benchmark
+				var variant string
+				if len(config.ABVariants) > 0 {
+					variant = config.ABVariants[i].Name
+					name += "/" + variant
+				}
+
+				if runConfig.UseExistingDB && slices.Contains(fillBenchmarks, benchmark) {
+					fmt.Printf("Skipping benchmark: %s (using the existing database)\n\n", name)
+					continue
+				}
+
+				if result := suiteCheckpoint.completed(seq); result != nil {
+					fmt.Printf("Skipping benchmark: %s (completed before the checkpoint)\n\n", name)
+					results = append(results, result)
+					seq++
+					continue
+				}
+
+				if runConfig.Repeat > 1 {
+					fmt.Printf("Running benchmark: %s (run %d/%d)\n", name, run, runConfig.Repeat)
+				} else {
+					fmt.Printf("Running benchmark: %s\n", name)
+				}
+
+				// A benchmark being resumed keeps the database it left
+				fresh := runConfig.RepeatFresh || (runConfig.FreshDB == "per_benchmark" && run == 1)
+				if fresh && !suiteCheckpoint.resuming(seq) {
+					loaded, err := freshDatabase(runConfig, benchmark)
+					if err != nil {
+						return results, err
+					}
+					logical[runConfig.DBPath] = loaded
+				}
+
+				if benchmarkBarrier != nil {
+					benchmarkBarrier(seq)
+				}
+				seq++
+
+				var result *BenchmarkResult
+				if db != nil {
+					phases = append(phases, time.Since(pipelineStart))
+					result, err = runBenchmarkOn(db, runConfig, benchmark)
+				} else {
+					result, err = runSingleBenchmark(runConfig, benchmark)
+				}
+				if err != nil {
+					return results, fmt.Errorf("%s: %w", name, err)
+				}
+				if runConfig.Repeat > 1 {
+					result.Run = run
+				}
+				result.Sweep = spec.Sweep
+				result.Variant = variant
+				accountSpace(logical, runConfig.DBPath, result)
+				updateManifest(runConfig, benchmark)
+				results = append(results, result)
+				suiteCheckpoint.complete(result)
+				suiteNotifier.checkResult(result)
+
+				if runConfig.Histogram {
+					printHistogram(name, result.Histogram)
+				}
 
-		result := runSingleBenchmark(config, benchmark)
-		results = append(results, result)
+				if runConfig.Stats {
+					if db != nil {
+						printStats(db)
+					} else {
+						printDatabaseStats(runConfig)
+					}
+				}
 
-		if config.Stats {
-			printDatabaseStats(config)
+				fmt.Printf("Completed %s: %.2f ops/sec\n\n", name, result.OpsPerSecond)
+			}
 		}
+	}
 
-		fmt.Printf("Completed %s: %.2f ops/sec\n\n", benchmark, result.OpsPerSecond)
+	if config.Pipeline {
+		printPipelineSummary(results, phases, time.Since(pipelineStart))
 	}
 
-	return results
+	return results, nil
 }
 
-func runSingleBenchmark(config *BenchmarkConfig, benchmarkName string) *BenchmarkResult {
-	db := openDatabase(config)
-	defer func(db *wildcat.DB) {
+func runSingleBenchmark(config *BenchmarkConfig, benchmarkName string) (*BenchmarkResult, error) {
+	db, err := openEngine(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func(db Engine) {
 		_ = db.Close()
 	}(db)
 
-	tracker := &LatencyTracker{}
+	return runBenchmarkOn(db, config, benchmarkName)
+}
+
+// benchmarkNames are the benchmarks runBenchmarkOn knows how to run.
+var benchmarkNames = []string{
+	"fillseq", "fillrandom", "fillprefixed", "filllarge",
+	"readseq", "readrandom", "readhot", "readmissing", "readwhilewriting", "mixedworkload",
+	"iterseq", "readreverse", "iterrandom", "seekrandom", "iterprefix",
+	"concurrent_writers", "concurrent_transactions", "high_contention_writes",
+	"batch_concurrent_writes", "transaction_conflicts", "concurrent_read_write",
+	"snapshotread", "long_txn", "heavy_contention", "updaterandom", "appendrandom",
+	"scanwhilewriting", "deletewhilewriting", "deleteseq", "deleterandom",
+	"fuzz", "snapshotisolation", "readyourwrites", "boundarykeys", "spacereclaim",
+	"reopen", "compact", "compactwait", "replay",
+}
+
+// runBenchmarkOn runs one benchmark against an already open database. An
+// error that stops the benchmark part way, such as an invalid replay trace,
+// is returned along with the result of what ran.
+func runBenchmarkOn(db Engine, config *BenchmarkConfig, benchmarkName string) (*BenchmarkResult, error) {
+	if !slices.Contains(benchmarkNames, benchmarkName) {
+		return nil, fmt.Errorf("unknown benchmark: %s", benchmarkName)
+	}
+	if err := warmCache(db, config, benchmarkName); err != nil {
+		return nil, err
+	}
+
+	tracker := NewLatencyTracker(config.NumThreads)
+	if config.LatencyDump != "" {
+		tracker.KeepRaw()
+	}
 
 	var opsCompleted int64
 	var bytesRead, bytesWritten int64
 	var errors int64
+	txnStats := &TxnStats{}
+	ryw := &ReadYourWrites{}
+	si := &SnapshotIsolation{}
+	var emptyRefused int64
+	fz := &Fuzz{}
+	cr := &CompactRun{}
+	ro := &Reopen{}
+	errorKinds, stopErrorKinds := trackErrorKinds(&errors)
+	defer stopErrorKinds()
+	checksBefore := valueChecks.snapshot()
 
 	startTime := time.Now()
 
-	stopReporting := make(chan bool)
-	if config.ReportInterval > 0 {
+	live := &LiveRun{
+		Name:         benchmarkName,
+		StartTime:    startTime,
+		Tracker:      tracker,
+		OpsCompleted: &opsCompleted,
+		Errors:       &errors,
+		Percentiles:  config.Percentiles,
+		DB:           db,
+	}
+	currentRun.Store(live)
+	defer currentRun.Store(nil)
+
+	resumed := suiteCheckpoint.begin(benchmarkName, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
+
+	exporters := newExporters(config, benchmarkName)
+	var stopExporters []func()
+	for _, e := range exporters {
+		stopExporters = append(stopExporters, e.Stream(live, config.ExportInterval))
+	}
+
+	timeline := StartTimelineSampler(&opsCompleted)
+	space := StartSpaceSampler(config.DBPath, config.SpaceInterval)
+	var wal *WALSampler
+	var compaction *CompactionSampler
+	if config.Engine == "wildcat" {
+		wal = StartWALSampler(config.DBPath, config.WALInterval)
+		compaction = StartCompactionSampler(config.DBPath, config.CompactionInterval)
+	}
+	gcStats := startGCStats()
+	pageCache := startPageCacheStats()
+	resources := StartResourceSampler()
+	latencySeries := StartLatencySampler(tracker, config.LatencyWindow, config.Percentiles, config.HeatmapFile != "")
+
+	stopReporting := make(chan bool, 1)
+	if config.ReportInterval > 0 && !config.TUI {
 		go func() {
 			ticker := time.NewTicker(config.ReportInterval)
 			defer ticker.Stop()
@@ -265,6 +850,25 @@ func runSingleBenchmark(config *BenchmarkConfig, benchmarkName string) *Benchmar
 		}()
 	}
 
+	if config.Validate && slices.Contains(validatedBenchmarks, benchmarkName) {
+		fillLedger = newFillLedger(config.NumThreads)
+	}
+	if config.CheckConsistency && slices.Contains(consistencyBenchmarks, benchmarkName) {
+		consistencyOracle = newConsistencyOracle()
+	}
+	if config.CheckLostWrites && slices.Contains(lostWriteBenchmarks, benchmarkName) {
+		lostWrites = newWriteTagger(config.NumThreads)
+	}
+	if config.CheckIterators && slices.Contains(iteratorBenchmarks, benchmarkName) {
+		iterChecker = newIteratorChecker(config)
+	}
+	if config.CheckTimestamps {
+		tsChecker = newTimestampChecker()
+	}
+
+	stopProfiles := startProfiles(config, benchmarkName)
+
+	var err error
 	switch benchmarkName {
 	case "fillseq":
 		runFillSequential(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
@@ -272,10 +876,14 @@ func runSingleBenchmark(config *BenchmarkConfig, benchmarkName string) *Benchmar
 		runFillRandom(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
 	case "fillprefixed":
 		runFillPrefixed(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
+	case "filllarge":
+		runFillLarge(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
 	case "readseq":
 		runReadSequential(db, config, tracker, &opsCompleted, &bytesRead, &errors)
 	case "readrandom":
 		runReadRandom(db, config, tracker, &opsCompleted, &bytesRead, &errors)
+	case "readhot":
+		runReadHot(db, config, tracker, &opsCompleted, &bytesRead, &errors)
 	case "readmissing":
 		runReadMissing(db, config, tracker, &opsCompleted, &bytesRead)
 	case "readwhilewriting":
@@ -284,79 +892,220 @@ func runSingleBenchmark(config *BenchmarkConfig, benchmarkName string) *Benchmar
 		runMixedWorkload(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
 	case "iterseq":
 		runIteratorSequential(db, config, tracker, &opsCompleted, &bytesRead, &errors)
+	case "readreverse":
+		runReadReverse(db, config, tracker, &opsCompleted, &bytesRead, &errors)
 	case "iterrandom":
 		runIteratorRandom(db, config, tracker, &opsCompleted, &bytesRead, &errors)
+	case "seekrandom":
+		runSeekRandom(db, config, tracker, &opsCompleted, &bytesRead, &errors)
 	case "iterprefix":
 		runIteratorPrefix(db, config, tracker, &opsCompleted, &bytesRead, &errors)
 	case "concurrent_writers":
 		runConcurrentWriters(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
 	case "concurrent_transactions":
-		runConcurrentTransactions(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
+		runConcurrentTransactions(db, config, tracker, &opsCompleted, &bytesWritten, &errors, txnStats)
 	case "high_contention_writes":
-		runHighContentionWrites(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
+		runHighContentionWrites(db, config, tracker, &opsCompleted, &bytesWritten, &errors, txnStats)
 	case "batch_concurrent_writes":
-		runBatchConcurrentWrites(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
+		runBatchConcurrentWrites(db, config, tracker, &opsCompleted, &bytesWritten, &errors, txnStats)
 	case "transaction_conflicts":
-		runTransactionConflicts(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
+		runTransactionConflicts(db, config, tracker, &opsCompleted, &bytesWritten, &errors, txnStats)
 	case "concurrent_read_write":
 		runConcurrentReadWrite(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
+	case "snapshotread":
+		runSnapshotRead(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
+	case "long_txn":
+		runLongTxn(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
 	case "heavy_contention":
-		runHeavyContention(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
-	default:
-		log.Fatalf("Unknown benchmark: %s", benchmarkName)
+		runHeavyContention(db, config, tracker, &opsCompleted, &bytesWritten, &errors, txnStats)
+	case "updaterandom":
+		runUpdateRandom(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors, txnStats)
+	case "appendrandom":
+		runAppendRandom(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
+	case "scanwhilewriting":
+		runScanWhileWriting(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
+	case "deletewhilewriting":
+		runDeleteWhileWriting(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
+	case "deleteseq":
+		runDeleteSequential(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
+	case "deleterandom":
+		runDeleteRandom(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
+	case "fuzz":
+		runFuzz(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors, fz)
+	case "snapshotisolation":
+		runSnapshotIsolation(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors, txnStats, si)
+	case "readyourwrites":
+		runReadYourWrites(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors, ryw)
+	case "boundarykeys":
+		runBoundaryKeys(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors, &emptyRefused)
+	case "spacereclaim":
+		runSpaceReclaim(db, config, tracker, &opsCompleted, &bytesWritten, &errors)
+	case "reopen":
+		runReopen(config, tracker, &opsCompleted, &bytesWritten, &errors, ro)
+	case "compact":
+		runCompact(wildcatDB(db), config, tracker, &opsCompleted, &bytesWritten, &errors, cr)
+	case "compactwait":
+		runCompactWait(wildcatDB(db), config, tracker, &opsCompleted, &errors, cr)
+	case "replay":
+		err = runReplay(db, config, tracker, &opsCompleted, &bytesRead, &bytesWritten, &errors)
 	}
 
 	stopReporting <- true
 
 	duration := time.Since(startTime)
-	p50, p95, p99, mx := tracker.GetPercentiles()
+	samples := timeline.Stop()
+	usage := resources.Stop(atomic.LoadInt64(&opsCompleted))
+	spaceUsage := space.Stop()
+	walStats := wal.Stop()
+	gc := gcStats(atomic.LoadInt64(&opsCompleted))
+	cacheStats := pageCache()
+	stopProfiles() // After gcStats, so a GC forced for a heap profile is not counted
+	windows := latencySeries.Stop()
+	compactionStats := compaction.Stop(windows)
+	for _, stop := range stopExporters {
+		stop()
+	}
 
-	return &BenchmarkResult{
-		TestName:     benchmarkName,
-		Operations:   atomic.LoadInt64(&opsCompleted),
-		Duration:     duration,
-		OpsPerSecond: float64(atomic.LoadInt64(&opsCompleted)) / duration.Seconds(),
-		LatencyP50:   p50,
-		LatencyP95:   p95,
-		LatencyP99:   p99,
-		LatencyMax:   mx,
-		BytesRead:    atomic.LoadInt64(&bytesRead),
-		BytesWritten: atomic.LoadInt64(&bytesWritten),
-		Errors:       atomic.LoadInt64(&errors),
-	}
-}
-
-func openDatabase(config *BenchmarkConfig) *wildcat.DB {
-	var syncOpt wildcat.SyncOption
-	switch strings.ToLower(config.SyncOption) {
-	case "none":
-		syncOpt = wildcat.SyncNone
-	case "partial":
-		syncOpt = wildcat.SyncPartial
-	case "full":
-		syncOpt = wildcat.SyncFull
-	default:
-		log.Fatalf("Invalid sync option: %s", config.SyncOption)
+	if config.LatencyDump != "" {
+		path, err := dumpLatencies(config.LatencyDump, config.LatencyDumpFmt, benchmarkName, tracker)
+		if err != nil {
+			log.Printf("Failed to dump latencies: %v", err)
+		} else {
+			fmt.Printf("Latencies written to: %s\n", path)
+		}
+	}
+	resumed.finish(&duration, &opsCompleted, &bytesRead, &bytesWritten, &errors)
+
+	p50, p95, p99, mx := tracker.GetPercentiles()
+	mn, mean, stddev := tracker.GetSpread()
+
+	result := &BenchmarkResult{
+		TestName:      benchmarkName,
+		Operations:    atomic.LoadInt64(&opsCompleted),
+		Duration:      duration,
+		OpsPerSecond:  float64(atomic.LoadInt64(&opsCompleted)) / duration.Seconds(),
+		LatencyP50:    p50,
+		LatencyP95:    p95,
+		LatencyP99:    p99,
+		LatencyMax:    mx,
+		LatencyMin:    mn,
+		LatencyMean:   mean,
+		LatencyStdDev: stddev,
+		BytesRead:     atomic.LoadInt64(&bytesRead),
+		BytesWritten:  atomic.LoadInt64(&bytesWritten),
+		Errors:        atomic.LoadInt64(&errors),
+		EmptyRefused:  atomic.LoadInt64(&emptyRefused),
+		Percentiles:   tracker.Percentiles(config.Percentiles),
+		Classes:       tracker.Classes(config.Percentiles),
+		Fairness:      computeFairness(tracker.Threads()),
+		Jitter:        computeThroughputJitter(samples, duration.Seconds()),
+		Resources:     usage,
+		Space:         spaceUsage,
+		WAL:           walStats,
+		Compaction:    compactionStats,
+		GC:            gc,
+		PageCache:     cacheStats,
+		Timeline:      samples,
+		LatencySeries: windows,
+		Histogram:     tracker.Histogram(),
+		Fingerprint:   configFingerprint(config),
+		Latency:       newLatencyCounts(tracker.snapshot()),
+	}
+	if txnStats.Transactions > 0 {
+		result.Txn = txnStats
+	}
+	if ryw.Checked > 0 {
+		result.ReadYourWrites = ryw
+	}
+	if si.Snapshots > 0 {
+		result.SnapshotIsolation = si
+	}
+	if fz.Puts+fz.Deletes+fz.Gets+fz.Scans+fz.Txns > 0 {
+		result.Fuzz = fz
+	}
+	if cr.Elapsed > 0 {
+		result.Compact = cr
+	}
+	if len(ro.Sizes) > 0 {
+		result.Reopen = ro
+	}
+	if config.Verify {
+		checks := valueChecks.snapshot()
+		result.Verify = &VerifyStats{
+			Checked: checks.Checked - checksBefore.Checked,
+			Corrupt: checks.Corrupt - checksBefore.Corrupt,
+		}
+	}
+	if oracle := consistencyOracle; oracle != nil {
+		consistencyOracle = nil
+		result.Consistency = oracle.Result()
+	}
+	if checker := iterChecker; checker != nil {
+		iterChecker = nil
+		result.Iterators = checker.Result()
+	}
+	if checker := tsChecker; checker != nil {
+		tsChecker = nil
+		if tc := checker.Result(); tc.Entries > 0 {
+			result.Timestamps = tc
+		}
+	}
+	if tagger := lostWrites; tagger != nil {
+		lostWrites = nil
+		result.LostWrites = checkLostWrites(db, config, tagger, benchmarkName == "heavy_contention", config.ValueSize)
+	}
+	if ledger := fillLedger; ledger != nil {
+		// Read back outside the measured run
+		fillLedger = nil
+		result.Validation = validateFill(db, config, ledger)
+		printValidation(benchmarkName, result.Validation)
+	}
+	if result.Errors > 0 {
+		result.ErrorKinds = errorKinds
 	}
 
-	opts := &wildcat.Options{
-		Directory:                config.DBPath,
-		WriteBufferSize:          config.WriteBufferSize,
-		SyncOption:               syncOpt,
-		LevelCount:               config.LevelCount,
-		BloomFilter:              config.BloomFilter,
-		MaxCompactionConcurrency: config.MaxCompactionConc,
-		STDOutLogging:            false,
+	for _, e := range exporters {
+		e.WriteResult(result)
 	}
 
-	db, err := wildcat.Open(opts)
+	return result, err
+}
+
+// openDatabase opens config's database with the engine chosen by -engine,
+// exiting if it cannot.
+func openDatabase(config *BenchmarkConfig) Engine {
+	db, err := openEngine(config)
 	if err != nil {
 		log.Fatalf("Failed to open database: %v", err)
 	}
-
 	return db
 }
 
+// openEngine opens config's database with the engine chosen by -engine.
+func openEngine(config *BenchmarkConfig) (Engine, error) {
+	if config.ReadOnly {
+		if _, err := os.Stat(config.DBPath); err != nil {
+			return nil, fmt.Errorf("%w (-readonly needs a database written beforehand)", err)
+		}
+	}
+
+	db, err := engines[config.Engine](config)
+	if err != nil {
+		return nil, err
+	}
+	if config.ReadOnly {
+		db = readOnlyEngine{db}
+	}
+	if config.Verify {
+		return verifyingEngine{db}, nil
+	}
+
+	return db, nil
+}
+
+// keyPrefixes are the prefixes fillprefixed writes and iterprefix scans.
+var keyPrefixes = []string{"user_", "order_", "product_", "session_", "config_"}
+
 func generateKey(i int64, keySize int, distribution string) []byte {
 	var key []byte
 
@@ -376,13 +1125,7 @@ func generateKey(i int64, keySize int, distribution string) []byte {
 	}
 
 	if len(key) < keySize {
-		padding := make([]byte, keySize-len(key))
-		if _, err := rand.Read(padding); err != nil {
-			for i := range padding {
-				padding[i] = byte(i % 256)
-			}
-		}
-		key = append(key, padding...)
+		key = append(key, keyPadding(i, keySize-len(key))...)
 	} else if len(key) > keySize {
 		key = key[:keySize]
 	}
@@ -412,13 +1155,7 @@ func generateKeyWithPrefix(i int64, keySize int, prefix string, distribution str
 	key := append(prefixBytes, suffix...)
 
 	if len(key) < keySize {
-		padding := make([]byte, keySize-len(key))
-		if _, err := rand.Read(padding); err != nil {
-			for i := range padding {
-				padding[i] = byte(i % 256)
-			}
-		}
-		key = append(key, padding...)
+		key = append(key, keyPadding(i, keySize-len(key))...)
 	} else if len(key) > keySize {
 		key = key[:keySize]
 	}
@@ -426,7 +1163,93 @@ func generateKeyWithPrefix(i int64, keySize int, prefix string, distribution str
 	return key
 }
 
-func generateValue(valueSize int, compressible bool) []byte {
+// generateBoundaryKey returns the i-th key of the boundary key set. Keys cycle
+// through all-0x00, all-0xFF, alternating 0x00/0xFF and maximum-length shapes;
+// the index is embedded big-endian in the tail so every key stays unique.
+func generateBoundaryKey(i int64, keySize, maxKeySize int) []byte {
+	size := keySize
+	if size < 8 {
+		size = 8
+	}
+
+	var fill func(j int) byte
+	switch i % 4 {
+	case 0:
+		fill = func(j int) byte { return 0x00 }
+	case 1:
+		fill = func(j int) byte { return 0xFF }
+	case 2:
+		fill = func(j int) byte {
+			if j%2 == 0 {
+				return 0x00
+			}
+			return 0xFF
+		}
+	case 3:
+		if maxKeySize > size {
+			size = maxKeySize
+		}
+		fill = func(j int) byte { return byte(j % 256) }
+	}
+
+	key := make([]byte, size)
+	for j := 0; j < size-8; j++ {
+		key[j] = fill(j)
+	}
+	binary.BigEndian.PutUint64(key[size-8:], uint64(i))
+
+	return key
+}
+
+// generateBoundaryValue returns the value stored under the i-th boundary key.
+// Values cycle through empty, all-0x00, all-0xFF and patterned payloads and are
+// fully deterministic so reads can be checked byte for byte.
+func generateBoundaryValue(i int64, valueSize int) []byte {
+	switch (i / 4) % 4 {
+	case 0:
+		return []byte{}
+	case 1:
+		return make([]byte, valueSize)
+	case 2:
+		return bytes.Repeat([]byte{0xFF}, valueSize)
+	default:
+		value := make([]byte, valueSize)
+		for j := range value {
+			value[j] = byte((int64(j) + i) % 256)
+		}
+		return value
+	}
+}
+
+// keyPadding returns n filler bytes derived from the key index, so a key can be
+// regenerated exactly by later read benchmarks and by reruns.
+func keyPadding(i int64, n int) []byte {
+	padding := make([]byte, n)
+	state := uint64(i)
+	var z uint64
+	for j := range padding {
+		if j%8 == 0 {
+			state += 0x9E3779B97F4A7C15
+			z = state
+			z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+			z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+			z ^= z >> 31
+		}
+		padding[j] = byte(z >> (8 * uint(j%8)))
+	}
+	return padding
+}
+
+// valueRand returns the source of the values thread threadID writes. It is
+// seeded from Seed like the thread's key choices but kept apart from them, so
+// the written bytes repeat with -seed while the keys picked stay as before.
+func valueRand(config *BenchmarkConfig, threadID int) *rand.Rand {
+	return rand.New(rand.NewSource((config.Seed + int64(threadID)) ^ 0x5bd1e995))
+}
+
+// generateValue returns a value of valueSize bytes drawn from rng, or a
+// repeating pattern when compressible.
+func generateValue(rng *rand.Rand, valueSize int, compressible bool) []byte {
 	value := make([]byte, valueSize)
 
 	if compressible {
@@ -435,50 +1258,144 @@ func generateValue(valueSize int, compressible bool) []byte {
 			value[i] = pattern[i%len(pattern)]
 		}
 	} else {
-		if _, err := rand.Read(value); err != nil {
-			for i := range value {
-				value[i] = byte(i % 256)
-			}
-		}
+		rng.Read(value)
 	}
 
 	return value
 }
 
-func runFillSequential(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+// runFillLarge writes NumOperations/1000 values of LargeValueSize bytes, as
+// LevelDB's fill100K does, then reads every one back, reporting both phases'
+// latencies and throughput in bytes per second.
+func runFillLarge(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64) {
+
+	puts := tracker.Class("put")
+	gets := tracker.Class("get")
+
+	numValues := max(config.NumOperations/1000, 1)
+	threads := int(min(int64(config.NumThreads), numValues))
+	valuesPerThread := numValues / int64(threads)
+
+	phase := func(name string, fn func(threadID int, key, value []byte) (int, error)) {
+		var wg sync.WaitGroup
+		var phaseBytes int64
+		phaseStart := time.Now()
+
+		for t := 0; t < threads; t++ {
+			wg.Add(1)
+			go func(threadID int) {
+				defer wg.Done()
+
+				values := valueRand(config, threadID)
+				start := int64(threadID) * valuesPerThread
+				end := start + valuesPerThread
+				if threadID == threads-1 {
+					end = numValues
+				}
+
+				// Generating a multi-megabyte value costs more than writing it,
+				// so each thread reuses one
+				value := generateValue(values, config.LargeValueSize, config.CompressibleData)
+
+				for i := start; i < end; i++ {
+					key := generateKey(i, config.KeySize, config.KeyDistribution)
+
+					n, err := fn(threadID, key, value)
+					if err != nil {
+						countError(errors, 1, err)
+					} else {
+						atomic.AddInt64(&phaseBytes, int64(n))
+					}
+
+					atomic.AddInt64(opsCompleted, 1)
+				}
+			}(t)
+		}
+
+		wg.Wait()
+
+		elapsed := time.Since(phaseStart)
+		fmt.Printf("  %-6s %8d values %12s %12s/sec\n", name, numValues,
+			formatBytes(phaseBytes), formatBytes(int64(float64(phaseBytes)/elapsed.Seconds())))
+	}
+
+	fmt.Printf("Large values (%s each)\n", formatBytes(int64(config.LargeValueSize)))
+
+	phase("write", func(threadID int, key, value []byte) (int, error) {
+		startTime := time.Now()
+
+		err := db.Update(func(txn Txn) error {
+			opTrace.Record("PUT", key, len(value))
+			return txn.Put(key, value)
+		})
+
+		latency := time.Since(startTime)
+		tracker.Record(threadID, latency)
+		puts.Record(threadID, latency)
+
+		if err != nil {
+			return 0, err
+		}
+		atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+		fillLedger.Record(threadID, key, len(value))
+		return len(key) + len(value), nil
+	})
+
+	phase("read", func(threadID int, key, _ []byte) (int, error) {
+		startTime := time.Now()
+
+		var value []byte
+		err := db.View(func(txn Txn) error {
+			var err error
+			opTrace.Record("GET", key, 0)
+			value, err = txn.Get(key)
+			return err
+		})
+
+		latency := time.Since(startTime)
+		tracker.Record(threadID, latency)
+		gets.Record(threadID, latency)
+
+		if err != nil {
+			return 0, err
+		}
+		atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+		return len(key) + len(value), nil
+	})
+}
+
+func runFillSequential(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesWritten, errors *int64) {
 
 	var wg sync.WaitGroup
-	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * opsPerThread
-			end := start + opsPerThread
-			if threadID == config.NumThreads-1 {
-				end = config.NumOperations
-			}
-
-			for i := start; i < end; i++ {
+			values := valueRand(config, threadID)
+			for i := range ops.Thread(threadID) {
 				key := generateKey(i, config.KeySize, config.KeyDistribution)
-				value := generateValue(config.ValueSize, config.CompressibleData)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
-				err := db.Update(func(txn *wildcat.Txn) error {
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("PUT", key, len(value))
 					return txn.Put(key, value)
 				})
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 
 				if err != nil {
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 				} else {
 					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+					fillLedger.Record(threadID, key, len(value))
 				}
 
 				atomic.AddInt64(opsCompleted, 1)
@@ -489,43 +1406,38 @@ func runFillSequential(db *wildcat.DB, config *BenchmarkConfig, tracker *Latency
 	wg.Wait()
 }
 
-func runFillPrefixed(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runFillPrefixed(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesWritten, errors *int64) {
 
-	prefixes := []string{"user_", "order_", "product_", "session_", "config_"}
-
 	var wg sync.WaitGroup
-	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * opsPerThread
-			end := start + opsPerThread
-			if threadID == config.NumThreads-1 {
-				end = config.NumOperations
-			}
-
-			for i := start; i < end; i++ {
-				prefix := prefixes[i%int64(len(prefixes))]
+			values := valueRand(config, threadID)
+			for i := range ops.Thread(threadID) {
+				prefix := keyPrefixes[i%int64(len(keyPrefixes))]
 				key := generateKeyWithPrefix(i, config.KeySize, prefix, config.KeyDistribution)
-				value := generateValue(config.ValueSize, config.CompressibleData)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
-				err := db.Update(func(txn *wildcat.Txn) error {
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("PUT", key, len(value))
 					return txn.Put(key, value)
 				})
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 
 				if err != nil {
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 				} else {
 					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+					fillLedger.Record(threadID, key, len(value))
 				}
 
 				atomic.AddInt64(opsCompleted, 1)
@@ -536,7 +1448,7 @@ func runFillPrefixed(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTr
 	wg.Wait()
 }
 
-func runFillRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runFillRandom(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesWritten, errors *int64) {
 
 	indices := make([]int64, config.NumOperations)
@@ -551,37 +1463,37 @@ func runFillRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTrac
 	}
 
 	var wg sync.WaitGroup
-	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * opsPerThread
-			end := start + opsPerThread
-			if threadID == config.NumThreads-1 {
-				end = config.NumOperations
-			}
-
-			for i := start; i < end; i++ {
-				keyIndex := indices[i]
+			values := valueRand(config, threadID)
+			for i := range ops.Thread(threadID) {
+				keyIndex := i
+				if i < int64(len(indices)) {
+					keyIndex = indices[i]
+				}
 				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
-				value := generateValue(config.ValueSize, config.CompressibleData)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
-				err := db.Update(func(txn *wildcat.Txn) error {
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("PUT", key, len(value))
 					return txn.Put(key, value)
 				})
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 
 				if err != nil {
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 				} else {
 					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+					fillLedger.Record(threadID, key, len(value))
 				}
 
 				atomic.AddInt64(opsCompleted, 1)
@@ -592,41 +1504,54 @@ func runFillRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTrac
 	wg.Wait()
 }
 
-func runReadSequential(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runReadSequential(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesRead, errors *int64) {
 
+	hits := tracker.Class("hit")
+	misses := tracker.Class("miss")
+
 	var wg sync.WaitGroup
-	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * opsPerThread
-			end := start + opsPerThread
-			if threadID == config.NumThreads-1 {
-				end = config.NumOperations
-			}
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
 
-			for i := start; i < end; i++ {
+			for i := range ops.Thread(threadID) {
 				keyIndex := i % config.ExistingKeys
+				miss := config.MissRatio > 0 && rng.Intn(100) < config.MissRatio
+				if miss {
+					keyIndex += config.ExistingKeys
+				}
 				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
 				var value []byte
-				err := db.View(func(txn *wildcat.Txn) error {
+				err := db.View(func(txn Txn) error {
 					var err error
+					opTrace.Record("GET", key, 0)
 					value, err = txn.Get(key)
 					return err
 				})
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
+
+				if miss {
+					misses.Record(threadID, latency)
+					if isNotFound(err) {
+						err = nil
+					}
+				} else if config.MissRatio > 0 {
+					hits.Record(threadID, latency)
+				}
 
 				if err != nil {
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 				} else {
 					atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
 				}
@@ -639,41 +1564,59 @@ func runReadSequential(db *wildcat.DB, config *BenchmarkConfig, tracker *Latency
 	wg.Wait()
 }
 
-func runReadRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runReadRandom(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesRead, errors *int64) {
 
+	hits := tracker.Class("hit")
+	misses := tracker.Class("miss")
+
+	keys := newKeyChooser(config, config.ExistingKeys)
+
 	var wg sync.WaitGroup
-	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * opsPerThread
-			end := start + opsPerThread
-			if threadID == config.NumThreads-1 {
-				end = config.NumOperations
-			}
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
 
-			for i := start; i < end; i++ {
+			for i := range ops.Thread(threadID) {
 				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = keys.Next(rng)
+				}
+				miss := config.MissRatio > 0 && rng.Intn(100) < config.MissRatio
+				if miss {
+					keyIndex += max(config.ExistingKeys, config.KeySpace)
+				}
 				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
 				var value []byte
-				err := db.View(func(txn *wildcat.Txn) error {
+				err := db.View(func(txn Txn) error {
 					var err error
+					opTrace.Record("GET", key, 0)
 					value, err = txn.Get(key)
 					return err
 				})
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
+
+				if miss {
+					misses.Record(threadID, latency)
+					if isNotFound(err) {
+						err = nil
+					}
+				} else if config.MissRatio > 0 {
+					hits.Record(threadID, latency)
+				}
 
 				if err != nil {
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 				} else {
 					atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
 				}
@@ -686,38 +1629,82 @@ func runReadRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTrac
 	wg.Wait()
 }
 
-func runReadMissing(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
-	opsCompleted, bytesRead *int64) {
+// runReadHot reads only the most recently written HotReadRatio percent of
+// the keys. The set is small enough to stay in the memtable and block
+// cache, so compared with readrandom it isolates the cached read path.
+func runReadHot(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, errors *int64) {
+
+	hotKeys := max(int64(float64(config.ExistingKeys)*config.HotReadRatio/100), 1)
+	firstHot := config.ExistingKeys - hotKeys
 
 	var wg sync.WaitGroup
-	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * opsPerThread
-			end := start + opsPerThread
-			if threadID == config.NumThreads-1 {
-				end = config.NumOperations
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+
+			for range ops.Thread(threadID) {
+				key := generateKey(firstHot+rng.Int63n(hotKeys), config.KeySize, config.KeyDistribution)
+
+				startTime := ops.StartTime(threadID)
+
+				var value []byte
+				err := db.View(func(txn Txn) error {
+					var err error
+					opTrace.Record("GET", key, 0)
+					value, err = txn.Get(key)
+					return err
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
 			}
+		}(t)
+	}
 
-			for i := start; i < end; i++ {
+	wg.Wait()
+}
+
+func runReadMissing(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead *int64) {
+
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			for i := range ops.Thread(threadID) {
 				keyIndex := config.ExistingKeys + i
 				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
 				var value []byte
-				err := db.View(func(txn *wildcat.Txn) error {
+				err := db.View(func(txn Txn) error {
 					var err error
+					opTrace.Record("GET", key, 0)
 					value, err = txn.Get(key)
 					return err
 				})
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 
 				if err != nil {
 					// This is expected for missing keys
@@ -733,9 +1720,13 @@ func runReadMissing(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTra
 	wg.Wait()
 }
 
-func runReadWhileWriting(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runReadWhileWriting(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesRead, bytesWritten, errors *int64) {
 
+	ages := NewDataAgeTracker(tracker, config.ExistingKeys, config.AgeBuckets)
+
+	keys := newKeyChooser(config, config.ExistingKeys)
+
 	var wg sync.WaitGroup
 
 	readThreads := config.NumThreads / 2
@@ -743,30 +1734,40 @@ func runReadWhileWriting(db *wildcat.DB, config *BenchmarkConfig, tracker *Laten
 
 	opsPerReadThread := config.NumOperations / int64(readThreads) / 2
 	opsPerWriteThread := config.NumOperations / int64(writeThreads) / 2
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < readThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			for i := int64(0); i < opsPerReadThread; i++ {
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+
+			for i := range ops.Count(threadID, opsPerReadThread) {
 				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = keys.Next(rng)
+				}
 				key := generateKey(keyIndex, config.KeySize, "random")
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
+				floor := consistencyOracle.Floor(key)
 				var value []byte
-				err := db.View(func(txn *wildcat.Txn) error {
+				err := db.View(func(txn Txn) error {
 					var err error
+					opTrace.Record("GET", key, 0)
 					value, err = txn.Get(key)
 					return err
 				})
+				consistencyOracle.CheckRead(key, floor, value, err)
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
+				ages.Record(threadID, keyIndex, startTime, latency)
 
 				if err != nil {
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 				} else {
 					atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
 				}
@@ -781,87 +1782,110 @@ func runReadWhileWriting(db *wildcat.DB, config *BenchmarkConfig, tracker *Laten
 		go func(threadID int) {
 			defer wg.Done()
 
-			for i := int64(0); i < opsPerWriteThread; i++ {
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
+
+			for i := range ops.Count(threadID, opsPerWriteThread) {
 				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = insertKey(keys, keys.Next(rng))
+				}
 				key := generateKey(keyIndex, config.KeySize, "random")
-				value := generateValue(config.ValueSize, config.CompressibleData)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
-				err := db.Update(func(txn *wildcat.Txn) error {
+				version := consistencyOracle.BeginWrite(key, value)
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("PUT", key, len(value))
 					return txn.Put(key, value)
 				})
+				consistencyOracle.EndWrite(key, version, err == nil)
+				ackKey(keys, keyIndex)
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 
 				if err != nil {
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 				} else {
+					ages.MarkWritten(keyIndex)
 					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
 				}
 
 				atomic.AddInt64(opsCompleted, 1)
 			}
-		}(t)
+		}(readThreads + t)
 	}
 
 	wg.Wait()
 }
 
-func runMixedWorkload(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runMixedWorkload(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesRead, bytesWritten, errors *int64) {
 
+	ages := NewDataAgeTracker(tracker, config.ExistingKeys, config.AgeBuckets)
+
+	keys := newKeyChooser(config, config.ExistingKeys)
+
 	var wg sync.WaitGroup
-	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * opsPerThread
-			end := start + opsPerThread
-			if threadID == config.NumThreads-1 {
-				end = config.NumOperations
-			}
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
+
+			for i := range ops.Thread(threadID) {
+				isRead := i%100 < int64(config.ReadRatio)
 
-			for i := start; i < end; i++ {
 				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = keys.Next(rng)
+					if !isRead {
+						keyIndex = insertKey(keys, keyIndex)
+					}
+				}
 				key := generateKey(keyIndex, config.KeySize, "random")
 
-				isRead := (i*100)%100 < int64(config.ReadRatio)
-
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
 				if isRead {
 					var value []byte
-					err := db.View(func(txn *wildcat.Txn) error {
+					err := db.View(func(txn Txn) error {
 						var err error
+						opTrace.Record("GET", key, 0)
 						value, err = txn.Get(key)
 						return err
 					})
 
 					latency := time.Since(startTime)
-					tracker.Record(latency)
+					tracker.Record(threadID, latency)
+					ages.Record(threadID, keyIndex, startTime, latency)
 
 					if err != nil {
-						atomic.AddInt64(errors, 1)
+						countError(errors, 1, err)
 					} else {
 						atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
 					}
 				} else {
-					value := generateValue(config.ValueSize, config.CompressibleData)
-					err := db.Update(func(txn *wildcat.Txn) error {
+					value := generateValue(values, config.ValueSize, config.CompressibleData)
+					err := db.Update(func(txn Txn) error {
+						opTrace.Record("PUT", key, len(value))
 						return txn.Put(key, value)
 					})
+					ackKey(keys, keyIndex)
 
 					latency := time.Since(startTime)
-					tracker.Record(latency)
+					tracker.Record(threadID, latency)
 
 					if err != nil {
-						atomic.AddInt64(errors, 1)
+						countError(errors, 1, err)
 					} else {
+						ages.MarkWritten(keyIndex)
 						atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
 					}
 				}
@@ -874,47 +1898,76 @@ func runMixedWorkload(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyT
 	wg.Wait()
 }
 
-func runIteratorSequential(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runIteratorSequential(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, errors *int64) {
+	runFullScan(db, config, tracker, opsCompleted, bytesRead, errors, true)
+}
+
+func runReadReverse(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesRead, errors *int64) {
+	runFullScan(db, config, tracker, opsCompleted, bytesRead, errors, false)
+}
+
+// runFullScan iterates the whole database, from the smallest key up when
+// ascending and from the largest down otherwise; a descending iterator's
+// Next walks backwards.
+func runFullScan(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, errors *int64, ascending bool) {
 
 	var keysIterated int64
 
-	startTime := time.Now()
+	// One full scan, or repeated scans until the deadline with -duration
+	ops := NewOpSchedule(config, 1, 1)
+	for range ops.Count(0, 1) {
+		var passKeys int64
 
-	err := db.View(func(txn *wildcat.Txn) error {
-		iter, err := txn.NewIterator(true)
-		if err != nil {
-			return err
-		}
+		startTime := ops.StartTime(0)
 
-		for {
-			key, value, _, ok := iter.Next()
-			if !ok {
-				break
+		err := db.View(func(txn Txn) error {
+			iter, err := txn.NewIterator(ascending)
+			if err != nil {
+				return err
 			}
 
-			atomic.AddInt64(&keysIterated, 1)
-			atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+			scan := iterChecker.Scan(ascending, nil, nil,
+				iterChecker.Expect(0, config.ExistingKeys, 1, ascending, 0, func(i int64) []byte {
+					return generateKey(i, config.KeySize, config.KeyDistribution)
+				}))
+
+			exhausted := false
+			for {
+				key, value, ok := iter.Next()
+				if !ok {
+					exhausted = true
+					break
+				}
+
+				scan.Key(key)
+				passKeys++
+				atomic.AddInt64(&keysIterated, 1)
+				atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
 
-			if keysIterated >= config.NumOperations {
-				break
+				if passKeys >= config.NumOperations {
+					break
+				}
 			}
-		}
+			scan.Done(exhausted)
 
-		return nil
-	})
+			return nil
+		})
 
-	latency := time.Since(startTime)
-	tracker.Record(latency)
+		latency := time.Since(startTime)
+		tracker.Record(0, latency)
 
-	if err != nil {
-		atomic.AddInt64(errors, 1)
+		if err != nil {
+			countError(errors, 1, err)
+		}
 	}
 
 	atomic.StoreInt64(opsCompleted, keysIterated)
 }
 
-func runIteratorRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runIteratorRandom(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesRead, errors *int64) {
 	var iterationsCompleted int64
 	iterationsToRun := config.NumOperations / 100
@@ -922,28 +1975,37 @@ func runIteratorRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *Latency
 		iterationsToRun = 10
 	}
 
-	for i := int64(0); i < iterationsToRun; i++ {
-		rangeStart := i * 100
+	ops := NewOpSchedule(config, iterationsToRun, 1)
+	for i := range ops.Count(0, iterationsToRun) {
+		rangeStart := (i % iterationsToRun) * 100
 		rangeEnd := rangeStart + 100
 
 		startKey := generateKey(rangeStart, config.KeySize, config.KeyDistribution)
 		endKey := generateKey(rangeEnd, config.KeySize, config.KeyDistribution)
 
-		startTime := time.Now()
+		startTime := ops.StartTime(0)
 
-		err := db.View(func(txn *wildcat.Txn) error {
+		err := db.View(func(txn Txn) error {
 			iter, err := txn.NewRangeIterator(startKey, endKey, true)
 			if err != nil {
 				return err
 			}
 
+			scan := iterChecker.Scan(true, startKey, endKey,
+				iterChecker.Expect(rangeStart, min(rangeEnd, config.ExistingKeys), 1, true, 0, func(i int64) []byte {
+					return generateKey(i, config.KeySize, config.KeyDistribution)
+				}))
+
+			exhausted := false
 			var keysInRange int64
 			for {
-				key, value, _, ok := iter.Next()
+				key, value, ok := iter.Next()
 				if !ok {
+					exhausted = true
 					break
 				}
 
+				scan.Key(key)
 				keysInRange++
 				atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
 
@@ -951,15 +2013,16 @@ func runIteratorRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *Latency
 					break
 				}
 			}
+			scan.Done(exhausted)
 
 			return nil
 		})
 
 		latency := time.Since(startTime)
-		tracker.Record(latency)
+		tracker.Record(0, latency)
 
 		if err != nil {
-			atomic.AddInt64(errors, 1)
+			countError(errors, 1, err)
 		}
 
 		atomic.AddInt64(&iterationsCompleted, 1)
@@ -968,51 +2031,130 @@ func runIteratorRandom(db *wildcat.DB, config *BenchmarkConfig, tracker *Latency
 	atomic.StoreInt64(opsCompleted, iterationsCompleted)
 }
 
-func runIteratorPrefix(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runSeekRandom(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesRead, errors *int64) {
 
-	prefixes := []string{"user_", "order_", "product_", "session_", "config_"}
+	keys := newKeyChooser(config, config.ExistingKeys)
 
-	var iterationsCompleted int64
-	iterationsToRun := config.NumOperations / 50
-	if iterationsToRun == 0 {
-		iterationsToRun = int64(len(prefixes))
-	}
+	// Sorts after every generated key, so the range is open-ended
+	upperBound := bytes.Repeat([]byte{0xff}, config.KeySize+1)
 
-	for i := int64(0); i < iterationsToRun; i++ {
-		prefix := prefixes[i%int64(len(prefixes))]
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
-		startTime := time.Now()
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
 
-		err := db.View(func(txn *wildcat.Txn) error {
-			iter, err := txn.NewPrefixIterator([]byte(prefix), true)
-			if err != nil {
-				return err
-			}
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
 
-			var keysWithPrefix int64
-			for {
-				key, value, _, ok := iter.Next()
-				if !ok {
-					break
+			for i := range ops.Thread(threadID) {
+				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = keys.Next(rng)
 				}
+				seekKey := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
 
-				keysWithPrefix++
-				atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+				startTime := ops.StartTime(threadID)
 
-				if keysWithPrefix >= 200 {
-					break
-				}
-			}
+				err := db.View(func(txn Txn) error {
+					iter, err := txn.NewRangeIterator(seekKey, upperBound, true)
+					if err != nil {
+						return err
+					}
 
-			return nil
-		})
+					scan := iterChecker.Scan(true, seekKey, upperBound,
+						iterChecker.Expect(keyIndex, config.ExistingKeys, 1, true, 0, func(i int64) []byte {
+							return generateKey(i, config.KeySize, config.KeyDistribution)
+						}))
+
+					// The entry at the seek position, then SeekNexts more
+					exhausted := false
+					for n := 0; n <= config.SeekNexts; n++ {
+						key, value, ok := iter.Next()
+						if !ok {
+							exhausted = true
+							break
+						}
+						scan.Key(key)
+						atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+					}
+					scan.Done(exhausted)
+
+					return nil
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+func runIteratorPrefix(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, errors *int64) {
+
+	var iterationsCompleted int64
+	iterationsToRun := config.NumOperations / 50
+	if iterationsToRun == 0 {
+		iterationsToRun = int64(len(keyPrefixes))
+	}
+
+	ops := NewOpSchedule(config, iterationsToRun, 1)
+	for i := range ops.Count(0, iterationsToRun) {
+		prefixIndex := i % int64(len(keyPrefixes))
+		prefix := keyPrefixes[prefixIndex]
+
+		startTime := ops.StartTime(0)
+
+		err := db.View(func(txn Txn) error {
+			iter, err := txn.NewPrefixIterator([]byte(prefix), true)
+			if err != nil {
+				return err
+			}
+
+			// fillprefixed gives key i the prefix i % len(keyPrefixes)
+			scan := iterChecker.Scan(true, []byte(prefix), prefixEnd([]byte(prefix)),
+				iterChecker.Expect(prefixIndex, config.ExistingKeys, int64(len(keyPrefixes)), true, len(prefix), func(i int64) []byte {
+					return generateKeyWithPrefix(i, config.KeySize, prefix, config.KeyDistribution)
+				}))
+
+			exhausted := false
+			var keysWithPrefix int64
+			for {
+				key, value, ok := iter.Next()
+				if !ok {
+					exhausted = true
+					break
+				}
+
+				scan.Key(key)
+				keysWithPrefix++
+				atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+
+				if keysWithPrefix >= 200 {
+					break
+				}
+			}
+			scan.Done(exhausted)
+
+			return nil
+		})
 
 		latency := time.Since(startTime)
-		tracker.Record(latency)
+		tracker.Record(0, latency)
 
 		if err != nil {
-			atomic.AddInt64(errors, 1)
+			countError(errors, 1, err)
 		}
 
 		atomic.AddInt64(&iterationsCompleted, 1)
@@ -1021,52 +2163,54 @@ func runIteratorPrefix(db *wildcat.DB, config *BenchmarkConfig, tracker *Latency
 	atomic.StoreInt64(opsCompleted, iterationsCompleted)
 }
 
-func runConcurrentWriters(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runConcurrentWriters(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesWritten, errors *int64) {
 
+	phases := newTxnPhases(tracker, "put")
+
 	var wg sync.WaitGroup
-	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * opsPerThread
-			end := start + opsPerThread
-			if threadID == config.NumThreads-1 {
-				end = config.NumOperations
-			}
-
-			for i := start; i < end; i++ {
+			values := valueRand(config, threadID)
+			for i := range ops.Thread(threadID) {
 				key := generateKey(i, config.KeySize, config.KeyDistribution)
-				value := generateValue(config.ValueSize, config.CompressibleData)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
 				// Each thread manages its own transaction
-				txn, err := db.Begin()
+				txn, err := db.Begin(true)
 				if err != nil {
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 					atomic.AddInt64(opsCompleted, 1)
 					continue
 				}
 
+				opTrace.Record("PUT", key, len(value))
+				putStart := time.Now()
 				err = txn.Put(key, value)
+				phases.body.Record(threadID, time.Since(putStart))
 				if err != nil {
 					_ = txn.Rollback()
-					atomic.AddInt64(errors, 1)
+					countError(errors, 1, err)
 				} else {
+					commitStart := time.Now()
 					err = txn.Commit()
+					phases.commit.Record(threadID, time.Since(commitStart))
 					if err != nil {
-						atomic.AddInt64(errors, 1)
+						countError(errors, 1, wrapCommit(err))
 					} else {
 						atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
 					}
 				}
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 				atomic.AddInt64(opsCompleted, 1)
 			}
 		}(t)
@@ -1075,8 +2219,10 @@ func runConcurrentWriters(db *wildcat.DB, config *BenchmarkConfig, tracker *Late
 	wg.Wait()
 }
 
-func runConcurrentTransactions(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
-	opsCompleted, bytesWritten, errors *int64) {
+func runConcurrentTransactions(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64, txnStats *TxnStats) {
+
+	phases := newTxnPhases(tracker, "put")
 
 	var wg sync.WaitGroup
 	batchSize := int64(config.BatchSize)
@@ -1085,59 +2231,42 @@ func runConcurrentTransactions(db *wildcat.DB, config *BenchmarkConfig, tracker
 	}
 
 	numBatches := config.NumOperations / batchSize
-	batchesPerThread := numBatches / int64(config.NumThreads)
+	ops := NewOpSchedule(config, numBatches, config.NumThreads).Batched(batchSize)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * batchesPerThread
-			end := start + batchesPerThread
-			if threadID == config.NumThreads-1 {
-				end = numBatches
-			}
-
-			for batch := start; batch < end; batch++ {
-				startTime := time.Now()
-
-				txn, err := db.Begin()
-				if err != nil {
-					atomic.AddInt64(errors, batchSize)
-					atomic.AddInt64(opsCompleted, batchSize)
-					continue
-				}
+			values := valueRand(config, threadID)
+			for batch := range ops.Thread(threadID) {
+				startTime := ops.StartTime(threadID)
 
 				var batchBytesWritten int64
-				batchErrors := false
-
-				for i := int64(0); i < batchSize; i++ {
-					opIndex := batch*batchSize + i
-					key := generateKey(opIndex, config.KeySize, config.KeyDistribution)
-					value := generateValue(config.ValueSize, config.CompressibleData)
-
-					err = txn.Put(key, value)
-					if err != nil {
-						batchErrors = true
-						break
+				committed, err := runTxn(db, config, txnStats, phases, threadID, batchSize, func(txn Txn) error {
+					batchBytesWritten = 0
+					for i := int64(0); i < batchSize; i++ {
+						opIndex := batch*batchSize + i
+						key := generateKey(opIndex, config.KeySize, config.KeyDistribution)
+						value := generateValue(values, config.ValueSize, config.CompressibleData)
+
+						opTrace.Record("PUT", key, len(value))
+						if err := txn.Put(key, value); err != nil {
+							return err
+						}
+						batchBytesWritten += int64(len(key) + len(value))
 					}
-					batchBytesWritten += int64(len(key) + len(value))
-				}
+					return nil
+				})
 
-				if batchErrors {
-					_ = txn.Rollback()
-					atomic.AddInt64(errors, batchSize)
-				} else {
-					err = txn.Commit()
-					if err != nil {
-						atomic.AddInt64(errors, batchSize)
-					} else {
-						atomic.AddInt64(bytesWritten, batchBytesWritten)
-					}
+				if err != nil {
+					countError(errors, batchSize, err)
+				} else if committed {
+					atomic.AddInt64(bytesWritten, batchBytesWritten)
 				}
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 				atomic.AddInt64(opsCompleted, batchSize)
 			}
 		}(t)
@@ -1146,11 +2275,14 @@ func runConcurrentTransactions(db *wildcat.DB, config *BenchmarkConfig, tracker
 	wg.Wait()
 }
 
-func runHighContentionWrites(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
-	opsCompleted, bytesWritten, errors *int64) {
+func runHighContentionWrites(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64, txnStats *TxnStats) {
+
+	phases := newTxnPhases(tracker, "put")
 
 	var wg sync.WaitGroup
 	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	contentionRange := config.NumOperations / 4 // All threads compete for 25% of key space
 
@@ -1159,35 +2291,29 @@ func runHighContentionWrites(db *wildcat.DB, config *BenchmarkConfig, tracker *L
 		go func(threadID int) {
 			defer wg.Done()
 
-			for i := int64(0); i < opsPerThread; i++ {
+			values := valueRand(config, threadID)
+			for i := range ops.Count(threadID, opsPerThread) {
 				keyIndex := i % contentionRange
 				key := generateKey(keyIndex, config.KeySize, "sequential")
-				value := generateValue(config.ValueSize, config.CompressibleData)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
+				seq := lostWrites.Tag(threadID, value)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
-				txn, err := db.Begin()
-				if err != nil {
-					atomic.AddInt64(errors, 1)
-					atomic.AddInt64(opsCompleted, 1)
-					continue
-				}
+				committed, err := runTxn(db, config, txnStats, phases, threadID, 1, func(txn Txn) error {
+					opTrace.Record("PUT", key, len(value))
+					return txn.Put(key, value)
+				})
 
-				err = txn.Put(key, value)
 				if err != nil {
-					_ = txn.Rollback()
-					atomic.AddInt64(errors, 1)
-				} else {
-					err = txn.Commit()
-					if err != nil {
-						atomic.AddInt64(errors, 1)
-					} else {
-						atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
-					}
+					countError(errors, 1, err)
+				} else if committed {
+					lostWrites.Committed(threadID, seq, keyIndex)
+					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
 				}
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 				atomic.AddInt64(opsCompleted, 1)
 			}
 		}(t)
@@ -1196,8 +2322,10 @@ func runHighContentionWrites(db *wildcat.DB, config *BenchmarkConfig, tracker *L
 	wg.Wait()
 }
 
-func runBatchConcurrentWrites(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
-	opsCompleted, bytesWritten, errors *int64) {
+func runBatchConcurrentWrites(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64, txnStats *TxnStats) {
+
+	phases := newTxnPhases(tracker, "put")
 
 	var wg sync.WaitGroup
 	batchSize := int64(config.BatchSize)
@@ -1206,59 +2334,42 @@ func runBatchConcurrentWrites(db *wildcat.DB, config *BenchmarkConfig, tracker *
 	}
 
 	numBatches := config.NumOperations / batchSize
-	batchesPerThread := numBatches / int64(config.NumThreads)
+	ops := NewOpSchedule(config, numBatches, config.NumThreads).Batched(batchSize)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			start := int64(threadID) * batchesPerThread
-			end := start + batchesPerThread
-			if threadID == config.NumThreads-1 {
-				end = numBatches
-			}
-
-			for batch := start; batch < end; batch++ {
-				startTime := time.Now()
-
-				txn, err := db.Begin()
-				if err != nil {
-					atomic.AddInt64(errors, batchSize)
-					atomic.AddInt64(opsCompleted, batchSize)
-					continue
-				}
+			values := valueRand(config, threadID)
+			for batch := range ops.Thread(threadID) {
+				startTime := ops.StartTime(threadID)
 
 				var batchBytesWritten int64
-				batchErrors := false
-
-				for i := int64(0); i < batchSize; i++ {
-					opIndex := batch*batchSize + i
-					key := generateKey(opIndex, config.KeySize, config.KeyDistribution)
-					value := generateValue(config.ValueSize, config.CompressibleData)
-
-					err = txn.Put(key, value)
-					if err != nil {
-						batchErrors = true
-						break
+				committed, err := runTxn(db, config, txnStats, phases, threadID, batchSize, func(txn Txn) error {
+					batchBytesWritten = 0
+					for i := int64(0); i < batchSize; i++ {
+						opIndex := batch*batchSize + i
+						key := generateKey(opIndex, config.KeySize, config.KeyDistribution)
+						value := generateValue(values, config.ValueSize, config.CompressibleData)
+
+						opTrace.Record("PUT", key, len(value))
+						if err := txn.Put(key, value); err != nil {
+							return err
+						}
+						batchBytesWritten += int64(len(key) + len(value))
 					}
-					batchBytesWritten += int64(len(key) + len(value))
-				}
+					return nil
+				})
 
-				if batchErrors {
-					_ = txn.Rollback()
-					atomic.AddInt64(errors, batchSize)
-				} else {
-					err = txn.Commit()
-					if err != nil {
-						atomic.AddInt64(errors, batchSize)
-					} else {
-						atomic.AddInt64(bytesWritten, batchBytesWritten)
-					}
+				if err != nil {
+					countError(errors, batchSize, err)
+				} else if committed {
+					atomic.AddInt64(bytesWritten, batchBytesWritten)
 				}
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 				atomic.AddInt64(opsCompleted, batchSize)
 			}
 		}(t)
@@ -1267,11 +2378,14 @@ func runBatchConcurrentWrites(db *wildcat.DB, config *BenchmarkConfig, tracker *
 	wg.Wait()
 }
 
-func runTransactionConflicts(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
-	opsCompleted, bytesWritten, errors *int64) {
+func runTransactionConflicts(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64, txnStats *TxnStats) {
+
+	phases := newTxnPhases(tracker, "rmw")
 
 	var wg sync.WaitGroup
 	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	conflictKeySpace := int64(10)
 
@@ -1280,44 +2394,132 @@ func runTransactionConflicts(db *wildcat.DB, config *BenchmarkConfig, tracker *L
 		go func(threadID int) {
 			defer wg.Done()
 
-			for i := int64(0); i < opsPerThread; i++ {
+			values := valueRand(config, threadID)
+			for i := range ops.Count(threadID, opsPerThread) {
 				// All threads compete for the same small set of keys
 				keyIndex := i % conflictKeySpace
 				key := generateKey(keyIndex, config.KeySize, "sequential")
-				value := generateValue(config.ValueSize, config.CompressibleData)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
+
+				committed, err := runTxn(db, config, txnStats, phases, threadID, 2, func(txn Txn) error {
+					opTrace.Record("GET", key, 0)
+					if _, err := txn.Get(key); err != nil && !isNotFound(err) {
+						return err
+					}
+
+					opTrace.Record("PUT", key, len(value))
+					return txn.Put(key, value)
+				})
 
-				txn, err := db.Begin()
 				if err != nil {
-					atomic.AddInt64(errors, 1)
-					atomic.AddInt64(opsCompleted, 1)
-					continue
+					countError(errors, 1, err)
+				} else if committed {
+					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
 				}
 
-				_, err = txn.Get(key)
-				if err != nil && err.Error() != "key not found" {
-					_ = txn.Rollback()
-					atomic.AddInt64(errors, 1)
-					atomic.AddInt64(opsCompleted, 1)
-					continue
-				}
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+// runSnapshotRead measures reads from a long-lived snapshot while writers
+// overwrite the same keys. Each reader holds one read transaction open for
+// the whole run, so every write leaves a version the snapshot may still need;
+// readers alternate snapshot reads with reads in a fresh transaction to show
+// what that retention costs.
+func runSnapshotRead(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64) {
+
+	snapshotReads := tracker.Class("snapshot")
+	freshReads := tracker.Class("fresh")
+	puts := tracker.Class("put")
+
+	readThreads := max(config.NumThreads/2, 1)
+
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
+
+			if threadID >= readThreads {
+				for range ops.Thread(threadID) {
+					key := generateKey(rng.Int63n(config.ExistingKeys), config.KeySize, config.KeyDistribution)
+					value := generateValue(values, config.ValueSize, config.CompressibleData)
+
+					startTime := ops.StartTime(threadID)
+
+					err := db.Update(func(txn Txn) error {
+						opTrace.Record("PUT", key, len(value))
+						return txn.Put(key, value)
+					})
+
+					latency := time.Since(startTime)
+					tracker.Record(threadID, latency)
+					puts.Record(threadID, latency)
 
-				err = txn.Put(key, value)
-				if err != nil {
-					_ = txn.Rollback()
-					atomic.AddInt64(errors, 1)
-				} else {
-					err = txn.Commit()
 					if err != nil {
-						atomic.AddInt64(errors, 1)
+						countError(errors, 1, err)
 					} else {
 						atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
 					}
+
+					atomic.AddInt64(opsCompleted, 1)
+				}
+				return
+			}
+
+			snapshot, err := db.Begin(false)
+			if err != nil {
+				countError(errors, 1, err)
+				return
+			}
+			defer func() {
+				_ = snapshot.Rollback()
+			}()
+
+			for i := range ops.Thread(threadID) {
+				key := generateKey(rng.Int63n(config.ExistingKeys), config.KeySize, config.KeyDistribution)
+
+				startTime := ops.StartTime(threadID)
+
+				var value []byte
+				var err error
+				class := snapshotReads
+				opTrace.Record("GET", key, 0)
+				if i%2 == 0 {
+					value, err = snapshot.Get(key)
+				} else {
+					class = freshReads
+					err = db.View(func(txn Txn) error {
+						var err error
+						value, err = txn.Get(key)
+						return err
+					})
 				}
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
+				class.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+				}
+
 				atomic.AddInt64(opsCompleted, 1)
 			}
 		}(t)
@@ -1326,67 +2528,99 @@ func runTransactionConflicts(db *wildcat.DB, config *BenchmarkConfig, tracker *L
 	wg.Wait()
 }
 
-func runConcurrentReadWrite(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+func runConcurrentReadWrite(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
 	opsCompleted, bytesRead, bytesWritten, errors *int64) {
 
+	ages := NewDataAgeTracker(tracker, config.ExistingKeys, config.AgeBuckets)
+	phases := newTxnPhases(tracker, "put")
+
+	keys := newKeyChooser(config, config.ExistingKeys)
+
 	var wg sync.WaitGroup
 	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	for t := 0; t < config.NumThreads; t++ {
 		wg.Add(1)
 		go func(threadID int) {
 			defer wg.Done()
 
-			for i := int64(0); i < opsPerThread; i++ {
-				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
-				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
 
+			for i := range ops.Count(threadID, opsPerThread) {
 				// 70% reads, 30% writes for realistic workload..
-				isRead := (i*100)%100 < 70
+				isRead := i%100 < 70
 
-				startTime := time.Now()
+				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = keys.Next(rng)
+					if !isRead {
+						keyIndex = insertKey(keys, keyIndex)
+					}
+				}
+				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
+
+				startTime := ops.StartTime(threadID)
 
 				if isRead {
+					floor := consistencyOracle.Floor(key)
 					var value []byte
-					err := db.View(func(txn *wildcat.Txn) error {
+					err := db.View(func(txn Txn) error {
 						var err error
+						opTrace.Record("GET", key, 0)
 						value, err = txn.Get(key)
 						return err
 					})
+					consistencyOracle.CheckRead(key, floor, value, err)
 
 					latency := time.Since(startTime)
-					tracker.Record(latency)
+					tracker.Record(threadID, latency)
+					ages.Record(threadID, keyIndex, startTime, latency)
 
 					if err != nil {
-						atomic.AddInt64(errors, 1)
+						countError(errors, 1, err)
 					} else {
 						atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
 					}
 				} else {
-					value := generateValue(config.ValueSize, config.CompressibleData)
+					value := generateValue(values, config.ValueSize, config.CompressibleData)
 
-					txn, err := db.Begin()
+					version := consistencyOracle.BeginWrite(key, value)
+					txn, err := db.Begin(true)
 					if err != nil {
-						atomic.AddInt64(errors, 1)
+						consistencyOracle.EndWrite(key, version, false)
+						ackKey(keys, keyIndex)
+						countError(errors, 1, err)
 						atomic.AddInt64(opsCompleted, 1)
 						continue
 					}
 
+					opTrace.Record("PUT", key, len(value))
+					putStart := time.Now()
 					err = txn.Put(key, value)
+					phases.body.Record(threadID, time.Since(putStart))
 					if err != nil {
 						_ = txn.Rollback()
-						atomic.AddInt64(errors, 1)
+						consistencyOracle.EndWrite(key, version, false)
+						ackKey(keys, keyIndex)
+						countError(errors, 1, err)
 					} else {
+						commitStart := time.Now()
 						err = txn.Commit()
+						phases.commit.Record(threadID, time.Since(commitStart))
+						consistencyOracle.EndWrite(key, version, err == nil)
+						ackKey(keys, keyIndex)
 						if err != nil {
-							atomic.AddInt64(errors, 1)
+							countError(errors, 1, wrapCommit(err))
 						} else {
+							ages.MarkWritten(keyIndex)
 							atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
 						}
 					}
 
 					latency := time.Since(startTime)
-					tracker.Record(latency)
+					tracker.Record(threadID, latency)
 				}
 
 				atomic.AddInt64(opsCompleted, 1)
@@ -1397,11 +2631,14 @@ func runConcurrentReadWrite(db *wildcat.DB, config *BenchmarkConfig, tracker *La
 	wg.Wait()
 }
 
-func runHeavyContention(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
-	opsCompleted, bytesWritten, errors *int64) {
+func runHeavyContention(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64, txnStats *TxnStats) {
+
+	phases := newTxnPhases(tracker, "rmw")
 
 	var wg sync.WaitGroup
 	opsPerThread := config.NumOperations / int64(config.NumThreads)
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
 	// Only 3 keys for extreme contention
 	contentionKeys := int64(3)
@@ -1411,50 +2648,44 @@ func runHeavyContention(db *wildcat.DB, config *BenchmarkConfig, tracker *Latenc
 		go func(threadID int) {
 			defer wg.Done()
 
-			for i := int64(0); i < opsPerThread; i++ {
+			values := valueRand(config, threadID)
+			for i := range ops.Count(threadID, opsPerThread) {
 				keyIndex := i % contentionKeys
 				key := generateKey(keyIndex, config.KeySize, "sequential")
-				value := generateValue(config.ValueSize, config.CompressibleData)
+				chunk := generateValue(values, config.ValueSize, config.CompressibleData)
+				seq := lostWrites.Tag(threadID, chunk)
 
-				startTime := time.Now()
+				startTime := ops.StartTime(threadID)
 
-				txn, err := db.Begin()
-				if err != nil {
-					atomic.AddInt64(errors, 1)
-					atomic.AddInt64(opsCompleted, 1)
-					continue
-				}
+				var value []byte
+				committed, err := runTxn(db, config, txnStats, phases, threadID, 2, func(txn Txn) error {
+					// Read-modify-write pattern to increase contention
+					opTrace.Record("GET", key, 0)
+					oldValue, err := txn.Get(key)
+					if err != nil && !isNotFound(err) {
+						return err
+					}
 
-				// Read-modify-write pattern to increase contention
-				oldValue, err := txn.Get(key)
-				if err != nil && err.Error() != "key not found" {
-					_ = txn.Rollback()
-					atomic.AddInt64(errors, 1)
-					atomic.AddInt64(opsCompleted, 1)
-					continue
-				}
+					time.Sleep(1 * time.Microsecond)
 
-				time.Sleep(1 * time.Microsecond)
+					value = chunk
+					if oldValue != nil {
+						value = append(oldValue, chunk...)
+					}
 
-				if oldValue != nil {
-					value = append(oldValue, value...)
-				}
+					opTrace.Record("PUT", key, len(value))
+					return txn.Put(key, value)
+				})
 
-				err = txn.Put(key, value)
 				if err != nil {
-					_ = txn.Rollback()
-					atomic.AddInt64(errors, 1)
-				} else {
-					err = txn.Commit()
-					if err != nil {
-						atomic.AddInt64(errors, 1)
-					} else {
-						atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
-					}
+					countError(errors, 1, err)
+				} else if committed {
+					lostWrites.Committed(threadID, seq, keyIndex)
+					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
 				}
 
 				latency := time.Since(startTime)
-				tracker.Record(latency)
+				tracker.Record(threadID, latency)
 				atomic.AddInt64(opsCompleted, 1)
 			}
 		}(t)
@@ -1463,38 +2694,839 @@ func runHeavyContention(db *wildcat.DB, config *BenchmarkConfig, tracker *Latenc
 	wg.Wait()
 }
 
-func printDatabaseStats(config *BenchmarkConfig) {
-	db := openDatabase(config)
-	defer func(db *wildcat.DB) {
-		_ = db.Close()
-	}(db)
+func runUpdateRandom(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64, txnStats *TxnStats) {
 
-	stats := db.Stats()
-	fmt.Printf("Database Stats:\n%s\n", stats)
-}
+	committed := tracker.Class("committed")
+	abandoned := tracker.Class("abandoned")
+	phases := newTxnPhases(tracker, "rmw")
 
-func printResults(results []*BenchmarkResult) {
-	fmt.Printf("\n")
-	fmt.Printf("Benchmark Results\n")
-	fmt.Printf("=================\n")
-	fmt.Printf("%-25s %12s %12s %12s %12s %12s %12s %8s\n",
-		"Test", "Ops", "Ops/sec", "P50", "P95", "P99", "Max", "Errors")
-	fmt.Printf("%-25s %12s %12s %12s %12s %12s %12s %8s\n",
-		"----", "---", "-------", "---", "---", "---", "---", "------")
+	keys := newKeyChooser(config, config.ExistingKeys)
 
-	for _, result := range results {
-		fmt.Printf("%-25s %12d %12.2f %12s %12s %12s %12s %8d\n",
-			result.TestName,
-			result.Operations,
-			result.OpsPerSecond,
-			formatDuration(result.LatencyP50),
-			formatDuration(result.LatencyP95),
-			formatDuration(result.LatencyP99),
-			formatDuration(result.LatencyMax),
-			result.Errors)
-	}
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
 
-	fmt.Printf("\n")
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
+
+			for i := range ops.Thread(threadID) {
+				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = keys.Next(rng)
+				}
+				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
+
+				startTime := ops.StartTime(threadID)
+
+				var value []byte
+				ok, err := runTxn(db, config, txnStats, phases, threadID, 2, func(txn Txn) error {
+					opTrace.Record("GET", key, 0)
+					oldValue, err := txn.Get(key)
+					if err != nil && !isNotFound(err) {
+						return err
+					}
+					atomic.AddInt64(bytesRead, int64(len(key)+len(oldValue)))
+
+					// Bump a counter in the first bytes, keeping the rest of the
+					// value, so every update depends on what it read
+					value = make([]byte, max(len(oldValue), config.ValueSize, 8))
+					if copy(value, oldValue) < len(value) {
+						copy(value[len(oldValue):], generateValue(values, len(value)-len(oldValue), config.CompressibleData))
+					}
+					binary.BigEndian.PutUint64(value, binary.BigEndian.Uint64(value)+1)
+
+					opTrace.Record("PUT", key, len(value))
+					return txn.Put(key, value)
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else if ok {
+					committed.Record(threadID, latency)
+					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+				} else {
+					abandoned.Record(threadID, latency)
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+func runAppendRandom(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64) {
+
+	chunkSize := config.AppendSize
+	if chunkSize == 0 {
+		chunkSize = config.ValueSize
+	}
+
+	keys := newKeyChooser(config, config.ExistingKeys)
+
+	var largest int64
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
+
+			for i := range ops.Thread(threadID) {
+				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = keys.Next(rng)
+				}
+				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
+				chunk := generateValue(values, chunkSize, config.CompressibleData)
+
+				startTime := ops.StartTime(threadID)
+
+				var oldSize, newSize int
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("GET", key, 0)
+					oldValue, err := txn.Get(key)
+					if err != nil && !isNotFound(err) {
+						return err
+					}
+
+					value := make([]byte, 0, len(oldValue)+len(chunk))
+					value = append(append(value, oldValue...), chunk...)
+					oldSize, newSize = len(oldValue), len(value)
+
+					opTrace.Record("PUT", key, len(value))
+					return txn.Put(key, value)
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesRead, int64(len(key)+oldSize))
+					atomic.AddInt64(bytesWritten, int64(len(key)+newSize))
+					for {
+						cur := atomic.LoadInt64(&largest)
+						if int64(newSize) <= cur || atomic.CompareAndSwapInt64(&largest, cur, int64(newSize)) {
+							break
+						}
+					}
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+
+	fmt.Printf("Largest value after appends: %s\n", formatBytes(largest))
+}
+
+// runDeleteWhileWriting churns the key space like a queue: a third of the
+// threads insert new keys past the loaded ones, a third delete the oldest
+// keys, and the rest read the live window in between, alternating point gets
+// with short scans, to show how reads hold up among fresh tombstones.
+// runScanWhileWriting measures how long range scans and inserts slow each
+// other down: half the threads scan ScanLength keys from random positions
+// and half insert new keys, first each group alone as a baseline and then
+// both at once.
+func runScanWhileWriting(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64) {
+
+	scanThreads := max(config.NumThreads/2, 1)
+	writeThreads := max(config.NumThreads-scanThreads, 1)
+
+	numScans := max(config.NumOperations/int64(config.ScanLength), int64(scanThreads))
+	numWrites := max(config.NumOperations/2, int64(writeThreads))
+
+	upperBound := bytes.Repeat([]byte{0xff}, config.KeySize+1)
+	nextKey := config.ExistingKeys
+
+	scan := func(class *LatencyTracker) func(threadID int, rng *rand.Rand) {
+		return func(threadID int, rng *rand.Rand) {
+			start := generateKey(rng.Int63n(config.ExistingKeys), config.KeySize, config.KeyDistribution)
+
+			startTime := time.Now()
+
+			err := db.View(func(txn Txn) error {
+				iter, err := txn.NewRangeIterator(start, upperBound, true)
+				if err != nil {
+					return err
+				}
+
+				for n := 0; n < config.ScanLength; n++ {
+					key, value, ok := iter.Next()
+					if !ok {
+						break
+					}
+					atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+				}
+
+				return nil
+			})
+
+			latency := time.Since(startTime)
+			tracker.Record(threadID, latency)
+			class.Record(threadID, latency)
+
+			if err != nil {
+				countError(errors, 1, err)
+			}
+
+			atomic.AddInt64(opsCompleted, 1)
+		}
+	}
+
+	write := func(class *LatencyTracker) func(threadID int, rng *rand.Rand) {
+		return func(threadID int, rng *rand.Rand) {
+			key := generateKey(atomic.AddInt64(&nextKey, 1)-1, config.KeySize, config.KeyDistribution)
+			value := generateValue(rng, config.ValueSize, config.CompressibleData)
+
+			startTime := time.Now()
+
+			err := db.Update(func(txn Txn) error {
+				opTrace.Record("PUT", key, len(value))
+				return txn.Put(key, value)
+			})
+
+			latency := time.Since(startTime)
+			tracker.Record(threadID, latency)
+			class.Record(threadID, latency)
+
+			if err != nil {
+				countError(errors, 1, err)
+			} else {
+				atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+			}
+
+			atomic.AddInt64(opsCompleted, 1)
+		}
+	}
+
+	// group runs n operations over threads workers numbered from firstThread
+	// and returns once they are done, with the rate the group achieved
+	group := func(threads, firstThread int, n int64, op func(int, *rand.Rand)) func() float64 {
+		var wg sync.WaitGroup
+		start := time.Now()
+		perThread := n / int64(threads)
+
+		for t := 0; t < threads; t++ {
+			wg.Add(1)
+			go func(threadID int) {
+				defer wg.Done()
+
+				rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+				for i := int64(0); i < perThread; i++ {
+					op(threadID, rng)
+				}
+			}(firstThread + t)
+		}
+
+		return func() float64 {
+			wg.Wait()
+			return float64(perThread*int64(threads)) / time.Since(start).Seconds()
+		}
+	}
+
+	scanAlone := group(scanThreads, 0, numScans, scan(tracker.Class("scan_alone")))()
+	writeAlone := group(writeThreads, scanThreads, numWrites, write(tracker.Class("put_alone")))()
+
+	scanWait := group(scanThreads, 0, numScans, scan(tracker.Class("scan")))
+	writeWait := group(writeThreads, scanThreads, numWrites, write(tracker.Class("put")))
+	writeConcurrent := writeWait()
+	scanConcurrent := scanWait()
+
+	fmt.Printf("Scan/write interference (%d-key scans, %d scan and %d write threads)\n",
+		config.ScanLength, scanThreads, writeThreads)
+	fmt.Printf("  %-8s %14s %14s %10s\n", "Group", "Alone ops/s", "Together ops/s", "Change")
+	fmt.Printf("  %-8s %14.2f %14.2f %+9.1f%%\n", "scan", scanAlone, scanConcurrent, (scanConcurrent/scanAlone-1)*100)
+	fmt.Printf("  %-8s %14.2f %14.2f %+9.1f%%\n", "write", writeAlone, writeConcurrent, (writeConcurrent/writeAlone-1)*100)
+}
+
+func runDeleteWhileWriting(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64) {
+
+	puts := tracker.Class("put")
+	deletes := tracker.Class("delete")
+	gets := tracker.Class("get")
+	scans := tracker.Class("scan")
+
+	// Next index to insert and next index to delete; the live window is
+	// everything between them
+	inserted := config.ExistingKeys
+	var deleted int64
+
+	upperBound := bytes.Repeat([]byte{0xff}, config.KeySize+1)
+
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
+
+			for i := range ops.Thread(threadID) {
+				var err error
+				var class *LatencyTracker
+				startTime := ops.StartTime(threadID)
+
+				switch threadID % 3 {
+				case 0:
+					key := generateKey(atomic.AddInt64(&inserted, 1)-1, config.KeySize, config.KeyDistribution)
+					value := generateValue(values, config.ValueSize, config.CompressibleData)
+					class = puts
+
+					err = db.Update(func(txn Txn) error {
+						opTrace.Record("PUT", key, len(value))
+						return txn.Put(key, value)
+					})
+					if err == nil {
+						atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+					}
+
+				case 1:
+					key := generateKey(atomic.AddInt64(&deleted, 1)-1, config.KeySize, config.KeyDistribution)
+					class = deletes
+
+					err = db.Update(func(txn Txn) error {
+						opTrace.Record("DELETE", key, 0)
+						return txn.Delete(key)
+					})
+					if err == nil {
+						atomic.AddInt64(bytesWritten, int64(len(key)))
+					}
+
+				default:
+					low, high := atomic.LoadInt64(&deleted), atomic.LoadInt64(&inserted)
+					keyIndex := low
+					if high > low {
+						keyIndex += rng.Int63n(high - low)
+					}
+					key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
+
+					if i%2 == 0 {
+						class = gets
+
+						var value []byte
+						err = db.View(func(txn Txn) error {
+							var err error
+							opTrace.Record("GET", key, 0)
+							value, err = txn.Get(key)
+							return err
+						})
+
+						// The key may have been deleted since the window was read
+						if isNotFound(err) {
+							err = nil
+						}
+						atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+					} else {
+						class = scans
+
+						err = db.View(func(txn Txn) error {
+							iter, err := txn.NewRangeIterator(key, upperBound, true)
+							if err != nil {
+								return err
+							}
+
+							for n := 0; n < 100; n++ {
+								k, v, ok := iter.Next()
+								if !ok {
+									break
+								}
+								atomic.AddInt64(bytesRead, int64(len(k)+len(v)))
+							}
+
+							return nil
+						})
+					}
+				}
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+				class.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+func runDeleteSequential(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64) {
+
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			for i := range ops.Thread(threadID) {
+				key := generateKey(i%config.ExistingKeys, config.KeySize, config.KeyDistribution)
+
+				startTime := ops.StartTime(threadID)
+
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("DELETE", key, 0)
+					return txn.Delete(key)
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesWritten, int64(len(key)))
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+func runDeleteRandom(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64) {
+
+	keys := newKeyChooser(config, config.ExistingKeys)
+
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+
+			for i := range ops.Thread(threadID) {
+				keyIndex := (i*1103515245 + 12345) % config.ExistingKeys
+				if keys != nil {
+					keyIndex = keys.Next(rng)
+				}
+				key := generateKey(keyIndex, config.KeySize, config.KeyDistribution)
+
+				startTime := ops.StartTime(threadID)
+
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("DELETE", key, 0)
+					return txn.Delete(key)
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesWritten, int64(len(key)))
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+// errValueMismatch is a boundarykeys read back that does not return the
+// bytes written.
+var errValueMismatch = errors.New("value mismatch")
+
+func runBoundaryKeys(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors, refused *int64) {
+
+	var wg sync.WaitGroup
+	opsPerThread := config.NumOperations / int64(config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			start := int64(threadID) * opsPerThread
+			end := start + opsPerThread
+			if threadID == config.NumThreads-1 {
+				end = config.NumOperations
+			}
+			skip := make(map[int64]bool)
+
+			for i := start; i < end; i++ {
+				key := generateBoundaryKey(i, config.KeySize, config.MaxKeySize)
+				value := generateBoundaryValue(i, config.ValueSize)
+
+				startTime := time.Now()
+
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("PUT", key, len(value))
+					return txn.Put(key, value)
+				})
+
+				latency := time.Since(startTime)
+
+				// An empty value the engine refuses did no work: it is counted
+				// apart, left out of the ops and latencies, and not read back
+				if len(value) == 0 && isEmptyValueRefused(err) {
+					skip[i] = true
+					atomic.AddInt64(refused, 1)
+					continue
+				}
+
+				tracker.Record(threadID, latency)
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+
+			// Read back this thread's keys and verify every byte survived
+			for i := start; i < end; i++ {
+				if skip[i] {
+					continue
+				}
+				key := generateBoundaryKey(i, config.KeySize, config.MaxKeySize)
+				expected := generateBoundaryValue(i, config.ValueSize)
+
+				startTime := time.Now()
+
+				var value []byte
+				err := db.View(func(txn Txn) error {
+					var err error
+					opTrace.Record("GET", key, 0)
+					value, err = txn.Get(key)
+					return err
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+
+				if err == nil && !bytes.Equal(value, expected) {
+					err = errValueMismatch
+				}
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesRead, int64(len(key)+len(value)))
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+
+	if *refused > 0 {
+		fmt.Printf("Empty values refused by %s: %d (not counted as operations or errors, not read back)\n", config.Engine, *refused)
+	}
+}
+
+// runSpaceReclaim fills the database, deletes a fraction of the keys and then
+// samples the on-disk size to show how quickly and completely the space held
+// by deleted entries is given back.
+func runSpaceReclaim(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesWritten, errors *int64) {
+
+	puts := tracker.Class("put")
+	deletes := tracker.Class("delete")
+
+	var wg sync.WaitGroup
+	opsPerThread := config.NumOperations / int64(config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			values := valueRand(config, threadID)
+			start := int64(threadID) * opsPerThread
+			end := start + opsPerThread
+			if threadID == config.NumThreads-1 {
+				end = config.NumOperations
+			}
+
+			for i := start; i < end; i++ {
+				key := generateKey(i, config.KeySize, config.KeyDistribution)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
+
+				startTime := time.Now()
+
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("PUT", key, len(value))
+					return txn.Put(key, value)
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+				puts.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+
+	if err := wildcatDB(db).ForceFlush(); err != nil {
+		countError(errors, 1, err)
+	}
+	sizeAfterFill := dirSize(config.DBPath)
+
+	// Delete every key whose index falls in the first DeleteRatio percent of
+	// each hundred, spreading the tombstones across the whole key range
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			start := int64(threadID) * opsPerThread
+			end := start + opsPerThread
+			if threadID == config.NumThreads-1 {
+				end = config.NumOperations
+			}
+
+			for i := start; i < end; i++ {
+				if i%100 >= int64(config.DeleteRatio) {
+					continue
+				}
+
+				key := generateKey(i, config.KeySize, config.KeyDistribution)
+
+				startTime := time.Now()
+
+				err := db.Update(func(txn Txn) error {
+					opTrace.Record("DELETE", key, 0)
+					return txn.Delete(key)
+				})
+
+				latency := time.Since(startTime)
+				tracker.Record(threadID, latency)
+				deletes.Record(threadID, latency)
+
+				if err != nil {
+					countError(errors, 1, err)
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+
+	sizeAfterDelete := dirSize(config.DBPath)
+
+	fmt.Printf("Space reclamation (%d%% of %d keys deleted)\n", config.DeleteRatio, config.NumOperations)
+	fmt.Printf("  %-12s %12s\n", "Elapsed", "On-disk")
+	fmt.Printf("  %-12s %12s\n", "after fill", formatBytes(sizeAfterFill))
+	fmt.Printf("  %-12s %12s\n", "after delete", formatBytes(sizeAfterDelete))
+
+	// Flush the tombstones so background compaction can start merging them
+	// with the data they shadow, then watch the directory shrink
+	if err := wildcatDB(db).ForceFlush(); err != nil {
+		countError(errors, 1, err)
+	}
+
+	reclaimStart := time.Now()
+	minSize := sizeAfterDelete
+	ticker := time.NewTicker(config.ReclaimInterval)
+	for time.Since(reclaimStart) < config.ReclaimWait {
+		<-ticker.C
+		size := dirSize(config.DBPath)
+		if size < minSize {
+			minSize = size
+		}
+		fmt.Printf("  %-12s %12s\n", time.Since(reclaimStart).Round(time.Millisecond), formatBytes(size))
+	}
+	ticker.Stop()
+
+	finalSize := dirSize(config.DBPath)
+	fmt.Printf("  %-12s %12s\n", "final", formatBytes(finalSize))
+
+	if sizeAfterFill > 0 {
+		expected := sizeAfterFill * int64(config.DeleteRatio) / 100
+		reclaimed := sizeAfterFill - finalSize
+		fmt.Printf("  Reclaimed: %s of %s expected (%.1f%%), smallest observed: %s\n",
+			formatBytes(reclaimed), formatBytes(expected),
+			100*float64(reclaimed)/float64(max(expected, 1)), formatBytes(minSize))
+	}
+}
+
+func printDatabaseStats(config *BenchmarkConfig) {
+	db, err := openEngine(config)
+	if err != nil {
+		log.Printf("Failed to open database for stats: %v", err)
+		return
+	}
+	defer func(db Engine) {
+		_ = db.Close()
+	}(db)
+
+	printStats(db)
+}
+
+func printStats(db Engine) {
+	stats := db.Stats()
+	fmt.Printf("Database Stats:\n%s\n", stats)
+}
+
+// Label names the result in reports, numbering repeated runs as name#run.
+func (r *BenchmarkResult) Label() string {
+	if r.Run > 0 {
+		return fmt.Sprintf("%s#%d", r.SweepLabel(), r.Run)
+	}
+	return r.SweepLabel()
+}
+
+// SweepLabel names the result with its sweep point and A/B variant, as
+// name[key=value,...]/A.
+func (r *BenchmarkResult) SweepLabel() string {
+	label := r.TestName
+	if r.Sweep != "" {
+		label += "[" + r.Sweep + "]"
+	}
+	if r.Variant != "" {
+		label += "/" + r.Variant
+	}
+	return label
+}
+
+// labelWidth is the width of a table's label column: the longest of labels,
+// and never narrower than the usual 25.
+func labelWidth(labels []string) int {
+	width := 25
+	for _, label := range labels {
+		width = max(width, len(label))
+	}
+	return width
+}
+
+// classLabel labels a latency class row under its benchmark's row.
+func classLabel(result *BenchmarkResult, class *LatencyClass) string {
+	return "  " + result.Label() + "/" + class.Name
+}
+
+func printResults(results []*BenchmarkResult) {
+	cols := percentileColumns(results)
+
+	var labels []string
+	for _, result := range results {
+		labels = append(labels, result.Label())
+		for _, class := range result.Classes {
+			labels = append(labels, classLabel(result, class))
+		}
+	}
+	w := labelWidth(labels)
+
+	fmt.Printf("\n")
+	fmt.Printf("Benchmark Results\n")
+	fmt.Printf("=================\n")
+
+	header := fmt.Sprintf("%-*s %12s %12s %12s %12s %12s", w, "Test", "Ops", "Ops/sec", "Min", "Mean", "StdDev")
+	rule := fmt.Sprintf("%-*s %12s %12s %12s %12s %12s", w, "----", "---", "-------", "---", "----", "------")
+	for _, p := range cols {
+		label := percentileLabel(p)
+		header += fmt.Sprintf(" %12s", label)
+		rule += fmt.Sprintf(" %12s", strings.Repeat("-", len(label)))
+	}
+	fmt.Printf("%s %12s %8s\n", header, "Max", "Errors")
+	fmt.Printf("%s %12s %8s\n", rule, "---", "------")
+
+	latencies := func(mn, mean, stddev time.Duration, ps []Percentile, mx time.Duration) string {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, " %12s %12s %12s", formatDuration(mn), formatDuration(mean), formatDuration(stddev))
+		for _, cell := range percentileCells(ps, cols, formatDuration) {
+			fmt.Fprintf(&sb, " %12s", cell)
+		}
+		fmt.Fprintf(&sb, " %12s", formatDuration(mx))
+		return sb.String()
+	}
+
+	for _, result := range results {
+		name := result.Label()
+
+		fmt.Printf("%-*s %12d %12.2f%s %8d\n",
+			w, name,
+			result.Operations,
+			result.OpsPerSecond,
+			latencies(result.LatencyMin, result.LatencyMean, result.LatencyStdDev, result.Percentiles, result.LatencyMax),
+			result.Errors)
+
+		for _, class := range result.Classes {
+			fmt.Printf("%-*s %12d %12s%s %8s\n",
+				w, classLabel(result, class),
+				class.Operations,
+				"",
+				latencies(class.LatencyMin, class.LatencyMean, class.LatencyStdDev, class.Percentiles, class.LatencyMax),
+				"")
+		}
+	}
+
+	fmt.Printf("\n")
+
+	printRepeatSummaries(results)
+	printFairness(results)
+	printThroughputJitter(results)
+	printErrorKinds(results)
+	printResourceUsage(results)
+	printSpaceUsage(results)
+	printWALStats(results)
+	printCompactionStats(results)
+	printPageCacheStats(results)
+	printGCStats(results)
+	printTxnStats(results)
+	printVerifyStats(results)
+	printValidations(results)
+	printConsistency(results)
+	printLostWrites(results)
+	printIteratorChecks(results)
+	printTimestampChecks(results)
+	printReadYourWrites(results)
+	printSnapshotIsolation(results)
+	printFuzz(results)
 
 	var totalOps int64
 	var totalDuration time.Duration
@@ -0,0 +1,171 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// manifestFile is the file in a database directory describing the data the
+// benchmarks wrote to it, so a later -use_existing_db run can check it.
+const manifestFile = "wildcat_bench.manifest"
+
+// fillBenchmarks are the benchmarks that build the dataset, skipped with
+// -use_existing_db.
+var fillBenchmarks = []string{"fillseq", "fillrandom", "fillprefixed", "filllarge"}
+
+// deleteBenchmarks are the benchmarks that remove keys a fill wrote.
+var deleteBenchmarks = []string{"deleteseq", "deleterandom", "deletewhilewriting", "spacereclaim"}
+
+// DatasetManifest describes the data in a database directory. Keys is how
+// many keys fillseq and fillrandom wrote, indices 0 to Keys-1, and
+// PrefixedKeys how many fillprefixed wrote.
+type DatasetManifest struct {
+	Engine          string
+	KeySize         int
+	ValueSize       int
+	KeyDistribution string
+	Compressible    bool
+	Keys            int64
+	PrefixedKeys    int64
+	Writes          []string `json:",omitempty"` // Benchmarks other than fills that wrote to the data since
+	UpdatedAt       time.Time
+}
+
+func manifestPath(dbPath string) string {
+	return filepath.Join(dbPath, manifestFile)
+}
+
+func readManifest(dbPath string) (*DatasetManifest, error) {
+	data, err := os.ReadFile(manifestPath(dbPath))
+	if err != nil {
+		return nil, err
+	}
+	var m DatasetManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", manifestPath(dbPath), err)
+	}
+	return &m, nil
+}
+
+// updateManifest records in config's database directory what
+// benchmarkName, which has just run, did to the data. Read-only benchmarks
+// leave it alone.
+func updateManifest(config *BenchmarkConfig, benchmarkName string) {
+	if config.ReadOnly || slices.Contains(readOnlyBenchmarks, benchmarkName) {
+		return
+	}
+
+	m, err := readManifest(config.DBPath)
+	if err != nil {
+		m = &DatasetManifest{}
+	}
+	if slices.Contains(fillBenchmarks, benchmarkName) {
+		m.describe(config)
+	}
+
+	switch benchmarkName {
+	case "fillseq", "fillrandom":
+		m.Keys = max(m.Keys, config.NumOperations)
+	case "fillprefixed":
+		m.PrefixedKeys = max(m.PrefixedKeys, config.NumOperations)
+	case "filllarge":
+		m.Keys = max(m.Keys, config.NumOperations/1000)
+	default:
+		m.Writes = append(m.Writes, benchmarkName)
+	}
+	writeManifest(config.DBPath, m)
+}
+
+// describe takes the shape of the keys and values from config.
+func (m *DatasetManifest) describe(config *BenchmarkConfig) {
+	m.Engine = config.Engine
+	m.KeySize = config.KeySize
+	m.ValueSize = config.ValueSize
+	m.KeyDistribution = config.KeyDistribution
+	m.Compressible = config.CompressibleData
+}
+
+func writeManifest(dbPath string, m *DatasetManifest) {
+	m.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		log.Printf("Failed to encode dataset manifest: %v", err)
+		return
+	}
+	// Stores that keep nothing on disk have no directory to put it in
+	if err := os.WriteFile(manifestPath(dbPath), data, 0644); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to write dataset manifest: %v", err)
+	}
+}
+
+// useExistingDB checks that config's database holds data its benchmarks can
+// read, from the manifest an earlier run left, and takes -existing_keys
+// from it.
+func useExistingDB(config *BenchmarkConfig) error {
+	m, err := readManifest(config.DBPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no dataset manifest in %s: build the database with fill benchmarks and -cleanup=false first", config.DBPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read dataset manifest: %w", err)
+	}
+
+	var mismatches []string
+	if m.Engine != config.Engine {
+		mismatches = append(mismatches, fmt.Sprintf("engine %s (database has %s)", config.Engine, m.Engine))
+	}
+	if m.KeySize != config.KeySize {
+		mismatches = append(mismatches, fmt.Sprintf("key size %d (database has %d)", config.KeySize, m.KeySize))
+	}
+	if m.KeyDistribution != config.KeyDistribution {
+		mismatches = append(mismatches, fmt.Sprintf("key distribution %s (database has %s)", config.KeyDistribution, m.KeyDistribution))
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("database %s is incompatible: %s", config.DBPath, strings.Join(mismatches, ", "))
+	}
+
+	keys := m.Keys
+	if keys == 0 {
+		keys = m.PrefixedKeys
+	}
+	if keys == 0 {
+		return fmt.Errorf("database %s holds no filled keys", config.DBPath)
+	}
+	config.ExistingKeys = keys
+
+	fmt.Printf("Using existing database %s: %d keys", config.DBPath, m.Keys)
+	if m.PrefixedKeys > 0 {
+		fmt.Printf(", %d prefixed keys", m.PrefixedKeys)
+	}
+	fmt.Printf(", %d-byte values, written %s\n", m.ValueSize, m.UpdatedAt.Format(time.RFC3339))
+	if m.ValueSize != config.ValueSize {
+		fmt.Printf("  Note: values are %d bytes, not the -value_size of %d\n", m.ValueSize, config.ValueSize)
+	}
+	for _, name := range m.Writes {
+		if slices.Contains(deleteBenchmarks, name) {
+			fmt.Printf("  Note: %s ran after the fills, so some reads may miss\n", name)
+		}
+	}
+	fmt.Printf("\n")
+	return nil
+}
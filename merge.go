@@ -0,0 +1,233 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// runMerge runs the merge subcommand: it combines results files written by
+// worker processes that ran side by side into one aggregate report, without
+// running anything.
+func runMerge(args []string) {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	resultsFile := fs.String("results_file", "", "Write the merged results to this JSON file")
+	force := fs.Bool("force", false, "Merge results even if the workloads differ")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s merge [flags] result1.json result2.json ...\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	files := make([]*ResultsFile, fs.NArg())
+	sets := make([][]*BenchmarkResult, fs.NArg())
+	for i := range files {
+		rf, err := readResultsFile(fs.Arg(i))
+		if err != nil {
+			log.Fatalf("Failed to load results file: %v", err)
+		}
+		files[i] = rf
+		sets[i] = rf.Results
+	}
+	if !*force {
+		checkMergeable(fs.Args(), files)
+	}
+
+	fmt.Printf("\n")
+	printMergeInputs(fs.Args(), sets)
+
+	results := mergeResults(fs.Args(), sets)
+	fmt.Printf("Aggregate of %d results files\n\n", len(files))
+	printResults(results)
+
+	if *resultsFile != "" {
+		// The merged file keeps the first file's configuration and host,
+		// starting when the earliest run did
+		merged := *files[0]
+		for _, rf := range files[1:] {
+			if rf.StartedAt.Before(merged.StartedAt) {
+				merged.StartedAt = rf.StartedAt
+			}
+		}
+		merged.Results = results
+
+		data, err := json.MarshalIndent(&merged, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*resultsFile, append(data, '\n'), 0644)
+		}
+		if err != nil {
+			log.Fatalf("Failed to write results file: %v", err)
+		}
+		fmt.Printf("Results written to: %s\n", *resultsFile)
+	}
+}
+
+// checkMergeable exits unless every file ran the same workload. The seed is
+// not compared, since workers normally run with seeds of their own.
+func checkMergeable(names []string, files []*ResultsFile) {
+	first := files[0].Config
+	if first == nil {
+		return
+	}
+
+	var mismatched []string
+	for i, rf := range files[1:] {
+		if rf.Config == nil || workloadFingerprint(rf.Config) == workloadFingerprint(first) {
+			continue
+		}
+		msg := names[i+1]
+		diff := slices.DeleteFunc(configDiff(first, rf.Config), func(name string) bool { return name == "Seed" })
+		if len(diff) > 0 {
+			msg += ": " + strings.Join(diff, ", ")
+		}
+		mismatched = append(mismatched, msg)
+	}
+	if len(mismatched) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Results were produced by different workloads than %s:\n", names[0])
+	for _, m := range mismatched {
+		fmt.Fprintf(os.Stderr, "  %s\n", m)
+	}
+	log.Fatalf("Refusing to merge; pass -force to merge anyway")
+}
+
+// mergeResults combines results from processes that ran side by side, one
+// set per process, into one result per benchmark found in every set, in the
+// order of the first. Counts add up; the aggregate rate is the total
+// operations over the slowest process's duration, the per-second timelines
+// are summed, and the latency figures come from the merged histograms.
+// Per-thread and per-class figures are left to each process's own report.
+func mergeResults(names []string, sets [][]*BenchmarkResult) []*BenchmarkResult {
+	byLabel := make([]map[string]*BenchmarkResult, len(sets))
+	for i, set := range sets {
+		byLabel[i] = make(map[string]*BenchmarkResult)
+		for _, r := range set {
+			byLabel[i][r.Label()] = r
+		}
+	}
+
+	var merged []*BenchmarkResult
+	for _, first := range sets[0] {
+		label := first.Label()
+
+		parts := make([]*BenchmarkResult, 0, len(sets))
+		for i := range sets {
+			if r := byLabel[i][label]; r != nil {
+				parts = append(parts, r)
+			} else {
+				log.Printf("Skipping %s: not found in %s", label, names[i])
+				break
+			}
+		}
+		if len(parts) < len(sets) {
+			continue
+		}
+
+		merged = append(merged, mergeResult(label, names, parts))
+	}
+	return merged
+}
+
+// mergeResult merges one benchmark's results, one from each process.
+func mergeResult(label string, names []string, parts []*BenchmarkResult) *BenchmarkResult {
+	first := parts[0]
+	result := &BenchmarkResult{
+		TestName:    first.TestName,
+		Run:         first.Run,
+		Sweep:       first.Sweep,
+		Variant:     first.Variant,
+		Fingerprint: first.Fingerprint,
+	}
+
+	latency := newHdrHistogram()
+	complete := true
+	for i, r := range parts {
+		result.Operations += r.Operations
+		result.BytesRead += r.BytesRead
+		result.BytesWritten += r.BytesWritten
+		result.Errors += r.Errors
+		result.Duration = max(result.Duration, r.Duration)
+
+		for s, ops := range r.Timeline {
+			if s == len(result.Timeline) {
+				result.Timeline = append(result.Timeline, 0)
+			}
+			result.Timeline[s] += ops
+		}
+
+		if r.Latency == nil {
+			if complete {
+				log.Printf("%s in %s has no latency histogram; its latencies cannot be merged", label, names[i])
+			}
+			complete = false
+			continue
+		}
+		r.Latency.mergeInto(latency)
+	}
+
+	if result.Duration > 0 {
+		result.OpsPerSecond = float64(result.Operations) / result.Duration.Seconds()
+	}
+	if !complete || latency.total == 0 {
+		return result
+	}
+
+	result.LatencyP50 = time.Duration(latency.percentile(0.50))
+	result.LatencyP95 = time.Duration(latency.percentile(0.95))
+	result.LatencyP99 = time.Duration(latency.percentile(0.99))
+	result.LatencyMax = time.Duration(latency.max)
+	result.LatencyMin = time.Duration(latency.min)
+	result.LatencyMean = time.Duration(latency.mean())
+	result.LatencyStdDev = time.Duration(latency.stddev())
+
+	ps := make([]float64, len(first.Percentiles))
+	for i, p := range first.Percentiles {
+		ps[i] = p.Percentile
+	}
+	result.Percentiles = latency.percentiles(ps)
+	result.Histogram = latency.histogram()
+	result.Latency = newLatencyCounts(latency)
+
+	return result
+}
+
+// printMergeInputs lists each input's results ahead of the aggregate.
+func printMergeInputs(names []string, sets [][]*BenchmarkResult) {
+	fmt.Printf("Results by Source\n")
+	fmt.Printf("=========================\n")
+	fmt.Printf("%-30s %-25s %12s %12s %12s %10s\n",
+		"Source", "Test", "Ops/sec", "P50", "P99", "Errors")
+
+	for i, set := range sets {
+		for _, r := range set {
+			fmt.Printf("%-30s %-25s %12.0f %12s %12s %10d\n",
+				names[i], r.Label(), r.OpsPerSecond, formatDuration(r.LatencyP50), formatDuration(r.LatencyP99), r.Errors)
+		}
+	}
+	fmt.Printf("\n")
+}
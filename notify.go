@@ -0,0 +1,197 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+var notifyFormats = []string{"json", "slack"}
+
+// webhookClient posts notifications and alerts. The timeout keeps a hung
+// endpoint from holding up the benchmarks.
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// Notification is the JSON body posted to -notify_url.
+type Notification struct {
+	Event     string // finished, stopped or threshold
+	Text      string `json:"text"` // Human-readable summary
+	Host      string
+	StartedAt time.Time
+	Elapsed   time.Duration
+	Results   []NotificationResult `json:",omitempty"`
+	Breaches  []string             `json:",omitempty"` // Thresholds breached, for a threshold event
+}
+
+// NotificationResult summarizes one benchmark for a notification.
+type NotificationResult struct {
+	Test         string
+	Operations   int64
+	OpsPerSecond float64
+	LatencyP50   time.Duration
+	LatencyP99   time.Duration
+	Errors       int64
+}
+
+// suiteNotifier posts to -notify_url for the running suite.
+var suiteNotifier *notifier
+
+type notifier struct {
+	config    *BenchmarkConfig
+	baseline  resultGroups
+	startedAt time.Time
+	host      string
+}
+
+func newNotifier(config *BenchmarkConfig, baseline *ResultsFile, startedAt time.Time) *notifier {
+	n := &notifier{config: config, startedAt: startedAt}
+	if baseline != nil {
+		n.baseline = groupResults(baseline.Results)
+	}
+	n.host, _ = os.Hostname()
+	return n
+}
+
+// checkResult notifies at once when a finished benchmark breaches the error
+// rate, or falls behind -baseline by more than the regression threshold.
+func (n *notifier) checkResult(result *BenchmarkResult) {
+	if n == nil {
+		return
+	}
+
+	var breaches []string
+	if rate := n.config.NotifyErrorRate; rate > 0 && result.Operations > 0 {
+		if pct := float64(result.Errors) * 100 / float64(result.Operations); pct > rate {
+			breaches = append(breaches, fmt.Sprintf("%s: %.2f%% of operations failed (threshold %g%%)", result.Label(), pct, rate))
+		}
+	}
+	if drop := n.config.NotifyRegression; drop > 0 {
+		if runs := n.baseline.runs[result.SweepLabel()]; len(runs) > 0 {
+			before := meanOf(runs, opsPerSecond)
+			if result.OpsPerSecond < before*(1-drop/100) {
+				breaches = append(breaches, fmt.Sprintf("%s: %.2f ops/sec, %s against the baseline's %.2f (threshold -%g%%)",
+					result.Label(), result.OpsPerSecond, percentChange(before, result.OpsPerSecond), before, drop))
+			}
+		}
+	}
+	if len(breaches) == 0 {
+		return
+	}
+
+	n.send(&Notification{
+		Event:     "threshold",
+		Text:      fmt.Sprintf("Benchmark threshold breached on %s:\n%s", n.host, strings.Join(breaches, "\n")),
+		Host:      n.host,
+		StartedAt: n.startedAt,
+		Elapsed:   time.Since(n.startedAt),
+		Results:   notificationResults([]*BenchmarkResult{result}),
+		Breaches:  breaches,
+	})
+}
+
+// finished notifies that the suite is done, with a summary of every result.
+func (n *notifier) finished(results []*BenchmarkResult) {
+	if n == nil {
+		return
+	}
+
+	event, verb := "finished", "finished"
+	if stopRequested.Load() {
+		event, verb = "stopped", "was stopped"
+	}
+	elapsed := time.Since(n.startedAt)
+
+	benchmarks := "benchmarks"
+	if len(results) == 1 {
+		benchmarks = "benchmark"
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Benchmark suite on %s %s after %s (%d %s)", n.host, verb, formatDuration(elapsed), len(results), benchmarks)
+	if len(results) > 0 {
+		text.WriteString("\n```\n")
+		fmt.Fprintf(&text, "%-25s %14s %10s %10s %10s\n", "Test", "Ops/sec", "P50", "P99", "Errors")
+		for _, r := range results {
+			fmt.Fprintf(&text, "%-25s %14.2f %10s %10s %10d\n",
+				r.Label(), r.OpsPerSecond, formatDuration(r.LatencyP50), formatDuration(r.LatencyP99), r.Errors)
+		}
+		text.WriteString("```")
+	}
+
+	n.send(&Notification{
+		Event:     event,
+		Text:      text.String(),
+		Host:      n.host,
+		StartedAt: n.startedAt,
+		Elapsed:   elapsed,
+		Results:   notificationResults(results),
+	})
+}
+
+func notificationResults(results []*BenchmarkResult) []NotificationResult {
+	out := make([]NotificationResult, len(results))
+	for i, r := range results {
+		out[i] = NotificationResult{
+			Test:         r.Label(),
+			Operations:   r.Operations,
+			OpsPerSecond: r.OpsPerSecond,
+			LatencyP50:   r.LatencyP50,
+			LatencyP99:   r.LatencyP99,
+			Errors:       r.Errors,
+		}
+	}
+	return out
+}
+
+// send posts the notification in -notify_format: the whole document for
+// json, or just its text for slack.
+func (n *notifier) send(notification *Notification) {
+	var body any = notification
+	if n.config.NotifyFormat == "slack" {
+		body = map[string]string{"text": notification.Text}
+	}
+
+	if err := postWebhook(n.config.NotifyURL, body); err != nil {
+		log.Printf("Failed to send notification: %v", err)
+	} else {
+		fmt.Printf("Notification sent: %s\n", notification.Event)
+	}
+}
+
+// postWebhook posts body as JSON to url with webhookClient, failing on a
+// status other than 2xx.
+func postWebhook(url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := webhookClient.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
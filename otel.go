@@ -0,0 +1,291 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// OTLPExporter pushes metrics to an OpenTelemetry collector using OTLP over
+// HTTP with the JSON encoding, so no OpenTelemetry SDK is required.
+type OTLPExporter struct {
+	url       string
+	headers   map[string]string
+	resource  []otlpAttribute
+	attrs     []otlpAttribute
+	client    *http.Client
+	startedAt time.Time
+	failed    atomic.Bool
+}
+
+// OTLP/JSON message shapes, following the protobuf JSON mapping: 64-bit
+// integers are encoded as strings.
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpNumberPoint struct {
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	AsInt             *string         `json:"asInt,omitempty"`
+	AsDouble          *float64        `json:"asDouble,omitempty"`
+}
+
+type otlpQuantile struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+type otlpSummaryPoint struct {
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	Count             string          `json:"count"`
+	Sum               float64         `json:"sum"`
+	QuantileValues    []otlpQuantile  `json:"quantileValues"`
+}
+
+type otlpMetric struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Unit        string `json:"unit,omitempty"`
+	Gauge       *struct {
+		DataPoints []otlpNumberPoint `json:"dataPoints"`
+	} `json:"gauge,omitempty"`
+	Sum *struct {
+		DataPoints             []otlpNumberPoint `json:"dataPoints"`
+		AggregationTemporality int               `json:"aggregationTemporality"`
+		IsMonotonic            bool              `json:"isMonotonic"`
+	} `json:"sum,omitempty"`
+	Summary *struct {
+		DataPoints []otlpSummaryPoint `json:"dataPoints"`
+	} `json:"summary,omitempty"`
+}
+
+func otlpString(s string) otlpValue {
+	return otlpValue{StringValue: &s}
+}
+
+func otlpInt(n int64) *string {
+	s := strconv.FormatInt(n, 10)
+	return &s
+}
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// newOTLPExporter returns nil when no endpoint is configured.
+func newOTLPExporter(config *BenchmarkConfig, benchmarkName string) *OTLPExporter {
+	if config.OTLPEndpoint == "" {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	for _, kv := range strings.Split(config.OTLPHeaders, ",") {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+
+	host, _ := os.Hostname()
+	seed := strconv.FormatInt(config.Seed, 10)
+
+	return &OTLPExporter{
+		url:     strings.TrimSuffix(config.OTLPEndpoint, "/") + "/v1/metrics",
+		headers: headers,
+		resource: []otlpAttribute{
+			{Key: "service.name", Value: otlpString("wildcat-bench")},
+			{Key: "host.name", Value: otlpString(host)},
+		},
+		attrs: []otlpAttribute{
+			{Key: "benchmark", Value: otlpString(benchmarkName)},
+			{Key: "seed", Value: otlpString(seed)},
+			{Key: "threads", Value: otlpValue{IntValue: otlpInt(int64(config.NumThreads))}},
+			{Key: "sync", Value: otlpString(config.SyncOption)},
+			{Key: "fingerprint", Value: otlpString(configFingerprint(config))},
+		},
+		client:    &http.Client{Timeout: 5 * time.Second},
+		startedAt: time.Now(),
+	}
+}
+
+func (e *OTLPExporter) counter(name, unit string, value int64, now time.Time) otlpMetric {
+	m := otlpMetric{Name: name, Unit: unit}
+	m.Sum = &struct {
+		DataPoints             []otlpNumberPoint `json:"dataPoints"`
+		AggregationTemporality int               `json:"aggregationTemporality"`
+		IsMonotonic            bool              `json:"isMonotonic"`
+	}{
+		DataPoints: []otlpNumberPoint{{
+			Attributes:        e.attrs,
+			StartTimeUnixNano: otlpTime(e.startedAt),
+			TimeUnixNano:      otlpTime(now),
+			AsInt:             otlpInt(value),
+		}},
+		AggregationTemporality: 2, // cumulative
+		IsMonotonic:            true,
+	}
+	return m
+}
+
+func (e *OTLPExporter) gauge(name, unit string, value float64, now time.Time) otlpMetric {
+	m := otlpMetric{Name: name, Unit: unit}
+	m.Gauge = &struct {
+		DataPoints []otlpNumberPoint `json:"dataPoints"`
+	}{
+		DataPoints: []otlpNumberPoint{{
+			Attributes:   e.attrs,
+			TimeUnixNano: otlpTime(now),
+			AsDouble:     &value,
+		}},
+	}
+	return m
+}
+
+func (e *OTLPExporter) export(metrics []otlpMetric) {
+	body := map[string]any{
+		"resourceMetrics": []any{map[string]any{
+			"resource": map[string]any{"attributes": e.resource},
+			"scopeMetrics": []any{map[string]any{
+				"scope":   map[string]any{"name": "wildcat-bench"},
+				"metrics": metrics,
+			}},
+		}},
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		e.reportFailure(err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(data))
+	if err != nil {
+		e.reportFailure(err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		e.reportFailure(err)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		e.reportFailure(fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg))))
+	}
+}
+
+func (e *OTLPExporter) reportFailure(err error) {
+	if e.failed.CompareAndSwap(false, true) {
+		log.Printf("OTLP export failed (further failures are not logged): %v", err)
+	}
+}
+
+// Stream pushes the op and error counters and the ops/sec gauge every
+// interval until the returned function is called.
+func (e *OTLPExporter) Stream(run *LiveRun, interval time.Duration) (stop func()) {
+	done := make(chan bool)
+	finished := make(chan bool)
+
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var lastOps int64
+		lastAt := run.StartTime
+		for {
+			select {
+			case now := <-ticker.C:
+				ops := atomic.LoadInt64(run.OpsCompleted)
+				rate := float64(ops-lastOps) / now.Sub(lastAt).Seconds()
+				lastOps, lastAt = ops, now
+
+				e.export([]otlpMetric{
+					e.counter("wildcat_bench.operations", "{operation}", ops, now),
+					e.counter("wildcat_bench.errors", "{error}", atomic.LoadInt64(run.Errors), now),
+					e.gauge("wildcat_bench.ops_per_sec", "{operation}/s", rate, now),
+				})
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// WriteResult pushes the final counters, throughput and a latency summary
+// with a quantile per -percentiles value plus Max as quantile 1.
+func (e *OTLPExporter) WriteResult(r *BenchmarkResult) {
+	now := time.Now()
+
+	var quantiles []otlpQuantile
+	for _, p := range r.Percentiles {
+		quantiles = append(quantiles, otlpQuantile{Quantile: p.Percentile / 100, Value: float64(p.Latency.Nanoseconds())})
+	}
+	quantiles = append(quantiles, otlpQuantile{Quantile: 1, Value: float64(r.LatencyMax.Nanoseconds())})
+
+	latency := otlpMetric{Name: "wildcat_bench.latency", Unit: "ns"}
+	latency.Summary = &struct {
+		DataPoints []otlpSummaryPoint `json:"dataPoints"`
+	}{
+		DataPoints: []otlpSummaryPoint{{
+			Attributes:        e.attrs,
+			StartTimeUnixNano: otlpTime(e.startedAt),
+			TimeUnixNano:      otlpTime(now),
+			Count:             strconv.FormatInt(r.Operations, 10),
+			QuantileValues:    quantiles,
+		}},
+	}
+
+	e.export([]otlpMetric{
+		e.counter("wildcat_bench.operations", "{operation}", r.Operations, now),
+		e.counter("wildcat_bench.errors", "{error}", r.Errors, now),
+		e.counter("wildcat_bench.bytes_read", "By", r.BytesRead, now),
+		e.counter("wildcat_bench.bytes_written", "By", r.BytesWritten, now),
+		e.gauge("wildcat_bench.ops_per_sec", "{operation}/s", r.OpsPerSecond, now),
+		latency,
+	})
+}
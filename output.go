@@ -0,0 +1,205 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// outputFormats lists the machine-readable formats accepted by -output.
+var outputFormats = []string{"text", "json", "csv", "markdown"}
+
+// structuredOutputToStdout reports whether -output will write a machine-readable
+// document to stdout, in which case human-readable output has to move to stderr.
+func structuredOutputToStdout(config *BenchmarkConfig) bool {
+	return config.OutputFormat != "text" && config.OutputFile == ""
+}
+
+// writeOutput emits results in the configured -output format to w.
+func writeOutput(w io.Writer, config *BenchmarkConfig, startedAt time.Time, results []*BenchmarkResult) error {
+	switch config.OutputFormat {
+	case "text":
+		return nil
+	case "json":
+		return encodeResultsJSON(w, config, startedAt, results)
+	case "csv":
+		return writeResultsCSV(w, results)
+	case "markdown":
+		return writeResultsMarkdown(w, config, results)
+	default:
+		return fmt.Errorf("unknown output format: %s", config.OutputFormat)
+	}
+}
+
+// emitOutput writes the structured output to -output_file, or to stdout when no
+// file was given.
+func emitOutput(stdout io.Writer, config *BenchmarkConfig, startedAt time.Time, results []*BenchmarkResult) error {
+	if config.OutputFormat == "text" {
+		return nil
+	}
+
+	if config.OutputFile == "" {
+		return writeOutput(stdout, config, startedAt, results)
+	}
+
+	f, err := os.Create(config.OutputFile)
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutput(f, config, startedAt, results); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s output written to: %s\n", config.OutputFormat, config.OutputFile)
+	return nil
+}
+
+// writeResultsCSV writes one row per benchmark, followed by one row per latency
+// class of that benchmark with the class column set. Latencies are integer
+// nanoseconds so spreadsheets can do arithmetic on them, with one column per
+// -percentiles value.
+func writeResultsCSV(w io.Writer, results []*BenchmarkResult) error {
+	cw := csv.NewWriter(w)
+	cols := percentileColumns(results)
+	nanos := func(d time.Duration) string { return strconv.FormatInt(d.Nanoseconds(), 10) }
+
+	header := []string{"benchmark", "class", "operations", "duration_sec", "ops_per_sec", "min_ns", "mean_ns", "stddev_ns"}
+	for _, p := range cols {
+		header = append(header, percentileField(p))
+	}
+	header = append(header, "max_ns", "bytes_read", "bytes_written", "errors", "empty_refused", "fingerprint")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		row := []string{
+			r.Label(),
+			"",
+			strconv.FormatInt(r.Operations, 10),
+			strconv.FormatFloat(r.Duration.Seconds(), 'f', 6, 64),
+			strconv.FormatFloat(r.OpsPerSecond, 'f', 2, 64),
+			nanos(r.LatencyMin),
+			nanos(r.LatencyMean),
+			nanos(r.LatencyStdDev),
+		}
+		row = append(row, percentileCells(r.Percentiles, cols, nanos)...)
+		row = append(row,
+			nanos(r.LatencyMax),
+			strconv.FormatInt(r.BytesRead, 10),
+			strconv.FormatInt(r.BytesWritten, 10),
+			strconv.FormatInt(r.Errors, 10),
+			strconv.FormatInt(r.EmptyRefused, 10),
+			r.Fingerprint,
+		)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+
+		for _, c := range r.Classes {
+			row := []string{
+				r.Label(),
+				c.Name,
+				strconv.FormatInt(c.Operations, 10),
+				"",
+				"",
+				nanos(c.LatencyMin),
+				nanos(c.LatencyMean),
+				nanos(c.LatencyStdDev),
+			}
+			row = append(row, percentileCells(c.Percentiles, cols, nanos)...)
+			row = append(row, nanos(c.LatencyMax), "", "", "", "", r.Fingerprint)
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeResultsMarkdown writes a GitHub-flavored markdown results table and a
+// configuration section, ready to paste into PR descriptions and issues.
+func writeResultsMarkdown(w io.Writer, config *BenchmarkConfig, results []*BenchmarkResult) error {
+	version := collectVersionInfo()
+	host := collectHostInfo(config.DBPath)
+
+	var sb strings.Builder
+	sb.WriteString("### Benchmark Results\n\n")
+	cols := percentileColumns(results)
+	sb.WriteString("| Test | Ops | Ops/sec | Min | Mean | StdDev |")
+	for _, p := range cols {
+		sb.WriteString(" " + percentileLabel(p) + " |")
+	}
+	sb.WriteString(" Max | Errors |\n")
+	sb.WriteString("|------|----:|--------:|----:|-----:|-------:|" + strings.Repeat("----:|", len(cols)) + "----:|-------:|\n")
+
+	for _, r := range results {
+		fmt.Fprintf(&sb, "| `%s` | %d | %.2f | %s | %s | %s | %s | %s | %d |\n",
+			r.Label(), r.Operations, r.OpsPerSecond,
+			formatDuration(r.LatencyMin), formatDuration(r.LatencyMean), formatDuration(r.LatencyStdDev),
+			strings.Join(percentileCells(r.Percentiles, cols, formatDuration), " | "),
+			formatDuration(r.LatencyMax), r.Errors)
+
+		for _, c := range r.Classes {
+			fmt.Fprintf(&sb, "| &nbsp;&nbsp;`%s/%s` | %d | | %s | %s | %s | %s | %s | |\n",
+				r.Label(), c.Name, c.Operations,
+				formatDuration(c.LatencyMin), formatDuration(c.LatencyMean), formatDuration(c.LatencyStdDev),
+				strings.Join(percentileCells(c.Percentiles, cols, formatDuration), " | "),
+				formatDuration(c.LatencyMax))
+		}
+	}
+
+	sb.WriteString("\n<details>\n<summary>Configuration</summary>\n\n")
+	sb.WriteString("| Option | Value |\n|--------|-------|\n")
+	rows := [][2]string{
+		{"Wildcat", version.WildcatVersion},
+		{"Go", version.GoVersion},
+		{"Revision", version.VCSRevision},
+		{"Host", host.String()},
+		{"Filesystem", host.FilesystemString()},
+		{"Sync Option", config.SyncOption},
+		{"Write Buffer Size", formatBytes(config.WriteBufferSize)},
+		{"Levels", strconv.Itoa(config.LevelCount)},
+		{"Bloom Filter", strconv.FormatBool(config.BloomFilter)},
+		{"Operations", strconv.FormatInt(config.NumOperations, 10)},
+		{"Key Size", fmt.Sprintf("%d bytes", config.KeySize)},
+		{"Value Size", fmt.Sprintf("%d bytes", config.ValueSize)},
+		{"Threads", strconv.Itoa(config.NumThreads)},
+		{"Batch Size", strconv.Itoa(config.BatchSize)},
+		{"Key Distribution", config.KeyDistribution},
+		{"Seed", strconv.FormatInt(config.Seed, 10)},
+		{"Fingerprint", configFingerprint(config)},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(&sb, "| %s | `%s` |\n", row[0], row[1])
+	}
+	sb.WriteString("\n</details>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
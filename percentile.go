@@ -0,0 +1,132 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Percentile is the latency at one of the -percentiles of a benchmark or
+// latency class.
+type Percentile struct {
+	Percentile float64 // 0-100, e.g. 99.9
+	Latency    time.Duration
+}
+
+// parsePercentiles parses a comma-separated list of percentiles and returns
+// it sorted ascending without duplicates.
+func parsePercentiles(s string) ([]float64, error) {
+	var out []float64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		p, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid percentile %q", part)
+		}
+		if p <= 0 || p >= 100 {
+			return nil, fmt.Errorf("percentile %q must be between 0 and 100", part)
+		}
+		out = append(out, p)
+	}
+
+	sort.Float64s(out)
+	out = slices.Compact(out)
+	if len(out) == 0 {
+		return nil, fmt.Errorf("at least one percentile is required")
+	}
+	return out, nil
+}
+
+// formatPercentiles is the inverse of parsePercentiles.
+func formatPercentiles(ps []float64) string {
+	parts := make([]string, len(ps))
+	for i, p := range ps {
+		parts[i] = strconv.FormatFloat(p, 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+// percentileLabel names a percentile in tables, e.g. P99.9.
+func percentileLabel(p float64) string {
+	return "P" + strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// percentileField names a percentile in machine-readable outputs, e.g.
+// p99.9_ns.
+func percentileField(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64) + "_ns"
+}
+
+// percentileColumns returns every percentile reported by any of the results
+// or their classes, ascending. Benchmarks can override -percentiles, so the
+// results of one run need not all carry the same set.
+func percentileColumns(results []*BenchmarkResult) []float64 {
+	var cols []float64
+	for _, r := range results {
+		for _, p := range r.Percentiles {
+			cols = append(cols, p.Percentile)
+		}
+		for _, c := range r.Classes {
+			for _, p := range c.Percentiles {
+				cols = append(cols, p.Percentile)
+			}
+		}
+	}
+
+	sort.Float64s(cols)
+	return slices.Compact(cols)
+}
+
+// lookupPercentile returns the latency recorded for percentile p.
+func lookupPercentile(ps []Percentile, p float64) (time.Duration, bool) {
+	for _, q := range ps {
+		if q.Percentile == p {
+			return q.Latency, true
+		}
+	}
+	return 0, false
+}
+
+// percentileCells formats the latency of each column, leaving columns the
+// percentiles do not cover empty.
+func percentileCells(ps []Percentile, cols []float64, format func(time.Duration) string) []string {
+	cells := make([]string, len(cols))
+	for i, p := range cols {
+		if d, ok := lookupPercentile(ps, p); ok {
+			cells[i] = format(d)
+		}
+	}
+	return cells
+}
+
+// Percentiles returns the latency at each of ps.
+func (lt *LatencyTracker) Percentiles(ps []float64) []Percentile {
+	return lt.snapshot().percentiles(ps)
+}
+
+func (h *hdrHistogram) percentiles(ps []float64) []Percentile {
+	out := make([]Percentile, len(ps))
+	for i, p := range ps {
+		out[i] = Percentile{Percentile: p, Latency: time.Duration(h.percentile(p / 100))}
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wildcatdb/wildcat/v2"
+)
+
+// sameDatabaseOptions reports whether two configs open the database the same
+// way. Pipeline phases share one open database, so they cannot differ here.
+func sameDatabaseOptions(a, b *BenchmarkConfig) bool {
+	return a.Engine == b.Engine &&
+		a.DBPath == b.DBPath &&
+		a.WriteBufferSize == b.WriteBufferSize &&
+		a.SyncOption == b.SyncOption &&
+		a.LevelCount == b.LevelCount &&
+		a.BloomFilter == b.BloomFilter &&
+		a.MaxCompactionConc == b.MaxCompactionConc
+}
+
+// runCompactWait flushes the memtable and waits for background flushing and
+// compaction to settle, judged by the on-disk size holding still for
+// SettleQuiet, or until SettleTimeout passes.
+func runCompactWait(db *wildcat.DB, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, errors *int64, cr *CompactRun) {
+
+	cr.SizeBefore = dirSize(config.DBPath)
+	start := time.Now()
+	if err := db.ForceFlush(); err != nil {
+		countError(errors, 1, err)
+	}
+
+	_, cr.Settled = waitForSettle(config, nil)
+	cr.Elapsed = time.Since(start)
+	cr.SizeAfter = dirSize(config.DBPath)
+	cr.record(tracker, opsCompleted)
+
+	if !cr.Settled {
+		fmt.Printf("Compaction did not settle within %s (on-disk: %s)\n", config.SettleTimeout, formatBytes(cr.SizeAfter))
+		return
+	}
+	fmt.Printf("Compaction settled after %s (on-disk: %s)\n", cr.Elapsed.Round(time.Millisecond), formatBytes(cr.SizeAfter))
+}
+
+// waitForSettle polls the database directory until its size has held still
+// for SettleQuiet, calling sample (if set) on every poll. It reports how long
+// that took and whether it happened before SettleTimeout.
+func waitForSettle(config *BenchmarkConfig, sample func()) (time.Duration, bool) {
+	start := time.Now()
+	size := dirSize(config.DBPath)
+	stableSince := start
+
+	interval := min(time.Second, config.SettleQuiet)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for time.Since(stableSince) < config.SettleQuiet {
+		if time.Since(start) >= config.SettleTimeout {
+			return time.Since(start), false
+		}
+
+		<-ticker.C
+		if sample != nil {
+			sample()
+		}
+		if current := dirSize(config.DBPath); current != size {
+			size = current
+			stableSince = time.Now()
+		}
+	}
+
+	return time.Since(start), true
+}
+
+// printPipelineSummary prints when each phase of a pipeline started relative
+// to the first, alongside its throughput.
+func printPipelineSummary(results []*BenchmarkResult, starts []time.Duration, total time.Duration) {
+	fmt.Printf("Pipeline Summary\n")
+	fmt.Printf("================\n")
+	fmt.Printf("%-6s %-25s %12s %12s %12s %12s\n", "Phase", "Benchmark", "Start", "Duration", "Ops", "Ops/sec")
+	fmt.Printf("%-6s %-25s %12s %12s %12s %12s\n", "-----", "---------", "-----", "--------", "---", "-------")
+
+	for i, result := range results {
+		fmt.Printf("%-6d %-25s %12s %12s %12d %12.2f\n",
+			i+1,
+			result.Label(),
+			starts[i].Round(time.Millisecond),
+			result.Duration.Round(time.Millisecond),
+			result.Operations,
+			result.OpsPerSecond)
+	}
+
+	fmt.Printf("Total pipeline time: %s\n\n", total.Round(time.Millisecond))
+}
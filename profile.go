@@ -0,0 +1,272 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// profileSeq numbers the profiled benchmarks, so repeated runs of one
+// benchmark each get their own files and the files sort in run order.
+var profileSeq int64
+
+// profilePath returns the path of a benchmark's profile in dir, creating dir
+// if needed.
+func profilePath(dir string, seq int64, benchmarkName, ext string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%03d_%s.%s", seq, benchmarkName, ext)), nil
+}
+
+// startProfiles starts the profiles requested in config for one benchmark
+// and returns a function that stops them and writes them out. Failures are
+// logged rather than aborting the benchmark.
+func startProfiles(config *BenchmarkConfig, benchmarkName string) func() {
+	seq := atomic.AddInt64(&profileSeq, 1)
+	var stops []func()
+
+	if config.CPUProfileDir != "" {
+		if stop, err := startCPUProfile(config.CPUProfileDir, seq, benchmarkName); err != nil {
+			log.Printf("Failed to start CPU profile: %v", err)
+		} else {
+			stops = append(stops, stop)
+		}
+	}
+
+	if config.MutexProfileDir != "" {
+		runtime.SetMutexProfileFraction(config.MutexProfileRate)
+		stops = append(stops, func() {
+			runtime.SetMutexProfileFraction(0)
+			writeProfile(config.MutexProfileDir, seq, benchmarkName, "mutex")
+		})
+	}
+
+	if config.BlockProfileDir != "" {
+		runtime.SetBlockProfileRate(config.BlockProfileRate)
+		stops = append(stops, func() {
+			runtime.SetBlockProfileRate(0)
+			writeProfile(config.BlockProfileDir, seq, benchmarkName, "block")
+		})
+	}
+
+	if config.ExecTrace != "" && (config.ExecTraceBenchmark == "" || config.ExecTraceBenchmark == benchmarkName) &&
+		execTraced.CompareAndSwap(false, true) {
+		stops = append(stops, startExecTrace(config))
+	}
+
+	if config.MemProfileDir != "" && config.MemProfilePeak {
+		stops = append(stops, startPeakHeapProfile(config.MemProfileDir, seq, benchmarkName))
+	}
+
+	// Taken last so the snapshot does not include the profilers' own work
+	if config.MemProfileDir != "" {
+		stops = append(stops, func() {
+			// A GC first, as the heap profile reflects the last completed one
+			runtime.GC()
+			writeHeapProfile(config.MemProfileDir, seq, benchmarkName, "heap.pprof", nil)
+		})
+	}
+
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+func startCPUProfile(dir string, seq int64, benchmarkName string) (func(), error) {
+	path, err := profilePath(dir, seq, benchmarkName, "cpu.pprof")
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return func() {
+		pprof.StopCPUProfile()
+		if err := f.Close(); err != nil {
+			log.Printf("Failed to write CPU profile: %v", err)
+			return
+		}
+		fmt.Printf("CPU profile written to: %s\n", path)
+	}, nil
+}
+
+// writeHeapProfile writes profile to the benchmark's file in dir, or the
+// current heap profile when profile is nil.
+func writeHeapProfile(dir string, seq int64, benchmarkName, ext string, profile []byte) {
+	path, err := profilePath(dir, seq, benchmarkName, ext)
+	if err == nil {
+		if profile == nil {
+			var buf bytes.Buffer
+			err = pprof.Lookup("heap").WriteTo(&buf, 0)
+			profile = buf.Bytes()
+		}
+		if err == nil {
+			err = os.WriteFile(path, profile, 0644)
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to write heap profile: %v", err)
+		return
+	}
+	fmt.Printf("Heap profile written to: %s\n", path)
+}
+
+// heapObjectsMetric is the live heap size, which runtime/metrics reads
+// without stopping the world the way runtime.ReadMemStats does.
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// startPeakHeapProfile checks the heap size every second and keeps a heap
+// profile of the largest heap seen, written out when the benchmark stops.
+func startPeakHeapProfile(dir string, seq int64, benchmarkName string) func() {
+	stop := make(chan bool)
+	done := make(chan bool)
+	var peakProfile []byte
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		sample := []metrics.Sample{{Name: heapObjectsMetric}}
+		var peak uint64
+		for {
+			select {
+			case <-ticker.C:
+				metrics.Read(sample)
+				if sample[0].Value.Kind() != metrics.KindUint64 || sample[0].Value.Uint64() <= peak {
+					continue
+				}
+				peak = sample[0].Value.Uint64()
+
+				var buf bytes.Buffer
+				if err := pprof.Lookup("heap").WriteTo(&buf, 0); err == nil {
+					peakProfile = buf.Bytes()
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		stop <- true
+		<-done
+		if peakProfile != nil {
+			writeHeapProfile(dir, seq, benchmarkName, "peak.heap.pprof", peakProfile)
+		}
+	}
+}
+
+// writeProfile writes the named runtime profile, such as mutex or block, to
+// the benchmark's file in dir. These profiles accumulate over the whole
+// process; turning them off between benchmarks only keeps the gaps out.
+func writeProfile(dir string, seq int64, benchmarkName, name string) {
+	path, err := profilePath(dir, seq, benchmarkName, name+".pprof")
+	if err == nil {
+		var f *os.File
+		if f, err = os.Create(path); err == nil {
+			err = pprof.Lookup(name).WriteTo(f, 0)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to write %s profile: %v", name, err)
+		return
+	}
+	fmt.Printf("%s profile written to: %s\n", strings.ToUpper(name[:1])+name[1:], path)
+}
+
+// execTraced is set once the -exec_trace window has been claimed, so only the
+// first matching benchmark is traced.
+var execTraced atomic.Bool
+
+// startExecTrace records a runtime execution trace to -exec_trace from
+// -exec_trace_delay into the benchmark for -exec_trace_duration, or until the
+// benchmark ends. The returned function ends the trace early if needed.
+func startExecTrace(config *BenchmarkConfig) func() {
+	stop := make(chan bool)
+	done := make(chan bool)
+
+	go func() {
+		defer close(done)
+
+		if config.ExecTraceDelay > 0 {
+			select {
+			case <-time.After(config.ExecTraceDelay):
+			case <-stop:
+				log.Printf("Benchmark ended before -exec_trace_delay, no execution trace written")
+				return
+			}
+		}
+
+		f, err := os.Create(config.ExecTrace)
+		if err != nil {
+			log.Printf("Failed to create execution trace: %v", err)
+			<-stop
+			return
+		}
+		if err := trace.Start(f); err != nil {
+			_ = f.Close()
+			log.Printf("Failed to start execution trace: %v", err)
+			<-stop
+			return
+		}
+
+		var window <-chan time.Time
+		if config.ExecTraceDuration > 0 {
+			window = time.After(config.ExecTraceDuration)
+		}
+		select {
+		case <-window:
+			trace.Stop()
+			<-stop
+		case <-stop:
+			trace.Stop()
+		}
+
+		if err := f.Close(); err != nil {
+			log.Printf("Failed to write execution trace: %v", err)
+			return
+		}
+		fmt.Printf("Execution trace written to: %s\n", config.ExecTrace)
+	}()
+
+	return func() {
+		stop <- true
+		<-done
+	}
+}
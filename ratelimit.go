@@ -0,0 +1,60 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by all worker threads of a benchmark,
+// capping the offered load at a fixed number of operations per second.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Time to earn one token
+	burst    time.Duration // Tokens saved up while idle, as time
+	strict   bool          // Never forgive a backlog, see NewRateLimiter
+	next     time.Time     // When the next token becomes available
+}
+
+// NewRateLimiter returns a limiter issuing rate tokens per second that lets
+// up to burst tokens accumulate while workers are busy elsewhere. A strict
+// limiter keeps a fixed schedule instead: after a stall the backlog is issued
+// immediately, so every operation keeps its original intended start time.
+func NewRateLimiter(rate float64, burst int, strict bool) *RateLimiter {
+	interval := time.Duration(float64(time.Second) / rate)
+	return &RateLimiter{
+		interval: interval,
+		burst:    time.Duration(burst) * interval,
+		strict:   strict,
+		next:     time.Now(),
+	}
+}
+
+// Wait blocks until n tokens are available and returns the time the
+// operation was scheduled to start.
+func (rl *RateLimiter) Wait(n int64) time.Time {
+	rl.mu.Lock()
+	if earliest := time.Now().Add(-rl.burst); !rl.strict && rl.next.Before(earliest) {
+		rl.next = earliest
+	}
+	at := rl.next
+	rl.next = rl.next.Add(time.Duration(n) * rl.interval)
+	rl.mu.Unlock()
+
+	if d := time.Until(at); d > 0 {
+		time.Sleep(d)
+	}
+	return at
+}
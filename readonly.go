@@ -0,0 +1,65 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"errors"
+)
+
+// readOnlyBenchmarks are the benchmarks -readonly can run: they only read
+// the keys an earlier run wrote.
+var readOnlyBenchmarks = []string{
+	"readseq", "readrandom", "readhot", "readmissing",
+	"iterseq", "readreverse", "iterrandom", "seekrandom", "iterprefix",
+}
+
+var errReadOnly = errors.New("database opened with -readonly")
+
+// readOnlyEngine rejects every write to the Engine under it. WildcatDB has
+// no read-only mode, so for it this is the only guard; the other engines
+// are also opened read-only themselves.
+type readOnlyEngine struct {
+	Engine
+}
+
+type readOnlyTxn struct {
+	Txn
+}
+
+func (e readOnlyEngine) Begin(writable bool) (Txn, error) {
+	if writable {
+		return nil, errReadOnly
+	}
+	txn, err := e.Engine.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	return readOnlyTxn{txn}, nil
+}
+
+func (e readOnlyEngine) Update(func(txn Txn) error) error {
+	return errReadOnly
+}
+
+func (e readOnlyEngine) View(fn func(txn Txn) error) error {
+	return e.Engine.View(func(txn Txn) error { return fn(readOnlyTxn{txn}) })
+}
+
+func (t readOnlyTxn) Put(key, value []byte) error {
+	return errReadOnly
+}
+
+func (t readOnlyTxn) Delete(key []byte) error {
+	return errReadOnly
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Reopen is what the reopen benchmark measured, one entry per -reopen_sizes.
+type Reopen struct {
+	Sizes []ReopenResult
+}
+
+// ReopenResult is the recovery cost of one database size.
+type ReopenResult struct {
+	Keys        int64
+	OnDisk      int64
+	WALBytes    int64 // WAL left behind by the close, replayed by Open
+	Close       time.Duration
+	Open        time.Duration // Open as closed, WAL replay included
+	FirstRead   time.Duration // From the start of Open to the first read
+	FlushedOpen time.Duration `json:",omitempty"` // Open with the memtable flushed first, so no WAL to replay; WildcatDB only
+	Replay      time.Duration `json:",omitempty"` // Open less FlushedOpen; WildcatDB only
+}
+
+// runReopen measures how long a database takes to come back after a restart.
+// For each of ReopenSizes it fills a scratch database beside DBPath, closes
+// it, and times Open and the first successful read of the last key written.
+// WildcatDB replays its WAL inside Open, so with WildcatDB the database is
+// then flushed, closed and opened again with no WAL left to replay; the
+// difference between the two opens is the replay time.
+func runReopen(config *BenchmarkConfig, tracker *LatencyTracker, opsCompleted, bytesWritten, errors *int64, ro *Reopen) {
+	opens := tracker.Class("open")
+	firstReads := tracker.Class("first_read")
+	flushedOpens := tracker.Class("open_flushed")
+
+	scratch := *config
+	scratch.DBPath = config.DBPath + "_reopen"
+
+	for _, keys := range config.ReopenSizes {
+		if err := os.RemoveAll(scratch.DBPath); err != nil {
+			countError(errors, 1, err)
+			continue
+		}
+
+		db, err := openEngine(&scratch)
+		if err != nil {
+			countError(errors, 1, err)
+			continue
+		}
+		fillForReopen(db, &scratch, keys, bytesWritten, errors)
+
+		result := ReopenResult{Keys: keys}
+
+		start := time.Now()
+		if err := db.Close(); err != nil {
+			countError(errors, 1, err)
+		}
+		result.Close = time.Since(start)
+
+		result.OnDisk = dirSize(scratch.DBPath)
+		result.WALBytes = walSize(scratch.DBPath)
+
+		start = time.Now()
+		db, err = openEngine(&scratch)
+		if err != nil {
+			countError(errors, 1, err)
+			continue
+		}
+		result.Open = time.Since(start)
+
+		// Background recovery may still be running after Open returns, so
+		// retry until the data is actually readable
+		key := generateKey(keys-1, scratch.KeySize, scratch.KeyDistribution)
+		for {
+			err := db.View(func(txn Txn) error {
+				_, err := txn.Get(key)
+				return err
+			})
+			if err == nil {
+				break
+			}
+			if time.Since(start) >= config.SettleTimeout {
+				countError(errors, 1, fmt.Errorf("key not readable %s after reopen: %w", config.SettleTimeout, err))
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		result.FirstRead = time.Since(start)
+
+		tracker.Record(0, result.Open)
+		opens.Record(0, result.Open)
+		firstReads.Record(0, result.FirstRead)
+		atomic.AddInt64(opsCompleted, 1)
+
+		if config.Engine == "wildcat" {
+			if err := wildcatDB(db).ForceFlush(); err != nil {
+				countError(errors, 1, err)
+			}
+			if err := db.Close(); err != nil {
+				countError(errors, 1, err)
+			}
+
+			start = time.Now()
+			db, err = openEngine(&scratch)
+			if err != nil {
+				countError(errors, 1, err)
+				ro.Sizes = append(ro.Sizes, result)
+				continue
+			}
+			result.FlushedOpen = time.Since(start)
+			result.Replay = max(result.Open-result.FlushedOpen, 0)
+			flushedOpens.Record(0, result.FlushedOpen)
+		}
+
+		if err := db.Close(); err != nil {
+			countError(errors, 1, err)
+		}
+		ro.Sizes = append(ro.Sizes, result)
+	}
+
+	_ = os.RemoveAll(scratch.DBPath)
+
+	printReopen(ro)
+}
+
+func printReopen(ro *Reopen) {
+	fmt.Printf("Reopen (open includes WAL replay, flushed open has none; first read counts from the start of open)\n")
+	fmt.Printf("  %12s %12s %12s %12s %12s %12s %14s %12s\n", "Keys", "On-disk", "WAL", "Close", "Open", "First read", "Flushed open", "Replay")
+	for _, r := range ro.Sizes {
+		flushedOpen, replay := "-", "-"
+		if r.FlushedOpen > 0 {
+			flushedOpen, replay = formatDuration(r.FlushedOpen), formatDuration(r.Replay)
+		}
+		fmt.Printf("  %12d %12s %12s %12s %12s %12s %14s %12s\n", r.Keys,
+			formatBytes(r.OnDisk), formatBytes(r.WALBytes),
+			formatDuration(r.Close), formatDuration(r.Open), formatDuration(r.FirstRead), flushedOpen, replay)
+	}
+}
+
+// fillForReopen writes keys sequentially numbered keys across NumThreads
+// workers.
+func fillForReopen(db Engine, config *BenchmarkConfig, keys int64, bytesWritten, errors *int64) {
+	var wg sync.WaitGroup
+	perThread := keys / int64(config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			values := valueRand(config, threadID)
+			start := int64(threadID) * perThread
+			end := start + perThread
+			if threadID == config.NumThreads-1 {
+				end = keys
+			}
+
+			for i := start; i < end; i++ {
+				key := generateKey(i, config.KeySize, config.KeyDistribution)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
+
+				err := db.Update(func(txn Txn) error {
+					return txn.Put(key, value)
+				})
+				if err != nil {
+					countError(errors, 1, err)
+				} else {
+					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+				}
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+// walSize sums the WAL files under dir.
+func walSize(dir string) int64 {
+	var total int64
+	for path, size := range fileSizes(dir) {
+		if strings.HasSuffix(filepath.Base(path), ".wal") {
+			total += size
+		}
+	}
+	return total
+}
+
+// parseCountList parses a comma-separated list of positive counts, which may
+// be written in exponent form such as 1e6.
+func parseCountList(s string) ([]int64, error) {
+	var out []int64
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			f, ferr := strconv.ParseFloat(part, 64)
+			if ferr != nil || f != math.Trunc(f) || f > math.MaxInt64 {
+				return nil, fmt.Errorf("invalid count %q", part)
+			}
+			n = int64(f)
+		}
+		if n <= 0 {
+			return nil, fmt.Errorf("count %q must be positive", part)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+// formatCountList is the inverse of parseCountList.
+func formatCountList(ns []int64) string {
+	parts := make([]string, len(ns))
+	for i, n := range ns {
+		parts[i] = strconv.FormatInt(n, 10)
+	}
+	return strings.Join(parts, ",")
+}
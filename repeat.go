@@ -0,0 +1,137 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RunStat summarizes one metric over repeated runs of a benchmark.
+type RunStat struct {
+	Mean   float64
+	StdDev float64
+	Min    float64
+	Max    float64
+	CV     float64 // Coefficient of variation, StdDev/Mean
+}
+
+// PercentileStat summarizes the latency at one percentile, in nanoseconds.
+type PercentileStat struct {
+	Percentile float64
+	RunStat
+}
+
+// RepeatSummary aggregates the runs of a benchmark under -repeat.
+type RepeatSummary struct {
+	Runs      int
+	OpsPerSec RunStat
+	Latencies []PercentileStat
+}
+
+func summarizeRuns(runs []*BenchmarkResult) *RepeatSummary {
+	metric := func(f func(r *BenchmarkResult) float64) RunStat {
+		values := make([]float64, len(runs))
+		for i, r := range runs {
+			values[i] = f(r)
+		}
+		return computeRunStat(values)
+	}
+
+	s := &RepeatSummary{
+		Runs:      len(runs),
+		OpsPerSec: metric(func(r *BenchmarkResult) float64 { return r.OpsPerSecond }),
+	}
+	for _, p := range runs[0].Percentiles {
+		s.Latencies = append(s.Latencies, PercentileStat{
+			Percentile: p.Percentile,
+			RunStat: metric(func(r *BenchmarkResult) float64 {
+				d, _ := lookupPercentile(r.Percentiles, p.Percentile)
+				return float64(d)
+			}),
+		})
+	}
+	return s
+}
+
+// computeRunStat uses the sample standard deviation, as the runs are a sample
+// of what the benchmark could produce.
+func computeRunStat(values []float64) RunStat {
+	s := RunStat{Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, v := range values {
+		s.Mean += v
+		s.Min = min(s.Min, v)
+		s.Max = max(s.Max, v)
+	}
+	s.Mean /= float64(len(values))
+
+	if len(values) > 1 {
+		var sumSq float64
+		for _, v := range values {
+			sumSq += (v - s.Mean) * (v - s.Mean)
+		}
+		s.StdDev = math.Sqrt(sumSq / float64(len(values)-1))
+	}
+	if s.Mean != 0 {
+		s.CV = s.StdDev / s.Mean
+	}
+	return s
+}
+
+// printRepeatSummaries prints a summary for each group of repeated runs.
+// Repetitions of a benchmark are numbered from 1; they are consecutive
+// except in an A/B comparison, which alternates the two variants.
+func printRepeatSummaries(results []*BenchmarkResult) {
+	var groups [][]*BenchmarkResult
+	latest := make(map[string]int) // Group of the latest run 1 of each label
+	for _, r := range results {
+		label := r.SweepLabel()
+		switch {
+		case r.Run == 1:
+			latest[label] = len(groups)
+			groups = append(groups, []*BenchmarkResult{r})
+		case r.Run > 1:
+			if i, ok := latest[label]; ok {
+				groups[i] = append(groups[i], r)
+			}
+		}
+	}
+
+	for _, group := range groups {
+		if len(group) > 1 {
+			printRepeatSummary(group[0].SweepLabel(), summarizeRuns(group))
+		}
+	}
+}
+
+func printRepeatSummary(name string, s *RepeatSummary) {
+	fmt.Printf("Repeat Summary: %s (%d runs)\n", name, s.Runs)
+	fmt.Printf("  %-10s %12s %12s %12s %12s %8s\n", "Metric", "Mean", "StdDev", "Min", "Max", "CV")
+	fmt.Printf("  %-10s %12.2f %12.2f %12.2f %12.2f %7.2f%%\n", "Ops/sec",
+		s.OpsPerSec.Mean, s.OpsPerSec.StdDev, s.OpsPerSec.Min, s.OpsPerSec.Max, 100*s.OpsPerSec.CV)
+
+	latency := func(label string, st RunStat) {
+		fmt.Printf("  %-10s %12s %12s %12s %12s %7.2f%%\n", label,
+			formatDuration(time.Duration(st.Mean)),
+			formatDuration(time.Duration(st.StdDev)),
+			formatDuration(time.Duration(st.Min)),
+			formatDuration(time.Duration(st.Max)),
+			100*st.CV)
+	}
+	for _, l := range s.Latencies {
+		latency(percentileLabel(l.Percentile), l.RunStat)
+	}
+	fmt.Printf("\n")
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceOp is one operation of a replay trace.
+type TraceOp struct {
+	At        time.Duration // Offset from the first operation of the trace
+	Op        string        // get, put, delete
+	Key       []byte
+	ValueSize int
+}
+
+// parseTraceLine parses "<timestamp> <GET|PUT|DELETE> <key> [value_size]".
+// Timestamps are seconds with an optional fraction; keys starting with 0x
+// are hex-decoded so binary keys survive the text format.
+func parseTraceLine(line string) (ts float64, op TraceOp, err error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return 0, op, fmt.Errorf("expected timestamp, op and key")
+	}
+
+	ts, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, op, fmt.Errorf("invalid timestamp %q", fields[0])
+	}
+
+	op.Op = strings.ToLower(fields[1])
+	switch op.Op {
+	case "get", "delete":
+	case "put":
+		if len(fields) < 4 {
+			return 0, op, fmt.Errorf("put needs a value size")
+		}
+		op.ValueSize, err = strconv.Atoi(fields[3])
+		if err != nil || op.ValueSize < 0 {
+			return 0, op, fmt.Errorf("invalid value size %q", fields[3])
+		}
+	default:
+		return 0, op, fmt.Errorf("unknown op %q", fields[1])
+	}
+
+	if strings.HasPrefix(fields[2], "0x") {
+		op.Key, err = hex.DecodeString(fields[2][2:])
+		if err != nil {
+			return 0, op, fmt.Errorf("invalid hex key %q", fields[2])
+		}
+	} else {
+		op.Key = []byte(fields[2])
+	}
+
+	return ts, op, nil
+}
+
+// runReplay replays the -trace file. Operations on the same key always go to
+// the same worker so their order is kept; with -trace_timing the original
+// inter-arrival times, scaled by -trace_speed, are honoured as well. An
+// invalid line stops the replay and is returned after the operations queued
+// before it finish.
+func runReplay(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64) error {
+
+	f, err := os.Open(config.TraceFile)
+	if err != nil {
+		return fmt.Errorf("failed to open trace: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	var trace io.Reader = f
+	if strings.HasSuffix(config.TraceFile, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to open trace: %w", err)
+		}
+		trace = gz
+	}
+
+	classes := map[string]*LatencyTracker{
+		"get":    tracker.Class("get"),
+		"put":    tracker.Class("put"),
+		"delete": tracker.Class("delete"),
+	}
+
+	type queuedOp struct {
+		TraceOp
+		scheduled time.Time
+	}
+
+	queues := make([]chan queuedOp, config.NumThreads)
+	var wg sync.WaitGroup
+
+	for t := range queues {
+		queues[t] = make(chan queuedOp, 1024)
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			values := valueRand(config, threadID)
+			for op := range queues[threadID] {
+				startTime := time.Now()
+				if config.TraceTiming && config.CorrectLatency {
+					startTime = op.scheduled
+				}
+
+				switch op.Op {
+				case "get":
+					var value []byte
+					err := db.View(func(txn Txn) error {
+						var err error
+						value, err = txn.Get(op.Key)
+						return err
+					})
+					if isNotFound(err) {
+						err = nil
+					}
+					recordReplay(tracker, classes["get"], threadID, startTime, err, errors)
+					atomic.AddInt64(bytesRead, int64(len(op.Key)+len(value)))
+				case "put":
+					value := generateValue(values, op.ValueSize, config.CompressibleData)
+					err := db.Update(func(txn Txn) error {
+						return txn.Put(op.Key, value)
+					})
+					recordReplay(tracker, classes["put"], threadID, startTime, err, errors)
+					if err == nil {
+						atomic.AddInt64(bytesWritten, int64(len(op.Key)+len(value)))
+					}
+				case "delete":
+					err := db.Update(func(txn Txn) error {
+						return txn.Delete(op.Key)
+					})
+					recordReplay(tracker, classes["delete"], threadID, startTime, err, errors)
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	scanner := bufio.NewScanner(trace)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var first float64
+	var haveFirst bool
+	var lineNum int
+	var invalid error
+	started := time.Now()
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		ts, op, err := parseTraceLine(line)
+		if err != nil {
+			invalid = fmt.Errorf("invalid trace line %d: %w", lineNum, err)
+			break
+		}
+		if !haveFirst {
+			first, haveFirst = ts, true
+		}
+
+		scheduled := time.Now()
+		if config.TraceTiming {
+			op.At = time.Duration((ts - first) / config.TraceSpeed * float64(time.Second))
+			scheduled = started.Add(op.At)
+			if d := time.Until(scheduled); d > 0 {
+				time.Sleep(d)
+			}
+		}
+
+		h := fnv.New32a()
+		_, _ = h.Write(op.Key)
+		queues[h.Sum32()%uint32(len(queues))] <- queuedOp{TraceOp: op, scheduled: scheduled}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Printf("Failed to read trace: %v", err)
+	}
+
+	for _, q := range queues {
+		close(q)
+	}
+	wg.Wait()
+
+	return invalid
+}
+
+func recordReplay(tracker, class *LatencyTracker, threadID int, startTime time.Time, err error, errors *int64) {
+	latency := time.Since(startTime)
+	tracker.Record(threadID, latency)
+	class.Record(threadID, latency)
+	if err != nil {
+		countError(errors, 1, err)
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	chartWidth  = 640
+	chartHeight = 220
+	chartMargin = 48
+)
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Wildcat Benchmark Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+section { margin-bottom: 3em; }
+.charts { display: flex; flex-wrap: wrap; gap: 2em; }
+svg text { font-size: 11px; fill: #444; }
+</style>
+</head>
+<body>
+<h1>Wildcat Benchmark Report</h1>
+<p>Started {{.StartedAt}} &middot; wildcat {{.Version.WildcatVersion}} &middot; {{.Version.GoVersion}} &middot; seed {{.Seed}} &middot; fingerprint {{.Fingerprint}}</p>
+
+<h2>Configuration</h2>
+<table>
+{{range .ConfigRows}}<tr><td>{{index . 0}}</td><td>{{index . 1}}</td></tr>
+{{end}}</table>
+
+<h2>Results</h2>
+<table>
+<tr><th>Test</th><th>Ops</th><th>Ops/sec</th><th>Min</th><th>Mean</th><th>StdDev</th>{{range .PercentileHeaders}}<th>{{.}}</th>{{end}}<th>Max</th><th>Errors</th></tr>
+{{range .Benchmarks}}<tr><td>{{.Result.Label}}</td><td>{{.Result.Operations}}</td><td>{{printf "%.2f" .Result.OpsPerSecond}}</td><td>{{.Min}}</td><td>{{.Mean}}</td><td>{{.StdDev}}</td>{{range .Latencies}}<td>{{.}}</td>{{end}}<td>{{.Max}}</td><td>{{.Result.Errors}}</td></tr>
+{{end}}</table>
+
+{{range .Benchmarks}}<section>
+<h2>{{.Result.Label}}</h2>
+<div class="charts">
+<div><h3>Throughput over time</h3>{{.ThroughputChart}}</div>
+<div><h3>Latency percentiles</h3>{{.LatencyChart}}</div>
+<div><h3>Latency over time</h3>{{.LatencySeriesChart}}</div>
+</div>
+</section>
+{{end}}
+</body>
+</html>
+`))
+
+type reportBenchmark struct {
+	Result          *BenchmarkResult
+	Min             string
+	Mean            string
+	StdDev          string
+	Latencies       []string // One cell per reportData.PercentileHeaders
+	Max             string
+	ThroughputChart template.HTML
+	LatencyChart    template.HTML
+
+	LatencySeriesChart template.HTML
+}
+
+type reportData struct {
+	*ResultsFile
+	ConfigRows        [][2]string
+	PercentileHeaders []string
+	Benchmarks        []reportBenchmark
+}
+
+// writeHTMLReport renders a self-contained HTML report with inline SVG charts,
+// so it can be opened or shared without any other files or network access.
+func writeHTMLReport(path string, config *BenchmarkConfig, startedAt time.Time, results []*BenchmarkResult) error {
+	rf := newResultsFile(config, startedAt, results)
+	data := reportData{
+		ResultsFile: rf,
+		ConfigRows: [][2]string{
+			{"Host", rf.Host.String()},
+			{"Filesystem", rf.Host.FilesystemString()},
+			{"Database Path", config.DBPath},
+			{"Write Buffer Size", formatBytes(config.WriteBufferSize)},
+			{"Sync Option", config.SyncOption},
+			{"Levels", fmt.Sprint(config.LevelCount)},
+			{"Bloom Filter", fmt.Sprint(config.BloomFilter)},
+			{"Operations", fmt.Sprint(config.NumOperations)},
+			{"Key Size", fmt.Sprintf("%d bytes", config.KeySize)},
+			{"Value Size", fmt.Sprintf("%d bytes", config.ValueSize)},
+			{"Threads", fmt.Sprint(config.NumThreads)},
+			{"Batch Size", fmt.Sprint(config.BatchSize)},
+			{"Key Distribution", config.KeyDistribution},
+			{"Benchmarks", formatBenchmarkSpecs(config.Benchmarks)},
+		},
+	}
+
+	cols := percentileColumns(results)
+	for _, p := range cols {
+		data.PercentileHeaders = append(data.PercentileHeaders, percentileLabel(p))
+	}
+
+	for _, r := range results {
+		data.Benchmarks = append(data.Benchmarks, reportBenchmark{
+			Result:          r,
+			Min:             formatDuration(r.LatencyMin),
+			Mean:            formatDuration(r.LatencyMean),
+			StdDev:          formatDuration(r.LatencyStdDev),
+			Latencies:       percentileCells(r.Percentiles, cols, formatDuration),
+			Max:             formatDuration(r.LatencyMax),
+			ThroughputChart: throughputChart(r.Timeline),
+			LatencyChart:    latencyChart(r),
+
+			LatencySeriesChart: latencySeriesChart(r.LatencySeries),
+		})
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := reportTemplate.Execute(f, data); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// throughputChart draws ops/sec per one-second bucket as a line chart.
+func throughputChart(timeline []int64) template.HTML {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg width="%d" height="%d" xmlns="http://www.w3.org/2000/svg">`, chartWidth, chartHeight)
+
+	if len(timeline) == 0 {
+		fmt.Fprintf(&sb, `<text x="%d" y="%d">Finished in under a second, no samples</text></svg>`,
+			chartMargin, chartHeight/2)
+		return template.HTML(sb.String())
+	}
+
+	var peak int64 = 1
+	for _, ops := range timeline {
+		peak = max(peak, ops)
+	}
+
+	plotW := float64(chartWidth - 2*chartMargin)
+	plotH := float64(chartHeight - 2*chartMargin)
+	x := func(i int) float64 {
+		if len(timeline) == 1 {
+			return float64(chartMargin) + plotW/2
+		}
+		return float64(chartMargin) + plotW*float64(i)/float64(len(timeline)-1)
+	}
+	y := func(v int64) float64 {
+		return float64(chartMargin) + plotH - plotH*float64(v)/float64(peak)
+	}
+
+	writeAxes(&sb, fmt.Sprintf("%d ops/s", peak), "0", "0s", fmt.Sprintf("%ds", len(timeline)))
+
+	sb.WriteString(`<polyline fill="none" stroke="#2a6fdb" stroke-width="1.5" points="`)
+	for i, ops := range timeline {
+		fmt.Fprintf(&sb, "%.1f,%.1f ", x(i), y(ops))
+	}
+	sb.WriteString(`"/></svg>`)
+
+	return template.HTML(sb.String())
+}
+
+// latencyChart draws the recorded percentiles, plus each latency class, as
+// horizontal bars scaled to the slowest value.
+func latencyChart(r *BenchmarkResult) template.HTML {
+	type bar struct {
+		label string
+		value time.Duration
+	}
+
+	var bars []bar
+	for _, p := range r.Percentiles {
+		bars = append(bars, bar{percentileLabel(p.Percentile), p.Latency})
+	}
+	bars = append(bars, bar{"Max", r.LatencyMax})
+
+	// Classes get a single bar at the highest reported percentile.
+	for _, c := range r.Classes {
+		if len(c.Percentiles) == 0 {
+			continue
+		}
+		top := c.Percentiles[len(c.Percentiles)-1]
+		bars = append(bars, bar{c.Name + " " + percentileLabel(top.Percentile), top.Latency})
+	}
+
+	var longest time.Duration = 1
+	for _, b := range bars {
+		longest = max(longest, b.value)
+	}
+
+	rowH := 22
+	height := len(bars)*rowH + chartMargin
+	labelW := 120
+	plotW := float64(chartWidth - labelW - chartMargin)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg width="%d" height="%d" xmlns="http://www.w3.org/2000/svg">`, chartWidth, height)
+	for i, b := range bars {
+		top := chartMargin/2 + i*rowH
+		w := plotW * float64(b.value) / float64(longest)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" text-anchor="end">%s</text>`,
+			labelW-6, top+rowH/2+4, template.HTMLEscapeString(b.label))
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="#e07b39"/>`,
+			labelW, top+3, w, rowH-6)
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%d">%s</text>`,
+			float64(labelW)+w+4, top+rowH/2+4, formatDuration(b.value))
+	}
+	sb.WriteString(`</svg>`)
+
+	return template.HTML(sb.String())
+}
+
+// latencySeriesColors are cycled through for the percentile lines of
+// latencySeriesChart.
+var latencySeriesColors = []string{"#2a6fdb", "#3fa34d", "#e0b339", "#e07b39", "#c0392b", "#8e44ad"}
+
+// latencySeriesChart draws each percentile of every latency window as a line,
+// on a shared scale up to the slowest value.
+func latencySeriesChart(windows []LatencyWindow) template.HTML {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg width="%d" height="%d" xmlns="http://www.w3.org/2000/svg">`, chartWidth, chartHeight)
+
+	if len(windows) == 0 {
+		fmt.Fprintf(&sb, `<text x="%d" y="%d">No latency windows recorded</text></svg>`,
+			chartMargin, chartHeight/2)
+		return template.HTML(sb.String())
+	}
+
+	var longest time.Duration = 1
+	for _, w := range windows {
+		for _, p := range w.Percentiles {
+			longest = max(longest, p.Latency)
+		}
+	}
+	end := windows[len(windows)-1].End
+
+	plotW := float64(chartWidth - 2*chartMargin)
+	plotH := float64(chartHeight - 2*chartMargin)
+	x := func(d time.Duration) float64 {
+		return float64(chartMargin) + plotW*float64(d)/float64(end)
+	}
+	y := func(d time.Duration) float64 {
+		return float64(chartMargin) + plotH - plotH*float64(d)/float64(longest)
+	}
+
+	writeAxes(&sb, formatDuration(longest), "0", "0s", end.Round(time.Second).String())
+
+	for i, p := range windows[0].Percentiles {
+		color := latencySeriesColors[i%len(latencySeriesColors)]
+		fmt.Fprintf(&sb, `<polyline fill="none" stroke="%s" stroke-width="1.5" points="`, color)
+		for _, w := range windows {
+			if d, ok := lookupPercentile(w.Percentiles, p.Percentile); ok {
+				fmt.Fprintf(&sb, "%.1f,%.1f ", x(w.End), y(d))
+			}
+		}
+		sb.WriteString(`"/>`)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" fill="%s" style="fill:%s">%s</text>`,
+			chartWidth-chartMargin+4, chartMargin+12*i+4, color, color, percentileLabel(p.Percentile))
+	}
+	sb.WriteString(`</svg>`)
+
+	return template.HTML(sb.String())
+}
+
+func writeAxes(sb *strings.Builder, yTop, yBottom, xLeft, xRight string) {
+	left, right := chartMargin, chartWidth-chartMargin
+	top, bottom := chartMargin, chartHeight-chartMargin
+
+	fmt.Fprintf(sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#888"/>`, left, top, left, bottom)
+	fmt.Fprintf(sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#888"/>`, left, bottom, right, bottom)
+	fmt.Fprintf(sb, `<text x="%d" y="%d">%s</text>`, left, top-8, yTop)
+	fmt.Fprintf(sb, `<text x="%d" y="%d" text-anchor="end">%s</text>`, left-4, bottom, yBottom)
+	fmt.Fprintf(sb, `<text x="%d" y="%d">%s</text>`, left, bottom+16, xLeft)
+	fmt.Fprintf(sb, `<text x="%d" y="%d" text-anchor="end">%s</text>`, right, bottom+16, xRight)
+}
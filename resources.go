@@ -0,0 +1,211 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// ResourceUsage is the CPU and memory the benchmark process used while a
+// benchmark ran. CPU is in cores, so 2.5 means two and a half cores busy on
+// average, never more than NumCPU.
+type ResourceUsage struct {
+	CPUAvg     *float64 `json:",omitempty"` // nil for a benchmark shorter than cpuResolution
+	CPUPeak    *float64 `json:",omitempty"` // Busiest one-second sample; nil like CPUAvg
+	CPUSeconds float64  // User plus system CPU time
+	OpsPerCPU  float64  // Operations per CPU second
+	RSSAvg     int64    // Bytes
+	RSSPeak    int64
+	NumCPU     int
+}
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc/<pid>/stat. It is
+// 100 on every mainstream Linux architecture.
+const clockTicks = 100
+
+// cpuResolution is the shortest benchmark whose CPU average is reported: the
+// sampling interval, far above a clock tick. Each thread's CPU time is
+// rounded to whole ticks, so over a shorter run the rounding alone can add
+// whole cores to the average.
+const cpuResolution = time.Second
+
+// readProcessUsage returns the process's total CPU time and resident set
+// size from /proc, which limits resource sampling to Linux.
+func readProcessUsage() (cpu time.Duration, rss int64, ok bool) {
+	stat, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return 0, 0, false
+	}
+	// The command name is parenthesized and may contain spaces, so fields
+	// are counted from the closing parenthesis: utime and stime are the
+	// 14th and 15th fields overall
+	end := bytes.LastIndexByte(stat, ')')
+	if end < 0 {
+		return 0, 0, false
+	}
+	fields := bytes.Fields(stat[end+1:])
+	if len(fields) < 13 {
+		return 0, 0, false
+	}
+	utime, err1 := strconv.ParseInt(string(fields[11]), 10, 64)
+	stime, err2 := strconv.ParseInt(string(fields[12]), 10, 64)
+
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0, 0, false
+	}
+	mem := bytes.Fields(statm)
+	if len(mem) < 2 {
+		return 0, 0, false
+	}
+	pages, err3 := strconv.ParseInt(string(mem[1]), 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, 0, false
+	}
+
+	return time.Duration(utime+stime) * time.Second / clockTicks, pages * int64(os.Getpagesize()), true
+}
+
+// ResourceSampler samples the process's CPU time and RSS every second while a
+// benchmark runs.
+type ResourceSampler struct {
+	startCPU  time.Duration
+	startedAt time.Time
+	cpuPeak   float64
+	rssSum    int64
+	rssPeak   int64
+	samples   int64
+	ok        bool
+	stop      chan bool
+	done      chan bool
+}
+
+func StartResourceSampler() *ResourceSampler {
+	rs := &ResourceSampler{
+		startedAt: time.Now(),
+		stop:      make(chan bool),
+		done:      make(chan bool),
+	}
+	rs.startCPU, _, rs.ok = readProcessUsage()
+
+	go func() {
+		defer close(rs.done)
+		if !rs.ok {
+			<-rs.stop
+			return
+		}
+
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		lastCPU, lastAt := rs.startCPU, rs.startedAt
+		for {
+			select {
+			case <-ticker.C:
+				cpu, rss, ok := readProcessUsage()
+				if !ok {
+					continue
+				}
+				now := time.Now()
+				rs.cpuPeak = max(rs.cpuPeak, float64(cpu-lastCPU)/float64(now.Sub(lastAt)))
+				rs.rssSum += rss
+				rs.rssPeak = max(rs.rssPeak, rss)
+				rs.samples++
+				lastCPU, lastAt = cpu, now
+			case <-rs.stop:
+				return
+			}
+		}
+	}()
+
+	return rs
+}
+
+// Stop ends sampling and returns the usage over the benchmark, or nil where
+// /proc is not available.
+func (rs *ResourceSampler) Stop(operations int64) *ResourceUsage {
+	rs.stop <- true
+	<-rs.done
+	if !rs.ok {
+		return nil
+	}
+
+	cpu, rss, ok := readProcessUsage()
+	if !ok {
+		return nil
+	}
+	elapsed := time.Since(rs.startedAt)
+
+	// Benchmarks shorter than a second still get the final reading
+	rs.rssSum += rss
+	rs.rssPeak = max(rs.rssPeak, rss)
+	rs.samples++
+
+	u := &ResourceUsage{
+		CPUSeconds: (cpu - rs.startCPU).Seconds(),
+		RSSAvg:     rs.rssSum / rs.samples,
+		RSSPeak:    rs.rssPeak,
+		NumCPU:     runtime.NumCPU(),
+	}
+	if elapsed >= cpuResolution {
+		cores := float64(u.NumCPU)
+		avg := min(u.CPUSeconds/elapsed.Seconds(), cores)
+		peak := min(max(rs.cpuPeak, avg), cores)
+		u.CPUAvg, u.CPUPeak = &avg, &peak
+	}
+	if u.CPUSeconds > 0 {
+		u.OpsPerCPU = float64(operations) / u.CPUSeconds
+	}
+	return u
+}
+
+func printResourceUsage(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		u := result.Resources
+		if u == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Process Resources (%d CPUs)\n", u.NumCPU)
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %10s %10s %12s %14s %12s %12s\n",
+				"Test", "CPU avg", "CPU peak", "CPU sec", "Ops/CPU sec", "RSS avg", "RSS peak")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %10s %10s %12.2f %14.2f %12s %12s\n",
+			result.Label(), formatCores(u.CPUAvg), formatCores(u.CPUPeak), u.CPUSeconds, u.OpsPerCPU,
+			formatBytes(u.RSSAvg), formatBytes(u.RSSPeak))
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
+
+// formatCores formats a CPU figure in cores, or N/A when the benchmark was
+// too short to measure it.
+func formatCores(cores *float64) string {
+	if cores == nil {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f", *cores)
+}
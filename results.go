@@ -0,0 +1,158 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// ResultsFile is everything needed to reproduce a run: the fully resolved
+// configuration, the seed and the exact code that produced the numbers.
+type ResultsFile struct {
+	Version     VersionInfo
+	Host        HostInfo
+	Seed        int64
+	Fingerprint string // See configFingerprint; each result carries its own
+	StartedAt   time.Time
+	Config      *BenchmarkConfig
+	Results     []*BenchmarkResult
+}
+
+// VersionInfo identifies the code a run was produced with.
+type VersionInfo struct {
+	GoVersion      string
+	BenchVersion   string
+	VCSRevision    string
+	VCSModified    bool
+	WildcatVersion string
+	BinaryHash     string
+}
+
+// collectVersionInfo reads the build info embedded by the Go toolchain and
+// hashes the running executable, so results built from a dirty or untagged
+// tree can still be told apart.
+func collectVersionInfo() VersionInfo {
+	info := VersionInfo{GoVersion: runtime.Version()}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.BenchVersion = bi.Main.Version
+		for _, dep := range bi.Deps {
+			if dep.Path == "github.com/wildcatdb/wildcat/v2" {
+				info.WildcatVersion = dep.Version
+				if dep.Replace != nil {
+					info.WildcatVersion += " => " + dep.Replace.Path + " " + dep.Replace.Version
+				}
+			}
+		}
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.VCSRevision = setting.Value
+			case "vcs.modified":
+				info.VCSModified = setting.Value == "true"
+			}
+		}
+	}
+
+	if exe, err := os.Executable(); err == nil {
+		if f, err := os.Open(exe); err == nil {
+			h := sha256.New()
+			if _, err := io.Copy(h, f); err == nil {
+				info.BinaryHash = hex.EncodeToString(h.Sum(nil))
+			}
+			_ = f.Close()
+		}
+	}
+
+	return info
+}
+
+func newResultsFile(config *BenchmarkConfig, startedAt time.Time, results []*BenchmarkResult) *ResultsFile {
+	return &ResultsFile{
+		Version:     collectVersionInfo(),
+		Host:        collectHostInfo(config.DBPath),
+		Seed:        config.Seed,
+		Fingerprint: configFingerprint(config),
+		StartedAt:   startedAt,
+		Config:      config,
+		Results:     results,
+	}
+}
+
+func encodeResultsJSON(w io.Writer, config *BenchmarkConfig, startedAt time.Time, results []*BenchmarkResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(newResultsFile(config, startedAt, results))
+}
+
+func writeResultsFile(path string, config *BenchmarkConfig, startedAt time.Time, results []*BenchmarkResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := encodeResultsJSON(f, config, startedAt, results); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func readResultsFile(path string) (*ResultsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rf := &ResultsFile{}
+	if err := json.Unmarshal(data, rf); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if rf.Config == nil {
+		return nil, fmt.Errorf("%s does not contain a benchmark configuration", path)
+	}
+
+	return rf, nil
+}
+
+// printVersionMismatch warns when a rerun is not executing the same code that
+// produced the original results, since the numbers are then not expected to
+// match exactly.
+func printVersionMismatch(recorded, current VersionInfo) {
+	if recorded.BinaryHash != "" && recorded.BinaryHash == current.BinaryHash {
+		return
+	}
+
+	if recorded.VCSRevision != current.VCSRevision || recorded.VCSModified || current.VCSModified {
+		fmt.Printf("Warning: results were recorded at revision %q (modified=%t), running %q (modified=%t)\n",
+			recorded.VCSRevision, recorded.VCSModified, current.VCSRevision, current.VCSModified)
+	}
+	if recorded.WildcatVersion != current.WildcatVersion {
+		fmt.Printf("Warning: results were recorded with wildcat %s, running %s\n",
+			recorded.WildcatVersion, current.WildcatVersion)
+	}
+	if recorded.GoVersion != current.GoVersion {
+		fmt.Printf("Warning: results were recorded with %s, running %s\n", recorded.GoVersion, current.GoVersion)
+	}
+}
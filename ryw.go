@@ -0,0 +1,156 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReadYourWrites counts the violations the readyourwrites benchmark found.
+// They are kept apart from errors, so a correct run's numbers are not
+// mixed with correctness failures.
+type ReadYourWrites struct {
+	Checked     int64 // Writes checked
+	InTxn       int64 // Reads in the writing transaction not returning the value just put
+	AfterCommit int64 // Reads in a new transaction after the commit not returning it
+}
+
+// runReadYourWrites overwrites random existing keys, each in its own
+// transaction: it puts a fresh value, reads it back in the same transaction,
+// commits and reads it again in a new one. Each thread only touches keys
+// whose index is congruent to its ID, so no other writer can legitimately
+// change a key between the commit and the read after it.
+func runReadYourWrites(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64, ryw *ReadYourWrites) {
+
+	puts := tracker.Class("put")
+	inTxnGets := tracker.Class("get_in_txn")
+	commits := tracker.Class("commit")
+	committedGets := tracker.Class("get_after_commit")
+
+	keysPerThread := max(config.ExistingKeys/int64(config.NumThreads), 1)
+
+	// The zipfian encoding maps many indices to one key, which would let
+	// threads share keys
+	distribution := config.KeyDistribution
+	if distribution == "zipfian" {
+		distribution = "sequential"
+	}
+
+	var wg sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, config.NumThreads)
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func(threadID int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(config.Seed + int64(threadID)))
+			values := valueRand(config, threadID)
+
+			for range ops.Thread(threadID) {
+				keyIndex := rng.Int63n(keysPerThread)*int64(config.NumThreads) + int64(threadID)
+				key := generateKey(keyIndex, config.KeySize, distribution)
+				value := generateValue(values, config.ValueSize, config.CompressibleData)
+
+				startTime := ops.StartTime(threadID)
+
+				err := func() error {
+					txn, err := db.Begin(true)
+					if err != nil {
+						return err
+					}
+
+					phaseStart := time.Now()
+					opTrace.Record("PUT", key, len(value))
+					if err := txn.Put(key, value); err != nil {
+						_ = txn.Rollback()
+						return err
+					}
+					puts.Record(threadID, time.Since(phaseStart))
+
+					phaseStart = time.Now()
+					opTrace.Record("GET", key, 0)
+					got, err := txn.Get(key)
+					inTxnGets.Record(threadID, time.Since(phaseStart))
+					if err != nil || !bytes.Equal(got, value) {
+						atomic.AddInt64(&ryw.InTxn, 1)
+					}
+
+					phaseStart = time.Now()
+					if err := txn.Commit(); err != nil {
+						return wrapCommit(err)
+					}
+					commits.Record(threadID, time.Since(phaseStart))
+					atomic.AddInt64(bytesWritten, int64(len(key)+len(value)))
+
+					phaseStart = time.Now()
+					err = db.View(func(txn Txn) error {
+						var err error
+						opTrace.Record("GET", key, 0)
+						got, err = txn.Get(key)
+						return err
+					})
+					committedGets.Record(threadID, time.Since(phaseStart))
+					if err != nil || !bytes.Equal(got, value) {
+						atomic.AddInt64(&ryw.AfterCommit, 1)
+					} else {
+						atomic.AddInt64(bytesRead, int64(len(key)+len(got)))
+					}
+
+					atomic.AddInt64(&ryw.Checked, 1)
+					return nil
+				}()
+
+				tracker.Record(threadID, time.Since(startTime))
+
+				if err != nil {
+					countError(errors, 1, err)
+				}
+
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+func printReadYourWrites(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		r := result.ReadYourWrites
+		if r == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Read Your Writes\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %12s %12s %14s\n", "Test", "Checked", "In txn", "After commit")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %12d %12d %14d\n", result.Label(), r.Checked, r.InTxn, r.AfterCommit)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// stopRequested ends the running benchmark early: no schedule hands out
+// another operation once it is set, and no further benchmark starts. The
+// serve subcommand sets it to stop a run.
+var stopRequested atomic.Bool
+
+// OpSchedule hands out operation indices to worker threads. Normally each
+// thread gets a fixed share of the operations; with -duration every thread
+// instead keeps going until the deadline, continuing past its share with
+// indices no other thread uses. With -target_rate every index waits for
+// its share of the rate first.
+//
+// With -open_loop the workers no longer pace themselves: a scheduler
+// goroutine issues every index at the target rate into a bounded queue and
+// whichever worker is free takes the next one. When the workers fall behind,
+// operations wait in the queue and that wait is part of their latency.
+type OpSchedule struct {
+	total    int64
+	threads  int
+	deadline time.Time
+	limiter  *RateLimiter
+	weight   int64       // Operations per index, for rate limiting batches
+	intended []time.Time // Per-thread scheduled start of the current operation
+
+	openLoop   bool
+	queueDepth int
+	queue      chan scheduledOp
+	startQueue sync.Once
+
+	// Operations each thread has done, counting those skipped on resuming
+	// from -resume_file. Tracked only for a fixed number of operations.
+	progress []atomic.Int64
+}
+
+type scheduledOp struct {
+	index int64
+	at    time.Time
+}
+
+// NewOpSchedule splits total operations across threads. The -duration clock
+// starts now, so create the schedule right before starting the workers.
+func NewOpSchedule(config *BenchmarkConfig, total int64, threads int) *OpSchedule {
+	s := &OpSchedule{total: total, threads: threads, weight: 1}
+	if config.Duration > 0 {
+		s.deadline = time.Now().Add(config.Duration)
+	}
+	if config.TargetRate > 0 {
+		s.openLoop = config.OpenLoop
+		s.queueDepth = config.QueueDepth
+		s.limiter = NewRateLimiter(config.TargetRate, config.NumThreads, config.CorrectLatency || config.OpenLoop)
+		if config.CorrectLatency {
+			s.intended = make([]time.Time, max(threads, config.NumThreads))
+		}
+	}
+	if suiteCheckpoint != nil && s.deadline.IsZero() && !s.openLoop {
+		s.progress = make([]atomic.Int64, max(threads, config.NumThreads))
+		for i, done := range suiteCheckpoint.schedule(s) {
+			if i < len(s.progress) {
+				s.progress[i].Store(done)
+			}
+		}
+	}
+	return s
+}
+
+// Progress returns how many operations each thread has done.
+func (s *OpSchedule) Progress() []int64 {
+	out := make([]int64, len(s.progress))
+	for i := range s.progress {
+		out[i] = s.progress[i].Load()
+	}
+	return out
+}
+
+// done returns how many operations threadID had done when resumed.
+func (s *OpSchedule) done(threadID int) int64 {
+	if threadID < 0 || threadID >= len(s.progress) {
+		return 0
+	}
+	return s.progress[threadID].Load()
+}
+
+// advance records that threadID has done n operations.
+func (s *OpSchedule) advance(threadID int, n int64) {
+	if threadID >= 0 && threadID < len(s.progress) {
+		s.progress[threadID].Store(n)
+	}
+}
+
+// Batched marks every index as n operations, so a rate limit counts the
+// operations inside each batch rather than the batches.
+func (s *OpSchedule) Batched(n int64) *OpSchedule {
+	s.weight = n
+	return s
+}
+
+// Thread yields the operation indices assigned to threadID.
+func (s *OpSchedule) Thread(threadID int) iter.Seq[int64] {
+	if s.openLoop {
+		return s.dequeue(threadID)
+	}
+
+	perThread := s.total / int64(s.threads)
+	start := int64(threadID) * perThread
+	end := start + perThread
+	if threadID == s.threads-1 {
+		end = s.total
+	}
+
+	return func(yield func(int64) bool) {
+		for i := start + s.done(threadID); i < end; i++ {
+			if !s.next(threadID) || !yield(i) {
+				return
+			}
+			s.advance(threadID, i+1-start)
+		}
+		if s.deadline.IsZero() {
+			return
+		}
+		for i := s.total + int64(threadID); s.next(threadID); i += int64(s.threads) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Count yields 0 through n-1 for a thread that indexes its own operations,
+// continuing past n until the deadline with -duration.
+func (s *OpSchedule) Count(threadID int, n int64) iter.Seq[int64] {
+	if s.openLoop {
+		return s.dequeue(threadID)
+	}
+
+	return func(yield func(int64) bool) {
+		for i := s.done(threadID); i < n || !s.deadline.IsZero(); i++ {
+			if !s.next(threadID) || !yield(i) {
+				return
+			}
+			s.advance(threadID, i+1)
+		}
+	}
+}
+
+// StartTime returns the time to measure the current operation of threadID
+// from. When rate limited with -correct_latency this is the time the
+// operation was scheduled to start, so time spent queued behind a stall
+// counts towards its latency instead of being silently omitted.
+func (s *OpSchedule) StartTime(threadID int) time.Time {
+	if s.intended != nil {
+		return s.intended[threadID]
+	}
+	return time.Now()
+}
+
+// next waits for the rate limit and reports whether another operation may
+// start before the deadline and without a stop requested.
+func (s *OpSchedule) next(threadID int) bool {
+	if s.limiter != nil {
+		at := s.limiter.Wait(s.weight)
+		if s.intended != nil {
+			s.intended[threadID] = at
+		}
+	}
+	if stopRequested.Load() {
+		return false
+	}
+	return s.deadline.IsZero() || time.Now().Before(s.deadline)
+}
+
+// dequeue yields the indices issued by the open-loop scheduler, which all
+// threads share. The scheduler starts with the first worker.
+func (s *OpSchedule) dequeue(threadID int) iter.Seq[int64] {
+	s.startQueue.Do(func() {
+		s.queue = make(chan scheduledOp, s.queueDepth)
+		go s.issue()
+	})
+
+	return func(yield func(int64) bool) {
+		for op := range s.queue {
+			if s.intended != nil {
+				s.intended[threadID] = op.at
+			}
+			if !yield(op.index) {
+				return
+			}
+		}
+	}
+}
+
+// issue feeds the queue on the limiter's fixed schedule. A full queue blocks
+// it, but the operations keep their scheduled times, so the backlog still
+// shows up in the latencies.
+func (s *OpSchedule) issue() {
+	defer close(s.queue)
+	for i := int64(0); i < s.total || !s.deadline.IsZero(); i++ {
+		at := s.limiter.Wait(s.weight)
+		if !s.deadline.IsZero() && !time.Now().Before(s.deadline) || stopRequested.Load() {
+			return
+		}
+		s.queue <- scheduledOp{index: i, at: at}
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// servedRunFlags are the flags a run started over HTTP may set, directly or
+// as per-benchmark parameters, sweep axes or A/B variants. They shape the
+// workload only: the database path, every file the tool reads or writes and
+// every address it sends to stay as the server's defaults set them.
+var servedRunFlags = []string{
+	"engine", "write_buffer_size", "sync", "sync_interval", "levels", "bloom_filter", "bloom_filter_fpr",
+	"max_compaction_concurrency", "readonly", "use_existing_db", "warm_cache", "compression",
+	"level_multiplier", "block_manager_lru_size", "block_manager_lru_evict_ratio", "block_manager_lru_access_weight",
+	"compaction_cooldown", "compaction_batch_size", "compaction_size_ratio", "compaction_size_threshold",
+	"compaction_score_size_weight", "compaction_score_count_weight", "compaction_similarity_ratio",
+	"compaction_read_wait_backoff", "compaction_partition_ratio", "compaction_partition_distribution",
+	"compactor_tick", "flusher_tick", "wal_append_retry", "wal_append_backoff", "sstable_btree_order",
+	"max_concurrent_txns", "txn_begin_retry", "txn_begin_backoff", "txn_begin_max_backoff", "recover_uncommitted_txns",
+	"num", "duration", "target_rate", "correct_latency", "open_loop", "queue_depth",
+	"key_size", "value_size", "large_value_size", "append_size", "threads", "batch_size",
+	"txn_ops", "txn_retries", "txn_backoff", "txn_hold", "benchmarks",
+	"read_ratio", "miss_ratio", "scan_length", "seek_nexts", "hot_read_ratio",
+	"key_dist", "key_space", "hot_op_ratio", "hot_key_ratio", "existing_keys", "max_key_size",
+	"age_buckets", "report_interval", "histogram", "stats", "output", "latency_window",
+	"space_interval", "wal_interval", "compaction_interval", "percentiles",
+	"delete_ratio", "reclaim_wait", "reclaim_interval", "trace_timing", "trace_speed",
+	"repeat", "repeat_fresh", "fresh_db", "sweep", "ab_a", "ab_b", "sig_test", "sig_alpha", "pipeline",
+	"settle_quiet", "settle_timeout", "reopen_sizes", "use_txn", "iterator_tests", "compressible",
+	"verify", "validate", "check_consistency", "check_lost_writes", "check_iterators", "check_timestamps",
+	"seed", "cleanup",
+}
+
+// ServedRun is one benchmark run started through the serve subcommand.
+type ServedRun struct {
+	ID         int
+	Args       []string
+	Status     string // running, stopping, done, stopped or failed
+	Error      string `json:",omitempty"` // Why the run failed
+	StartedAt  time.Time
+	FinishedAt *time.Time   `json:",omitempty"`
+	Results    *ResultsFile `json:",omitempty"` // Once finished, as written by -results_file; a failed run has those that finished
+}
+
+// Progress is the live state of the running benchmark, as GET /progress
+// returns it.
+type Progress struct {
+	Running      bool
+	Run          int    `json:",omitempty"`
+	Benchmark    string `json:",omitempty"`
+	Elapsed      time.Duration
+	Ops          int64
+	OpsPerSecond float64
+	Errors       int64
+	Percentiles  []Percentile     `json:",omitempty"`
+	Threads      []ThreadProgress `json:",omitempty"`
+}
+
+// benchServer runs one benchmark run at a time on behalf of HTTP clients.
+// The benchmarks share process-wide state, so runs cannot overlap.
+type benchServer struct {
+	defaults []string // Benchmark flags every run starts from
+
+	mu     sync.Mutex
+	runs   []*ServedRun
+	active *ServedRun
+}
+
+// runServe runs the serve subcommand: an HTTP API to start and stop
+// benchmark runs, follow their progress and fetch their results as JSON.
+// Benchmark flags after -- are the defaults for every run, which a run's own
+// arguments override.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", "127.0.0.1:8080", "Address to serve the HTTP API on")
+	token := fs.String("token", "", "Token clients must send as \"Authorization: Bearer <token>\"; required unless -listen is a loopback address")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s serve [flags] [-- benchmark flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *token == "" && !isLoopback(*listen) {
+		log.Fatalf("-listen=%s is reachable from other hosts, so -token is required", *listen)
+	}
+
+	s := &benchServer{defaults: fs.Args()}
+
+	// Fail on bad defaults now rather than on the first run
+	if _, err := s.parseRunArgs(nil); err != nil {
+		log.Fatalf("Invalid default benchmark flags: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /runs", s.startRun)
+	mux.HandleFunc("GET /runs", s.listRuns)
+	mux.HandleFunc("GET /runs/{id}", s.getRun)
+	mux.HandleFunc("POST /runs/{id}/stop", s.stopRun)
+	mux.HandleFunc("GET /progress", s.progress)
+
+	var handler http.Handler = mux
+	if *token != "" {
+		handler = requireToken(*token, mux)
+	}
+
+	printBanner()
+	fmt.Printf("Serving the benchmark API on %s\n", *listen)
+	if err := http.ListenAndServe(*listen, handler); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// isLoopback reports whether addr listens only on a loopback interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// requireToken refuses requests that do not carry token as a bearer token.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			writeJSONError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseRunArgs parses a run's arguments over the server's defaults.
+func (s *benchServer) parseRunArgs(args []string) (*BenchmarkConfig, error) {
+	if err := checkRunArgs(args); err != nil {
+		return nil, err
+	}
+
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return parseConfig(fs, append(append([]string{}, s.defaults...), args...))
+}
+
+// checkRunArgs refuses a run's arguments if they set any flag outside
+// servedRunFlags, including through per-benchmark parameters, sweep axes and
+// A/B variants.
+func checkRunArgs(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	config := defaultConfig()
+	raw := registerFlags(fs, config)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var names []string
+	fs.Visit(func(f *flag.Flag) {
+		names = append(names, f.Name)
+	})
+
+	specs, err := parseBenchmarkSpecs(*raw.benchmarks)
+	if err != nil {
+		return fmt.Errorf("invalid benchmarks: %w", err)
+	}
+	for _, spec := range specs {
+		for name := range spec.Params {
+			names = append(names, name)
+		}
+	}
+
+	axes, err := parseSweep(config.Sweep)
+	if err != nil {
+		return fmt.Errorf("invalid sweep: %w", err)
+	}
+	for _, axis := range axes {
+		names = append(names, axis.Name)
+	}
+
+	for _, variant := range []string{config.ABVariantA, config.ABVariantB} {
+		if variant == "" {
+			continue
+		}
+		params, rest, err := parseSpecParams(variant + ")")
+		if err != nil || rest != "" {
+			return fmt.Errorf("invalid A/B variant: expected name=value,... in %q", variant)
+		}
+		for name := range params {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		if !slices.Contains(servedRunFlags, name) {
+			return fmt.Errorf("-%s cannot be set for a served run (only the server's defaults may set it)", name)
+		}
+	}
+	return nil
+}
+
+// startRun starts a run from a JSON body {"args": [benchmark flags...]}.
+func (s *benchServer) startRun(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Args []string `json:"args"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeJSONError(w, http.StatusBadRequest, "invalid request: %v", err)
+		return
+	}
+
+	config, err := s.parseRunArgs(req.Args)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	if s.active != nil {
+		id := s.active.ID
+		s.mu.Unlock()
+		writeJSONError(w, http.StatusConflict, "run %d is still running", id)
+		return
+	}
+	run := &ServedRun{ID: len(s.runs) + 1, Args: req.Args, Status: "running", StartedAt: time.Now()}
+	s.runs = append(s.runs, run)
+	s.active = run
+	summary := *run
+	s.mu.Unlock()
+
+	stopRequested.Store(false)
+	go s.execute(run, config)
+
+	writeJSON(w, http.StatusAccepted, summary)
+}
+
+// execute runs a started run to the end. A run that fails is marked failed
+// with the error, and the server carries on.
+func (s *benchServer) execute(run *ServedRun, config *BenchmarkConfig) {
+	startedAt, results, err := runBench(config)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	if len(results) > 0 || err == nil {
+		run.Results = newResultsFile(config, startedAt, results)
+	}
+	switch {
+	case err != nil:
+		run.Status = "failed"
+		run.Error = err.Error()
+		log.Printf("Run %d failed: %v", run.ID, err)
+	case stopRequested.Load():
+		run.Status = "stopped"
+	default:
+		run.Status = "done"
+	}
+	s.active = nil
+}
+
+// listRuns returns every run without its results.
+func (s *benchServer) listRuns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	runs := make([]ServedRun, len(s.runs))
+	for i, run := range s.runs {
+		runs[i] = *run
+		runs[i].Results = nil
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, runs)
+}
+
+func (s *benchServer) getRun(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	run, ok := s.lookup(r)
+	var copied ServedRun
+	if ok {
+		copied = *run
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, "no run %s", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, copied)
+}
+
+// stopRun stops the run: the running benchmark hands out no more
+// operations and finishes with what it has done, and the remaining ones are
+// skipped. The run's results cover what finished.
+func (s *benchServer) stopRun(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	run, ok := s.lookup(r)
+	if !ok {
+		s.mu.Unlock()
+		writeJSONError(w, http.StatusNotFound, "no run %s", r.PathValue("id"))
+		return
+	}
+	if run != s.active {
+		s.mu.Unlock()
+		writeJSONError(w, http.StatusConflict, "run %d is not running", run.ID)
+		return
+	}
+	stopRequested.Store(true)
+	run.Status = "stopping"
+	summary := *run
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusAccepted, summary)
+}
+
+// lookup returns the run named by the request's id. Call it with s.mu held.
+func (s *benchServer) lookup(r *http.Request) (*ServedRun, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 || id > len(s.runs) {
+		return nil, false
+	}
+	return s.runs[id-1], true
+}
+
+func (s *benchServer) progress(w http.ResponseWriter, r *http.Request) {
+	var p Progress
+
+	s.mu.Lock()
+	if s.active != nil {
+		p.Run = s.active.ID
+	}
+	s.mu.Unlock()
+
+	if live := currentRun.Load(); live != nil {
+		p.Running = true
+		p.Benchmark = live.Name
+		p.Elapsed = time.Since(live.StartTime)
+		p.Ops = atomic.LoadInt64(live.OpsCompleted)
+		p.OpsPerSecond = float64(p.Ops) / p.Elapsed.Seconds()
+		p.Errors = atomic.LoadInt64(live.Errors)
+		p.Percentiles = live.Tracker.Percentiles(live.Percentiles)
+		p.Threads = live.Tracker.Threads()
+	}
+
+	writeJSON(w, http.StatusOK, p)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
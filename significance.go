@@ -0,0 +1,253 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// sigTests are the tests -sig_test can apply to per-run throughput.
+var sigTests = []string{"welch", "mannwhitney"}
+
+// significance returns the two-sided p-value of the named test for the
+// hypothesis that samples a and b come from the same distribution, or NaN
+// when there are too few runs to say.
+func significance(test string, a, b []float64) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return math.NaN()
+	}
+	if test == "mannwhitney" {
+		return mannWhitneyU(a, b)
+	}
+	return welchTTest(a, b)
+}
+
+// welchTTest is Student's t-test without assuming equal variances, which
+// throughput under different options rarely has.
+func welchTTest(a, b []float64) float64 {
+	sa, sb := computeRunStat(a), computeRunStat(b)
+	va := sa.StdDev * sa.StdDev / float64(len(a))
+	vb := sb.StdDev * sb.StdDev / float64(len(b))
+
+	if va+vb == 0 {
+		if sa.Mean == sb.Mean {
+			return 1
+		}
+		return 0
+	}
+
+	t := (sb.Mean - sa.Mean) / math.Sqrt(va+vb)
+	df := (va + vb) * (va + vb) / (va*va/float64(len(a)-1) + vb*vb/float64(len(b)-1))
+	return regIncBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// mannWhitneyU is the rank-sum test, which assumes nothing about the shape
+// of the distribution and shrugs off a single outlying run. Without ties
+// the p-value is exact; with ties it uses the tie-corrected normal
+// approximation.
+func mannWhitneyU(a, b []float64) float64 {
+	type sample struct {
+		value float64
+		fromA bool
+	}
+	all := make([]sample, 0, len(a)+len(b))
+	for _, v := range a {
+		all = append(all, sample{v, true})
+	}
+	for _, v := range b {
+		all = append(all, sample{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	// Rank sum of a, giving tied values the mean of their ranks
+	var rankSum, tieTerm float64
+	ties := false
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromA {
+				rankSum += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties = true
+			tieTerm += t*t*t - t
+		}
+		i = j
+	}
+
+	n1, n2 := float64(len(a)), float64(len(b))
+	u := rankSum - n1*(n1+1)/2
+	u = min(u, n1*n2-u)
+
+	if !ties && len(all) <= 40 {
+		dist := mannWhitneyDist(len(a), len(b))
+		var below, total float64
+		for i, count := range dist {
+			if float64(i) <= u {
+				below += count
+			}
+			total += count
+		}
+		return min(2*below/total, 1)
+	}
+
+	n := n1 + n2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - tieTerm/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	z := max(n1*n2/2-u-0.5, 0) / sigma
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// mannWhitneyDist counts the orderings of m and n distinct values giving
+// each U statistic, using the largest value: from the first sample it beats
+// all n of the second, otherwise it adds nothing.
+func mannWhitneyDist(m, n int) []float64 {
+	counts := make([][][]float64, m+1)
+	for i := range counts {
+		counts[i] = make([][]float64, n+1)
+		for j := range counts[i] {
+			c := make([]float64, i*j+1)
+			switch {
+			case i == 0 || j == 0:
+				c[0] = 1
+			default:
+				for u := range c {
+					if u >= j {
+						c[u] += counts[i-1][j][u-j]
+					}
+					if u < len(counts[i][j-1]) {
+						c[u] += counts[i][j-1][u]
+					}
+				}
+			}
+			counts[i][j] = c
+		}
+	}
+	return counts[m][n]
+}
+
+// regIncBeta is the regularized incomplete beta function I_x(a, b), by the
+// continued fraction from Numerical Recipes.
+func regIncBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgab, _ := math.Lgamma(a + b)
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	const tiny = 1e-300
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+
+	c := 1.0
+	d := 1 / clamp(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1.0; m <= 300; m++ {
+		aa := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 / clamp(1+aa*d)
+		c = clamp(1 + aa/c)
+		h *= d * c
+
+		aa = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 / clamp(1+aa*d)
+		c = clamp(1 + aa/c)
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-12 {
+			break
+		}
+	}
+	return h
+}
+
+// formatPValue renders a p-value and whether it clears alpha.
+func formatPValue(p, alpha float64) (string, string) {
+	if math.IsNaN(p) {
+		return "-", "too few runs"
+	}
+	if p < alpha {
+		return fmt.Sprintf("%.4f", p), "yes"
+	}
+	return fmt.Sprintf("%.4f", p), "no"
+}
+
+// printBaselineComparison compares the throughput of each benchmark with
+// the same label in an earlier results file, testing whether the
+// difference is more than run-to-run noise.
+func printBaselineComparison(config *BenchmarkConfig, path string, baseline, results []*BenchmarkResult) {
+	before, after := groupResults(baseline), groupResults(results)
+
+	fmt.Printf("Baseline Comparison: %s (%s, alpha %g)\n", path, config.SigTest, config.SigAlpha)
+	fmt.Printf("==============================\n")
+	fmt.Printf("%-25s %6s %14s %6s %14s %9s %9s %12s\n",
+		"Test", "Runs", "Baseline", "Runs", "Ops/sec", "Change", "p-value", "Significant")
+
+	compared := 0
+	for _, label := range after.order {
+		b, ok := before.runs[label]
+		if !ok {
+			continue
+		}
+		a := after.runs[label]
+		mb, ma := meanOf(b, opsPerSecond), meanOf(a, opsPerSecond)
+		p, verdict := formatPValue(significance(config.SigTest, opsOf(b), opsOf(a)), config.SigAlpha)
+
+		fmt.Printf("%-25s %6d %14.2f %6d %14.2f %9s %9s %12s\n",
+			label, len(b), mb, len(a), ma, percentChange(mb, ma), p, verdict)
+		compared++
+	}
+	if compared == 0 {
+		fmt.Printf("No benchmark in %s matches this run\n", path)
+	}
+
+	for _, label := range before.order {
+		if _, ok := after.runs[label]; !ok {
+			fmt.Printf("%-25s not run this time\n", label)
+		}
+	}
+	for _, label := range after.order {
+		if b, ok := before.runs[label]; ok {
+			if msg := fingerprintMismatch(b, after.runs[label]); msg != "" {
+				fmt.Printf("Warning: %s: %s\n", label, msg)
+			}
+		}
+	}
+	fmt.Printf("\n")
+}
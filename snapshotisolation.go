@@ -0,0 +1,252 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	mrand "math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// pairTagSize is the length of the tag snapshotisolation stamps at the start
+// of both values of a pair: the run's nonce and the write's generation.
+const pairTagSize = 16
+
+// SnapshotIsolation counts the anomalies the snapshotisolation benchmark
+// found. Like ReadYourWrites they are kept apart from errors.
+type SnapshotIsolation struct {
+	Snapshots    int64 // Read transactions held
+	PairsRead    int64
+	Torn         int64    // Pairs seen with one half of a transaction but not the other
+	Unrepeatable int64    // Pairs read again in the same snapshot that had changed
+	Anomalies    []string `json:",omitempty"` // The first few anomalies found
+
+	mu sync.Mutex // Guards Anomalies
+}
+
+func (si *SnapshotIsolation) anomaly(counter *int64, format string, args ...any) {
+	atomic.AddInt64(counter, 1)
+	si.mu.Lock()
+	if len(si.Anomalies) < maxBadKeys {
+		si.Anomalies = append(si.Anomalies, fmt.Sprintf(format, args...))
+	}
+	si.mu.Unlock()
+}
+
+// runSnapshotIsolation checks that read transactions never see part of a
+// committed write transaction. Existing keys are paired, 2p with 2p+1, and
+// half the threads (at least one) write both keys of a random pair in one
+// transaction, tagging the two values alike. The other threads each hold a
+// View open for TxnHold at a time while the writers commit, reading random
+// pairs: both halves must carry the same tag, or neither one of this run's.
+// Before closing the snapshot, the first pair it read is read again and
+// must not have changed.
+func runSnapshotIsolation(db Engine, config *BenchmarkConfig, tracker *LatencyTracker,
+	opsCompleted, bytesRead, bytesWritten, errors *int64, txnStats *TxnStats, si *SnapshotIsolation) {
+
+	phases := newTxnPhases(tracker, "write_pair")
+	pairReads := tracker.Class("read_pair")
+	snapshots := tracker.Class("snapshot")
+
+	writeThreads := max(config.NumThreads/2, 1)
+	readThreads := max(config.NumThreads-writeThreads, 1)
+	pairs := max(config.ExistingKeys/2, 1)
+
+	// The zipfian encoding maps many indices to one key, which would let
+	// pairs share keys
+	distribution := config.KeyDistribution
+	if distribution == "zipfian" {
+		distribution = "sequential"
+	}
+	pairKeys := func(p int64) ([]byte, []byte) {
+		return generateKey(2*p, config.KeySize, distribution), generateKey(2*p+1, config.KeySize, distribution)
+	}
+
+	// A fresh nonce keeps values left by earlier runs from being taken for
+	// this run's writes
+	var nonce [8]byte
+	_, _ = rand.Read(nonce[:])
+	var generation uint64
+
+	// parse returns the generation tagged on value, if it is one of this
+	// run's
+	parse := func(value []byte, err error) (uint64, bool) {
+		if err != nil || len(value) < pairTagSize || !bytes.Equal(value[:8], nonce[:]) {
+			return 0, false
+		}
+		return binary.BigEndian.Uint64(value[8:]), true
+	}
+
+	stop := make(chan struct{})
+	stopped := func() bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return false
+		}
+	}
+	var readWG sync.WaitGroup
+
+	for t := 0; t < readThreads; t++ {
+		readWG.Add(1)
+		go func(threadID int) {
+			defer readWG.Done()
+
+			rng := mrand.New(mrand.NewSource(config.Seed + int64(threadID)))
+
+			for !stopped() {
+				snapshotStart := time.Now()
+				err := db.View(func(txn Txn) error {
+					var firstPair int64
+					var firstA, firstB []byte
+					var firstErrA, firstErrB error
+
+					// Once the writers are done nothing more can change
+					// under the snapshot
+					for n := 0; n == 0 || (time.Since(snapshotStart) < config.TxnHold && !stopped()); n++ {
+						p := rng.Int63n(pairs)
+						keyA, keyB := pairKeys(p)
+
+						startTime := time.Now()
+						opTrace.Record("GET", keyA, 0)
+						a, errA := txn.Get(keyA)
+						opTrace.Record("GET", keyB, 0)
+						b, errB := txn.Get(keyB)
+						latency := time.Since(startTime)
+						tracker.Record(threadID, latency)
+						pairReads.Record(threadID, latency)
+
+						for _, err := range []error{errA, errB} {
+							if err != nil && !isNotFound(err) {
+								countError(errors, 1, err)
+							}
+						}
+						atomic.AddInt64(bytesRead, int64(len(keyA)+len(a)+len(keyB)+len(b)))
+						atomic.AddInt64(&si.PairsRead, 1)
+						atomic.AddInt64(opsCompleted, 1)
+
+						genA, okA := parse(a, errA)
+						genB, okB := parse(b, errB)
+						if okA != okB || genA != genB {
+							si.anomaly(&si.Torn, "torn: pair %d read %x with generation %d (tagged %t) and %x with %d (tagged %t)",
+								p, keyA, genA, okA, keyB, genB, okB)
+						}
+
+						if n == 0 {
+							firstPair = p
+							firstA, firstB = bytes.Clone(a), bytes.Clone(b)
+							firstErrA, firstErrB = errA, errB
+						}
+					}
+
+					keyA, keyB := pairKeys(firstPair)
+					a, errA := txn.Get(keyA)
+					b, errB := txn.Get(keyB)
+					if !bytes.Equal(a, firstA) || !bytes.Equal(b, firstB) ||
+						(errA == nil) != (firstErrA == nil) || (errB == nil) != (firstErrB == nil) {
+						si.anomaly(&si.Unrepeatable, "unrepeatable: pair %d changed within a snapshot held for %s",
+							firstPair, time.Since(snapshotStart).Round(time.Millisecond))
+					}
+
+					return nil
+				})
+				snapshots.Record(threadID, time.Since(snapshotStart))
+				atomic.AddInt64(&si.Snapshots, 1)
+
+				if err != nil {
+					countError(errors, 1, err)
+				}
+			}
+		}(writeThreads + t)
+	}
+
+	var writeWG sync.WaitGroup
+	ops := NewOpSchedule(config, config.NumOperations, writeThreads)
+
+	for t := 0; t < writeThreads; t++ {
+		writeWG.Add(1)
+		go func(threadID int) {
+			values := valueRand(config, threadID)
+			defer writeWG.Done()
+
+			rng := mrand.New(mrand.NewSource(config.Seed + int64(threadID)))
+
+			for range ops.Thread(threadID) {
+				keyA, keyB := pairKeys(rng.Int63n(pairs))
+				valueA := generateValue(values, max(config.ValueSize, pairTagSize), config.CompressibleData)
+				valueB := generateValue(values, max(config.ValueSize, pairTagSize), config.CompressibleData)
+
+				copy(valueA, nonce[:])
+				binary.BigEndian.PutUint64(valueA[8:], atomic.AddUint64(&generation, 1))
+				copy(valueB, valueA[:pairTagSize])
+
+				startTime := ops.StartTime(threadID)
+
+				committed, err := runTxn(db, config, txnStats, phases, threadID, 2, func(txn Txn) error {
+					opTrace.Record("PUT", keyA, len(valueA))
+					if err := txn.Put(keyA, valueA); err != nil {
+						return err
+					}
+					opTrace.Record("PUT", keyB, len(valueB))
+					return txn.Put(keyB, valueB)
+				})
+
+				if err != nil {
+					countError(errors, 1, err)
+				} else if committed {
+					atomic.AddInt64(bytesWritten, int64(len(keyA)+len(valueA)+len(keyB)+len(valueB)))
+				}
+
+				tracker.Record(threadID, time.Since(startTime))
+				atomic.AddInt64(opsCompleted, 1)
+			}
+		}(t)
+	}
+
+	writeWG.Wait()
+	close(stop)
+	readWG.Wait()
+}
+
+func printSnapshotIsolation(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		si := result.SnapshotIsolation
+		if si == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Snapshot Isolation\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %10s %12s %10s %14s\n", "Test", "Snapshots", "Pairs read", "Torn", "Unrepeatable")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %10d %12d %10d %14d\n", result.Label(), si.Snapshots, si.PairsRead, si.Torn, si.Unrepeatable)
+		for _, a := range si.Anomalies {
+			fmt.Printf("  %s\n", a)
+		}
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DiskSize is the on-disk size of a database, split into SSTables, which
+// WildcatDB keeps in one subdirectory per level, WAL files, and everything
+// else such as ID generator state.
+type DiskSize struct {
+	Total    int64
+	SSTables int64
+	WAL      int64
+	Other    int64
+}
+
+// measureDisk walks dir and sizes each kind of file in it.
+func measureDisk(dir string) DiskSize {
+	var ds DiskSize
+	for path, size := range fileSizes(dir) {
+		ds.Total += size
+		rel, err := filepath.Rel(dir, path)
+		switch {
+		case strings.HasSuffix(path, ".wal"):
+			ds.WAL += size
+		case err == nil && strings.ContainsRune(rel, filepath.Separator):
+			ds.SSTables += size
+		default:
+			ds.Other += size
+		}
+	}
+	return ds
+}
+
+// SpaceUsage tracks the database size across a benchmark. Amplification is
+// the on-disk size after the benchmark divided by the logical bytes written
+// to the database so far in this run, overall and for SSTables and WAL alone.
+type SpaceUsage struct {
+	Before        DiskSize
+	After         DiskSize
+	Peak          int64 // Largest total seen, sampled every -space_interval
+	LogicalBytes  int64
+	Amplification float64
+	SSTableAmp    float64
+	WALAmp        float64
+}
+
+// SpaceSampler measures a database directory when a benchmark starts and
+// ends, and its total size periodically in between.
+type SpaceSampler struct {
+	dir   string
+	usage SpaceUsage
+	stop  chan bool
+	done  chan bool
+}
+
+// StartSpaceSampler measures dir now and then every interval, which disables
+// periodic sampling when zero.
+func StartSpaceSampler(dir string, interval time.Duration) *SpaceSampler {
+	ss := &SpaceSampler{
+		dir:  dir,
+		stop: make(chan bool),
+		done: make(chan bool),
+	}
+	ss.usage.Before = measureDisk(dir)
+	ss.usage.Peak = ss.usage.Before.Total
+
+	go func() {
+		defer close(ss.done)
+		if interval <= 0 {
+			<-ss.stop
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ss.usage.Peak = max(ss.usage.Peak, dirSize(ss.dir))
+			case <-ss.stop:
+				return
+			}
+		}
+	}()
+
+	return ss
+}
+
+// Stop ends sampling and returns the usage with the final measurement.
+// Amplification is left for accountSpace, which knows the logical bytes.
+func (ss *SpaceSampler) Stop() *SpaceUsage {
+	ss.stop <- true
+	<-ss.done
+
+	ss.usage.After = measureDisk(ss.dir)
+	ss.usage.Peak = max(ss.usage.Peak, ss.usage.After.Total)
+	return &ss.usage
+}
+
+// accountSpace adds the bytes result wrote to the running total for its
+// database in logical and fills in its space amplification.
+func accountSpace(logical map[string]int64, dbPath string, result *BenchmarkResult) {
+	logical[dbPath] += result.BytesWritten
+
+	s := result.Space
+	if s == nil {
+		return
+	}
+	s.LogicalBytes = logical[dbPath]
+	if s.LogicalBytes > 0 {
+		s.Amplification = float64(s.After.Total) / float64(s.LogicalBytes)
+		s.SSTableAmp = float64(s.After.SSTables) / float64(s.LogicalBytes)
+		s.WALAmp = float64(s.After.WAL) / float64(s.LogicalBytes)
+	}
+}
+
+func printSpaceUsage(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		s := result.Space
+		if s == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Disk Space\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %12s %12s %12s %12s %12s %12s %8s %8s %8s\n",
+				"Test", "Before", "After", "Peak", "SSTables", "WAL", "Logical", "Amp", "SST amp", "WAL amp")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %12s %12s %12s %12s %12s %12s %8.2f %8.2f %8.2f\n",
+			result.Label(), formatBytes(s.Before.Total), formatBytes(s.After.Total), formatBytes(s.Peak),
+			formatBytes(s.After.SSTables), formatBytes(s.After.WAL), formatBytes(s.LogicalBytes),
+			s.Amplification, s.SSTableAmp, s.WALAmp)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// LiveRun is the benchmark currently executing, published so its state can be
+// inspected without stopping it.
+type LiveRun struct {
+	Name         string
+	StartTime    time.Time
+	Tracker      *LatencyTracker
+	OpsCompleted *int64
+	Errors       *int64
+	Percentiles  []float64
+	DB           Engine // The database under test, for its stats
+}
+
+var currentRun atomic.Pointer[LiveRun]
+
+const maxRecentErrors = 16
+
+type errorEntry struct {
+	At        time.Time
+	Benchmark string
+	Err       string
+}
+
+// errorLog keeps the most recent errors seen by any worker in a ring buffer.
+type errorLog struct {
+	mu      sync.Mutex
+	entries []errorEntry
+	next    int
+}
+
+var recentErrors = &errorLog{}
+
+func (el *errorLog) add(err error) {
+	entry := errorEntry{At: time.Now(), Err: err.Error()}
+	if run := currentRun.Load(); run != nil {
+		entry.Benchmark = run.Name
+	}
+
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if len(el.entries) < maxRecentErrors {
+		el.entries = append(el.entries, entry)
+		return
+	}
+	el.entries[el.next] = entry
+	el.next = (el.next + 1) % maxRecentErrors
+}
+
+// snapshot returns the logged errors from oldest to newest.
+func (el *errorLog) snapshot() []errorEntry {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	out := make([]errorEntry, 0, len(el.entries))
+	out = append(out, el.entries[el.next:]...)
+	out = append(out, el.entries[:el.next]...)
+	return out
+}
+
+// countError adds n to a benchmark's error counter, classifies err and
+// remembers it for live state dumps.
+func countError(errors *int64, n int64, err error) {
+	atomic.AddInt64(errors, n)
+	classifyError(errors, n, err)
+	if err != nil {
+		recentErrors.add(err)
+	}
+}
+
+// dumpLiveState writes the in-flight state of the running benchmark, the most
+// recent errors and every goroutine's stack to w.
+func dumpLiveState(w io.Writer) {
+	fmt.Fprintf(w, "\n=== Live state dump at %s ===\n", time.Now().Format(time.RFC3339))
+
+	run := currentRun.Load()
+	if run == nil {
+		fmt.Fprintf(w, "No benchmark is running\n")
+	} else {
+		writeRunProgress(w, run)
+
+		fmt.Fprintf(w, "Threads:\n")
+		for i, t := range run.Tracker.Threads() {
+			fmt.Fprintf(w, "  thread %3d: %10d ops, last latency %s\n",
+				i, t.Ops, formatDuration(time.Duration(t.LastLatency)))
+		}
+	}
+
+	entries := recentErrors.snapshot()
+	fmt.Fprintf(w, "Recent errors (%d):\n", len(entries))
+	for _, e := range entries {
+		fmt.Fprintf(w, "  %s [%s] %s\n", e.At.Format("15:04:05.000"), e.Benchmark, e.Err)
+	}
+
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+	fmt.Fprintf(w, "Goroutines (%d):\n%s\n", runtime.NumGoroutine(), buf)
+	fmt.Fprintf(w, "=== End of live state dump ===\n\n")
+}
+
+// dumpLiveStats writes a short summary of the running benchmark to w: its
+// progress and percentiles so far, the goroutine count and the database's
+// own stats.
+func dumpLiveStats(w io.Writer) {
+	fmt.Fprintf(w, "\n=== Live stats at %s ===\n", time.Now().Format(time.RFC3339))
+
+	run := currentRun.Load()
+	if run == nil {
+		fmt.Fprintf(w, "No benchmark is running\n")
+	} else {
+		writeRunProgress(w, run)
+	}
+	fmt.Fprintf(w, "Goroutines: %d\n", runtime.NumGoroutine())
+	if run != nil && run.DB != nil {
+		fmt.Fprintf(w, "Database Stats:\n%s\n", run.DB.Stats())
+	}
+
+	fmt.Fprintf(w, "=== End of live stats ===\n\n")
+}
+
+// writeRunProgress writes a running benchmark's name, elapsed time, progress
+// and latency percentiles so far.
+func writeRunProgress(w io.Writer, run *LiveRun) {
+	elapsed := time.Since(run.StartTime)
+	ops := atomic.LoadInt64(run.OpsCompleted)
+	fmt.Fprintf(w, "Benchmark: %s (running for %s)\n", run.Name, elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Progress: %d ops, %.2f ops/sec, %d errors\n",
+		ops, float64(ops)/elapsed.Seconds(), atomic.LoadInt64(run.Errors))
+
+	fmt.Fprintf(w, "Latency:")
+	for _, p := range run.Tracker.Percentiles(run.Percentiles) {
+		fmt.Fprintf(w, " %s %s,", percentileLabel(p.Percentile), formatDuration(p.Latency))
+	}
+	_, _, _, mx := run.Tracker.GetPercentiles()
+	fmt.Fprintf(w, " Max %s\n", formatDuration(mx))
+}
+
+// handleDumpSignals dumps live state to stderr on SIGQUIT instead of letting
+// the runtime kill the process, and the shorter live stats on SIGUSR1. main
+// installs it once for the whole process; the dumps read currentRun, so a
+// server or daemon running one benchmark run after another dumps the current
+// one, once per signal.
+func handleDumpSignals() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGQUIT, syscall.SIGUSR1)
+
+	go func() {
+		for sig := range sigs {
+			if sig == syscall.SIGUSR1 {
+				dumpLiveStats(os.Stderr)
+			} else {
+				dumpLiveState(os.Stderr)
+			}
+		}
+	}()
+}
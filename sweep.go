@@ -0,0 +1,201 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// SweepAxis is one parameter of a -sweep and the values it takes.
+type SweepAxis struct {
+	Name   string
+	Values []string
+}
+
+// parseSweep parses "threads=1,4,16;value_size=128,1024" into axes, checking
+// that every name is a flag that can be overridden per benchmark.
+func parseSweep(s string) ([]SweepAxis, error) {
+	known := flag.NewFlagSet("sweep", flag.ContinueOnError)
+	known.SetOutput(io.Discard)
+	registerFlags(known, defaultConfig())
+
+	var axes []SweepAxis
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, values, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("expected name=value,... in %q", part)
+		}
+		switch {
+		case name == "benchmarks" || name == "config" || name == "sweep":
+			return nil, fmt.Errorf("%s cannot be swept", name)
+		case known.Lookup(name) == nil:
+			return nil, fmt.Errorf("unknown flag %q", name)
+		}
+		for _, axis := range axes {
+			if axis.Name == name {
+				return nil, fmt.Errorf("%s swept more than once", name)
+			}
+		}
+
+		axis := SweepAxis{Name: name}
+		for _, v := range strings.Split(values, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				axis.Values = append(axis.Values, v)
+			}
+		}
+		if len(axis.Values) == 0 {
+			return nil, fmt.Errorf("%s has no values", name)
+		}
+		axes = append(axes, axis)
+	}
+	return axes, nil
+}
+
+// sweepPoints returns the Cartesian product of the axes, the last axis
+// varying fastest, each point rendered as name=value,... for labels.
+func sweepPoints(axes []SweepAxis) []map[string]string {
+	points := []map[string]string{{}}
+	for _, axis := range axes {
+		var next []map[string]string
+		for _, p := range points {
+			for _, v := range axis.Values {
+				q := make(map[string]string, len(p)+1)
+				for k, pv := range p {
+					q[k] = pv
+				}
+				q[axis.Name] = v
+				next = append(next, q)
+			}
+		}
+		points = next
+	}
+	return points
+}
+
+func sweepLabel(axes []SweepAxis, point map[string]string) string {
+	parts := make([]string, len(axes))
+	for i, axis := range axes {
+		parts[i] = axis.Name + "=" + point[axis.Name]
+	}
+	return strings.Join(parts, ",")
+}
+
+// benchmarkRuns returns the benchmark list to run: the configured one, or
+// with -sweep the whole list once per sweep point. Parameters given for a
+// single benchmark win over the swept ones.
+func (config *BenchmarkConfig) benchmarkRuns() []BenchmarkSpec {
+	if len(config.SweepAxes) == 0 {
+		return config.Benchmarks
+	}
+
+	var specs []BenchmarkSpec
+	for _, point := range sweepPoints(config.SweepAxes) {
+		for _, spec := range config.Benchmarks {
+			params := make(map[string]string, len(point)+len(spec.Params))
+			for k, v := range point {
+				params[k] = v
+			}
+			for k, v := range spec.Params {
+				params[k] = v
+			}
+			specs = append(specs, BenchmarkSpec{
+				Name:   spec.Name,
+				Params: params,
+				Sweep:  sweepLabel(config.SweepAxes, point),
+			})
+		}
+	}
+	return specs
+}
+
+// printSweepGrid prints ops/sec and P99 latency for every sweep point and
+// benchmark, averaging repeated runs.
+func printSweepGrid(config *BenchmarkConfig, results []*BenchmarkResult) {
+	if len(config.SweepAxes) == 0 {
+		return
+	}
+
+	type cell struct {
+		opsPerSec float64
+		p99       time.Duration
+		runs      int
+	}
+	cells := make(map[[2]string]*cell)
+	for _, r := range results {
+		key := [2]string{r.Sweep, r.TestName}
+		c := cells[key]
+		if c == nil {
+			c = &cell{}
+			cells[key] = c
+		}
+		c.opsPerSec += r.OpsPerSecond
+		c.p99 += r.LatencyP99
+		c.runs++
+	}
+
+	var names []string
+	for _, spec := range config.Benchmarks {
+		names = append(names, spec.Name)
+	}
+
+	var labels []string
+	for _, point := range sweepPoints(config.SweepAxes) {
+		labels = append(labels, sweepLabel(config.SweepAxes, point))
+	}
+
+	width := len("Sweep")
+	for _, label := range labels {
+		width = max(width, len(label))
+	}
+
+	grid := func(title string, value func(c *cell) string) {
+		fmt.Printf("%s\n", title)
+		fmt.Printf("%-*s", width, "Sweep")
+		for _, name := range names {
+			fmt.Printf(" %14s", name)
+		}
+		fmt.Printf("\n")
+		for _, label := range labels {
+			fmt.Printf("%-*s", width, label)
+			for _, name := range names {
+				v := "-"
+				if c := cells[[2]string{label, name}]; c != nil {
+					v = value(c)
+				}
+				fmt.Printf(" %14s", v)
+			}
+			fmt.Printf("\n")
+		}
+		fmt.Printf("\n")
+	}
+
+	fmt.Printf("Sweep Results\n")
+	fmt.Printf("=============\n")
+	grid("Ops/sec", func(c *cell) string {
+		return fmt.Sprintf("%.2f", c.opsPerSec/float64(c.runs))
+	})
+	grid("P99 latency", func(c *cell) string {
+		return formatDuration(c.p99 / time.Duration(c.runs))
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// TimelineSampler counts operations completed in one-second buckets while a
+// benchmark runs.
+type TimelineSampler struct {
+	opsCompleted *int64
+	samples      []int64
+	stop         chan bool
+	done         chan bool
+}
+
+func StartTimelineSampler(opsCompleted *int64) *TimelineSampler {
+	ts := &TimelineSampler{
+		opsCompleted: opsCompleted,
+		stop:         make(chan bool),
+		done:         make(chan bool),
+	}
+
+	go func() {
+		defer close(ts.done)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		var last int64
+		for {
+			select {
+			case <-ticker.C:
+				ops := atomic.LoadInt64(ts.opsCompleted)
+				ts.samples = append(ts.samples, ops-last)
+				last = ops
+			case <-ts.stop:
+				// Keep the trailing partial second so the buckets add up to
+				// the total op count
+				if ops := atomic.LoadInt64(ts.opsCompleted); ops > last {
+					ts.samples = append(ts.samples, ops-last)
+				}
+				return
+			}
+		}
+	}()
+
+	return ts
+}
+
+// Stop ends sampling and returns the ops completed in each second.
+func (ts *TimelineSampler) Stop() []int64 {
+	ts.stop <- true
+	<-ts.done
+	return ts.samples
+}
+
+// writeTimelineCSV writes every benchmark's per-second op counts as
+// benchmark,second,ops rows, one file for the whole run.
+func writeTimelineCSV(path string, results []*BenchmarkResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(f)
+	_ = cw.Write([]string{"benchmark", "second", "ops"})
+	for _, r := range results {
+		for i, ops := range r.Timeline {
+			_ = cw.Write([]string{r.Label(), strconv.Itoa(i + 1), strconv.FormatInt(ops, 10)})
+		}
+	}
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
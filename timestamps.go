@@ -0,0 +1,162 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+// TimestampCheck is what -check_timestamps found in the MVCC timestamps
+// WildcatDB's iterators returned during a benchmark.
+type TimestampCheck struct {
+	Entries     int64    // Iterator entries checked
+	Invalid     int64    // Timestamps not above zero
+	Future      int64    // Versions newer than the snapshot that returned them
+	Regressed   int64    // Older versions returned after a newer one was visible
+	Conflicting int64    // One key and timestamp seen with two different values
+	Anomalies   []string `json:",omitempty"` // The first few anomalies found
+}
+
+// TimestampChecker checks the version of every entry an iterator returns
+// against the reading snapshot's timestamp and against the newest version
+// of the key seen so far. A version is only held against a later read that
+// began after the read that saw it ended, and whose snapshot includes it, so
+// reads racing on a key never produce a false regression. Each key seen is
+// remembered, so memory grows with the keys iterated.
+type TimestampChecker struct {
+	clock   atomic.Int64 // Orders the reads, not tied to WildcatDB's clock
+	stripes [256]timestampStripe
+
+	statsMu sync.Mutex // Guards stats.Anomalies
+	stats   TimestampCheck
+}
+
+type timestampStripe struct {
+	mu   sync.Mutex
+	keys map[string]keyVersion
+}
+
+// keyVersion is the newest version of a key seen.
+type keyVersion struct {
+	ts    int64
+	crc   uint32 // Checksum of the value
+	ended int64  // When the first read that saw it ended
+}
+
+// tsChecker is the checker of the running benchmark, nil unless
+// -check_timestamps is set. Its methods are safe to call on nil.
+var tsChecker *TimestampChecker
+
+func newTimestampChecker() *TimestampChecker {
+	c := &TimestampChecker{}
+	for i := range c.stripes {
+		c.stripes[i].keys = make(map[string]keyVersion)
+	}
+	return c
+}
+
+// Now returns the time to pass to Check as when a read began.
+func (c *TimestampChecker) Now() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.clock.Add(1)
+}
+
+// Check checks an entry with version ts that a read beginning at began (a
+// time from Now) returned from the snapshot with timestamp snapshot.
+func (c *TimestampChecker) Check(snapshot, began int64, key, value []byte, ts int64) {
+	if c == nil {
+		return
+	}
+
+	atomic.AddInt64(&c.stats.Entries, 1)
+	ended := c.clock.Add(1)
+
+	if ts <= 0 {
+		c.anomaly(&c.stats.Invalid, "invalid: key %x returned with timestamp %d", key, ts)
+		return
+	}
+	if ts > snapshot {
+		c.anomaly(&c.stats.Future, "future: key %x version %d returned by snapshot %d", key, ts, snapshot)
+	}
+
+	crc := crc32.ChecksumIEEE(value)
+
+	h := fnv.New32a()
+	_, _ = h.Write(key)
+	s := &c.stripes[h.Sum32()%uint32(len(c.stripes))]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen, ok := s.keys[string(key)]
+	switch {
+	case !ok || ts > seen.ts:
+		s.keys[string(key)] = keyVersion{ts: ts, crc: crc, ended: ended}
+	case ts == seen.ts:
+		if crc != seen.crc {
+			c.anomaly(&c.stats.Conflicting, "conflicting: key %x version %d returned with two different values", key, ts)
+		}
+	case began > seen.ended && seen.ts <= snapshot:
+		c.anomaly(&c.stats.Regressed, "regressed: key %x version %d returned by snapshot %d after version %d was seen",
+			key, ts, snapshot, seen.ts)
+	}
+}
+
+func (c *TimestampChecker) anomaly(counter *int64, format string, args ...any) {
+	atomic.AddInt64(counter, 1)
+	c.statsMu.Lock()
+	if len(c.stats.Anomalies) < maxBadKeys {
+		c.stats.Anomalies = append(c.stats.Anomalies, fmt.Sprintf(format, args...))
+	}
+	c.statsMu.Unlock()
+}
+
+// Result returns what the checker found. Call it once the benchmark is done.
+func (c *TimestampChecker) Result() *TimestampCheck {
+	r := c.stats
+	return &r
+}
+
+func printTimestampChecks(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		tc := result.Timestamps
+		if tc == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("MVCC Timestamp Checks\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %12s %10s %10s %10s %12s\n",
+				"Test", "Entries", "Invalid", "Future", "Regressed", "Conflicting")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %12d %10d %10d %10d %12d\n", result.Label(), tc.Entries,
+			tc.Invalid, tc.Future, tc.Regressed, tc.Conflicting)
+		for _, a := range tc.Anomalies {
+			fmt.Printf("  %s\n", a)
+		}
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
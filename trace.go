@@ -0,0 +1,144 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceRecorder writes every point operation the benchmarks issue in the
+// format the replay benchmark reads. Paths ending in .gz are gzip-compressed.
+type TraceRecorder struct {
+	mu     sync.Mutex
+	file   *os.File
+	gz     *gzip.Writer
+	w      *bufio.Writer
+	failed bool
+}
+
+// opTrace is the active recorder, nil unless -record_trace is set. Its
+// methods are safe to call on nil.
+var opTrace *TraceRecorder
+
+func NewTraceRecorder(path string) (*TraceRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := &TraceRecorder{file: f}
+	var w io.Writer = f
+	if strings.HasSuffix(path, ".gz") {
+		tr.gz = gzip.NewWriter(f)
+		w = tr.gz
+	}
+	tr.w = bufio.NewWriterSize(w, 1<<20)
+
+	fmt.Fprintf(tr.w, "# wildcat_bench trace: <unix seconds> <op> <key> [value size]\n")
+	return tr, nil
+}
+
+// startTraceRecording installs the -record_trace recorder and returns the
+// function that finishes the file.
+func startTraceRecording(config *BenchmarkConfig) (func(), error) {
+	if config.RecordTrace == "" {
+		return func() {}, nil
+	}
+
+	tr, err := NewTraceRecorder(config.RecordTrace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace: %w", err)
+	}
+	opTrace = tr
+
+	return func() {
+		opTrace = nil
+		if err := tr.Close(); err != nil {
+			log.Printf("Failed to write trace: %v", err)
+		} else {
+			fmt.Printf("Trace written to: %s\n", config.RecordTrace)
+		}
+	}, nil
+}
+
+// Record appends one operation: op is GET, PUT or DELETE.
+func (tr *TraceRecorder) Record(op string, key []byte, valueSize int) {
+	if tr == nil {
+		return
+	}
+
+	now := time.Now()
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.failed {
+		return
+	}
+
+	var err error
+	if op == "PUT" {
+		_, err = fmt.Fprintf(tr.w, "%d.%06d %s %s %d\n", now.Unix(), now.Nanosecond()/1000, op, traceKey(key), valueSize)
+	} else {
+		_, err = fmt.Fprintf(tr.w, "%d.%06d %s %s\n", now.Unix(), now.Nanosecond()/1000, op, traceKey(key))
+	}
+	if err != nil {
+		// Report once rather than for every following operation
+		tr.failed = true
+		log.Printf("Failed to record trace, recording stopped: %v", err)
+	}
+}
+
+func (tr *TraceRecorder) Close() error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	err := tr.w.Flush()
+	if tr.gz != nil {
+		if cerr := tr.gz.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if cerr := tr.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// traceKey writes keys of printable ASCII as they are and anything else,
+// including keys that would read as hex, as 0x-prefixed hex.
+func traceKey(key []byte) string {
+	if len(key) > 0 && !strings.HasPrefix(string(key), "0x") {
+		printable := true
+		for _, b := range key {
+			if b <= ' ' || b > '~' {
+				printable = false
+				break
+			}
+		}
+		if printable {
+			return string(key)
+		}
+	}
+	return "0x" + hex.EncodeToString(key)
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// dashboardRefresh is how often the -tui dashboard redraws.
+const dashboardRefresh = time.Second
+
+// sparkWidth is how many samples a sparkline shows, the most recent last.
+const sparkWidth = 40
+
+// Dashboard is the -tui live view. It polls the running benchmark and the
+// database directory, so the benchmarks need no knowledge of it, and redraws
+// the whole screen each time.
+type Dashboard struct {
+	config *BenchmarkConfig
+	stop   chan struct{}
+	done   chan struct{}
+
+	entries []*dashboardEntry
+	live    *LiveRun // The run the last entry follows
+	lastOps int64
+	lastAt  time.Time
+
+	size      int64
+	sizeRate  float64 // Bytes per second
+	sampledAt time.Time
+	files     map[string]int64
+	created   int // Files appeared and removed since the previous refresh
+	removed   int
+}
+
+// dashboardEntry is one benchmark as the dashboard saw it.
+type dashboardEntry struct {
+	name      string
+	rates     []float64 // Ops/sec in each refresh
+	opsPerSec float64   // Over the whole run, as of the last refresh
+}
+
+// startDashboard takes over the terminal with the -tui dashboard and returns
+// the function that hands it back. Without -tui, or when stdout is not a
+// terminal, it does nothing.
+func startDashboard(config *BenchmarkConfig) func() {
+	if !config.TUI {
+		return func() {}
+	}
+	if info, err := os.Stdout.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		log.Printf("-tui needs a terminal; falling back to plain progress reports")
+		config.TUI = false
+		return func() {}
+	}
+
+	d := &Dashboard{
+		config:    config,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		files:     fileSizes(config.DBPath),
+		size:      dirSize(config.DBPath),
+		sampledAt: time.Now(),
+	}
+
+	// The alternate screen keeps the dashboard out of the scrollback, so the
+	// final report prints as without -tui
+	fmt.Print("\033[?1049h\033[?25l")
+
+	go func() {
+		defer close(d.done)
+
+		ticker := time.NewTicker(dashboardRefresh)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				d.refresh()
+			case <-d.stop:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(d.stop)
+		<-d.done
+		fmt.Print("\033[?25h\033[?1049l")
+	}
+}
+
+// refresh samples the running benchmark and the database, and redraws.
+func (d *Dashboard) refresh() {
+	now := time.Now()
+
+	live := currentRun.Load()
+	if live != nil && live != d.live {
+		d.entries = append(d.entries, &dashboardEntry{name: live.Name})
+		d.lastOps = 0
+		d.lastAt = live.StartTime
+	}
+	d.live = live
+
+	if live != nil {
+		e := d.entries[len(d.entries)-1]
+		ops := atomic.LoadInt64(live.OpsCompleted)
+		if elapsed := now.Sub(d.lastAt).Seconds(); elapsed > 0 {
+			e.rates = append(e.rates, float64(ops-d.lastOps)/elapsed)
+		}
+		e.opsPerSec = float64(ops) / now.Sub(live.StartTime).Seconds()
+		d.lastOps = ops
+		d.lastAt = now
+	}
+
+	files := fileSizes(d.config.DBPath)
+	d.created, d.removed = 0, 0
+	for path := range files {
+		if _, ok := d.files[path]; !ok {
+			d.created++
+		}
+	}
+	for path := range d.files {
+		if _, ok := files[path]; !ok {
+			d.removed++
+		}
+	}
+	d.files = files
+
+	var size int64
+	for _, s := range files {
+		size += s
+	}
+	if elapsed := now.Sub(d.sampledAt).Seconds(); elapsed > 0 {
+		d.sizeRate = float64(size-d.size) / elapsed
+	}
+	d.size = size
+	d.sampledAt = now
+
+	d.draw(live)
+}
+
+func (d *Dashboard) draw(live *LiveRun) {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString("\033[K\n")
+	}
+
+	b.WriteString("\033[H")
+	line("WildcatDB Benchmark - %s", time.Now().Format("15:04:05"))
+	line("")
+
+	if live == nil {
+		line("Between benchmarks")
+	} else {
+		e := d.entries[len(d.entries)-1]
+		elapsed := time.Since(live.StartTime)
+		ops := atomic.LoadInt64(live.OpsCompleted)
+
+		line("Running: %s (benchmark %d), %s elapsed", live.Name, len(d.entries), elapsed.Round(time.Second))
+		line("  Throughput: %12.2f ops/sec  %s", lastRate(e.rates), sparkline(e.rates))
+		line("  Operations: %12d  (%.2f ops/sec overall), %d errors", ops, e.opsPerSec, atomic.LoadInt64(live.Errors))
+
+		var latency []string
+		for _, p := range live.Tracker.Percentiles(live.Percentiles) {
+			latency = append(latency, fmt.Sprintf("%s %s", percentileLabel(p.Percentile), formatDuration(p.Latency)))
+		}
+		_, _, _, mx := live.Tracker.GetPercentiles()
+		latency = append(latency, "Max "+formatDuration(mx))
+		line("  Latency:    %s", strings.Join(latency, "  "))
+	}
+	line("")
+
+	line("Database: %s on disk (%s/s), %d files", formatBytes(d.size), formatSignedBytes(d.sizeRate), len(d.files))
+	line("  Compaction: %d files written, %d removed in the last %s", d.created, d.removed, dashboardRefresh)
+	for _, shape := range dbShape(d.config.DBPath) {
+		line("  %-10s %6d files %12s", shape.Name, shape.Files, formatBytes(shape.Bytes))
+	}
+	line("")
+
+	if len(d.entries) > 1 || live == nil && len(d.entries) > 0 {
+		line("Completed:")
+		completed := d.entries
+		if live != nil {
+			completed = completed[:len(completed)-1]
+		}
+		for _, e := range completed {
+			line("  %-25s %12.2f ops/sec  %s", e.name, e.opsPerSec, sparkline(e.rates))
+		}
+	}
+
+	b.WriteString("\033[J")
+	fmt.Print(b.String())
+}
+
+func lastRate(rates []float64) float64 {
+	if len(rates) == 0 {
+		return 0
+	}
+	return rates[len(rates)-1]
+}
+
+// sparkline draws the last sparkWidth values scaled to their maximum.
+func sparkline(values []float64) string {
+	const bars = "▁▂▃▄▅▆▇█"
+	ticks := []rune(bars)
+
+	values = values[max(len(values)-sparkWidth, 0):]
+	var peak float64
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if peak > 0 {
+			i = min(int(v/peak*float64(len(ticks)-1)+0.5), len(ticks)-1)
+		}
+		b.WriteRune(ticks[i])
+	}
+	return b.String()
+}
+
+// formatSignedBytes formats a byte count that may be negative, such as the
+// change in database size while compaction reclaims space.
+func formatSignedBytes(bytes float64) string {
+	if bytes < 0 {
+		return "-" + formatBytes(int64(-bytes))
+	}
+	return "+" + formatBytes(int64(bytes))
+}
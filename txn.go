@@ -0,0 +1,136 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// TxnStats counts how the transactions of a benchmark fared. Conflicts are
+// kept apart from errors: they are the expected outcome of contention, and a
+// conflicted attempt can be retried.
+type TxnStats struct {
+	Transactions int64 // Transactions run to an outcome, not counting retries
+	Attempts     int64 // Attempts including retries
+	Conflicts    int64 // Attempts that failed with a conflict
+	Abandoned    int64 // Transactions still conflicting after TxnRetries retries
+	WastedOps    int64 // Operations performed in attempts that were thrown away
+}
+
+// isConflict reports whether err is a transaction conflict rather than a
+// hard failure, as the engine adapters report one with errConflict. Only
+// Badger has conflicts; on the other engines this is never true, so nothing
+// is retried and the conflict counts stay at zero.
+func isConflict(err error) bool {
+	return errors.Is(err, errConflict)
+}
+
+// txnPhases splits transaction latency into the operations inside the
+// transaction and the commit, so time spent buffering writes can be told
+// apart from time spent in the commit, WAL write and sync. Each attempt is
+// recorded, retries included.
+type txnPhases struct {
+	body   *LatencyTracker
+	commit *LatencyTracker
+}
+
+// newTxnPhases resolves the classes for the transaction body, named after
+// what it does, and for the commit.
+func newTxnPhases(tracker *LatencyTracker, body string) *txnPhases {
+	return &txnPhases{body: tracker.Class(body), commit: tracker.Class("commit")}
+}
+
+// runTxn runs fn in a new transaction and commits it. A conflict, whether
+// from fn or the commit, is retried up to TxnRetries times after a random
+// backoff of up to TxnBackoff, doubling with each retry. ops is how many
+// operations fn performs, counted as wasted for every discarded attempt. It
+// reports whether the transaction committed and returns only hard errors; a
+// transaction abandoned after its retries is neither. The duration of fn and
+// of the commit are recorded under threadID in phases.
+func runTxn(db Engine, config *BenchmarkConfig, stats *TxnStats, phases *txnPhases, threadID int, ops int64,
+	fn func(txn Txn) error) (bool, error) {
+
+	atomic.AddInt64(&stats.Transactions, 1)
+
+	for attempt := 0; ; attempt++ {
+		atomic.AddInt64(&stats.Attempts, 1)
+
+		txn, err := db.Begin(true)
+		if err != nil {
+			return false, err
+		}
+
+		bodyStart := time.Now()
+		err = fn(txn)
+		phases.body.Record(threadID, time.Since(bodyStart))
+		if err != nil {
+			_ = txn.Rollback()
+		} else {
+			commitStart := time.Now()
+			err = wrapCommit(txn.Commit())
+			phases.commit.Record(threadID, time.Since(commitStart))
+		}
+
+		if err == nil {
+			return true, nil
+		}
+		if !isConflict(err) {
+			return false, err
+		}
+
+		atomic.AddInt64(&stats.Conflicts, 1)
+		atomic.AddInt64(&stats.WastedOps, ops)
+
+		if attempt >= config.TxnRetries {
+			atomic.AddInt64(&stats.Abandoned, 1)
+			return false, nil
+		}
+
+		if backoff := config.TxnBackoff << attempt; backoff > 0 {
+			time.Sleep(time.Duration(rand.Int63n(int64(backoff)) + 1))
+		}
+	}
+}
+
+func printTxnStats(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		s := result.Txn
+		if s == nil || s.Transactions == 0 {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Transaction Conflicts\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %12s %12s %10s %12s %12s %12s\n",
+				"Test", "Txns", "Conflicts", "Rate", "Retries/txn", "Abandoned", "Wasted ops")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %12d %12d %9.2f%% %12.3f %12d %12d\n",
+			result.Label(), s.Transactions, s.Conflicts,
+			float64(s.Conflicts)*100/float64(s.Attempts),
+			float64(s.Attempts-s.Transactions)/float64(s.Transactions),
+			s.Abandoned, s.WastedOps)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// validatedBenchmarks are the fill benchmarks -validate reads back.
+var validatedBenchmarks = []string{"fillseq", "fillrandom", "fillprefixed", "filllarge"}
+
+// maxBadKeys caps how many missing or corrupt keys a validation keeps.
+const maxBadKeys = 10
+
+// Validation is the outcome of reading back every key a fill benchmark
+// wrote. Content is checked by the -verify checksum, which -validate turns
+// on, and the value's length.
+type Validation struct {
+	Keys      int64 // Distinct keys acknowledged by the fill
+	Missing   int64
+	Corrupt   int64 // Checksum mismatch
+	WrongSize int64 // Checksum intact but not the length written
+	Failed    int64 // Reads failing for another reason
+	Duration  time.Duration
+	BadKeys   []string `json:",omitempty"` // The first few missing or corrupt keys, in hex
+}
+
+// FillLedger records the keys a fill benchmark wrote and the length of
+// their values, once the write is acknowledged. Each thread appends to its
+// own slot, so recording does not contend.
+type FillLedger struct {
+	slots []ledgerSlot
+}
+
+type ledgerSlot struct {
+	mu      sync.Mutex
+	entries []ledgerEntry
+}
+
+type ledgerEntry struct {
+	key  string
+	size int
+}
+
+// fillLedger is the ledger of the running fill benchmark, nil unless
+// -validate is set. Its methods are safe to call on nil.
+var fillLedger *FillLedger
+
+func newFillLedger(threads int) *FillLedger {
+	return &FillLedger{slots: make([]ledgerSlot, max(threads, 1))}
+}
+
+// Record notes that key was written by threadID with a value of size bytes.
+func (l *FillLedger) Record(threadID int, key []byte, size int) {
+	if l == nil {
+		return
+	}
+
+	slot := &l.slots[threadID%len(l.slots)]
+	slot.mu.Lock()
+	slot.entries = append(slot.entries, ledgerEntry{string(key), size})
+	slot.mu.Unlock()
+}
+
+// entries returns one entry per distinct key. A key written twice keeps
+// whichever size was recorded last; the fills write one size throughout.
+func (l *FillLedger) entries() []ledgerEntry {
+	sizes := make(map[string]int)
+	for i := range l.slots {
+		for _, e := range l.slots[i].entries {
+			sizes[e.key] = e.size
+		}
+	}
+
+	entries := make([]ledgerEntry, 0, len(sizes))
+	for key, size := range sizes {
+		entries = append(entries, ledgerEntry{key, size})
+	}
+	return entries
+}
+
+// validateFill reads back every key in the ledger across config.NumThreads
+// threads, in read-only transactions of up to a thousand keys.
+func validateFill(db Engine, config *BenchmarkConfig, ledger *FillLedger) *Validation {
+	entries := ledger.entries()
+	v := &Validation{Keys: int64(len(entries))}
+	start := time.Now()
+
+	var mu sync.Mutex
+	bad := func(counter *int64, key string) {
+		atomic.AddInt64(counter, 1)
+		mu.Lock()
+		if len(v.BadKeys) < maxBadKeys {
+			v.BadKeys = append(v.BadKeys, fmt.Sprintf("%x", key))
+		}
+		mu.Unlock()
+	}
+
+	const perTxn = 1000
+	var next int64
+	var wg sync.WaitGroup
+
+	for t := 0; t < config.NumThreads; t++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				from := atomic.AddInt64(&next, perTxn) - perTxn
+				if from >= int64(len(entries)) {
+					return
+				}
+				batch := entries[from:min(from+perTxn, int64(len(entries)))]
+
+				err := db.View(func(txn Txn) error {
+					for _, e := range batch {
+						value, err := txn.Get([]byte(e.key))
+						switch {
+						case errors.Is(err, errCorruptValue):
+							bad(&v.Corrupt, e.key)
+						case isNotFound(err):
+							bad(&v.Missing, e.key)
+						case err != nil:
+							bad(&v.Failed, e.key)
+						case len(value) != e.size:
+							bad(&v.WrongSize, e.key)
+						}
+					}
+					return nil
+				})
+				if err != nil {
+					atomic.AddInt64(&v.Failed, int64(len(batch)))
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	v.Duration = time.Since(start)
+	slices.Sort(v.BadKeys)
+
+	return v
+}
+
+// printValidation reports the validation of one fill benchmark as soon as
+// it finishes.
+func printValidation(name string, v *Validation) {
+	fmt.Printf("Validated %s: %d keys in %s, %d missing, %d corrupt, %d wrong size, %d failed reads\n",
+		name, v.Keys, formatDuration(v.Duration), v.Missing, v.Corrupt, v.WrongSize, v.Failed)
+	for _, key := range v.BadKeys {
+		fmt.Printf("  bad key: %s\n", key)
+	}
+}
+
+func printValidations(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		v := result.Validation
+		if v == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Fill Validation\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %12s %10s %10s %10s %10s %10s\n",
+				"Test", "Keys", "Missing", "Corrupt", "Wrong size", "Failed", "Time")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %12d %10d %10d %10d %10d %10s\n", result.Label(), v.Keys,
+			v.Missing, v.Corrupt, v.WrongSize, v.Failed, formatDuration(v.Duration))
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
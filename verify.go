@@ -0,0 +1,176 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sync/atomic"
+)
+
+// errCorruptValue is returned by a Get under -verify when the value read
+// does not match the checksum written with it.
+var errCorruptValue = errors.New("value checksum mismatch")
+
+// checksumSize is the length of the CRC trailer -verify appends to values.
+const checksumSize = 4
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// VerifyStats counts the values a benchmark checked under -verify.
+type VerifyStats struct {
+	Checked int64
+	Corrupt int64 // Values whose checksum did not match, or too short to hold one
+}
+
+// valueChecks accumulates over the whole process; each benchmark reports the
+// difference across its run, so reopened databases are counted too.
+var valueChecks VerifyStats
+
+func (s *VerifyStats) snapshot() VerifyStats {
+	return VerifyStats{Checked: atomic.LoadInt64(&s.Checked), Corrupt: atomic.LoadInt64(&s.Corrupt)}
+}
+
+// valueChecksum covers the key as well as the value, so a value returned
+// for the wrong key is caught too.
+func valueChecksum(key, value []byte) uint32 {
+	return crc32.Update(crc32.Checksum(key, castagnoli), castagnoli, value)
+}
+
+// verifyingEngine wraps an Engine for -verify: every value is stored with a
+// CRC32C of its key and contents appended, which reads check and strip. The
+// benchmarks see the values they wrote, so nothing else needs to know, but
+// every value on disk is checksumSize bytes longer.
+type verifyingEngine struct {
+	Engine
+}
+
+type verifyingTxn struct {
+	Txn
+}
+
+type verifyingIterator struct {
+	Iterator
+}
+
+func (e verifyingEngine) Begin(writable bool) (Txn, error) {
+	txn, err := e.Engine.Begin(writable)
+	if err != nil {
+		return nil, err
+	}
+	return verifyingTxn{txn}, nil
+}
+
+func (e verifyingEngine) Update(fn func(txn Txn) error) error {
+	return e.Engine.Update(func(txn Txn) error { return fn(verifyingTxn{txn}) })
+}
+
+func (e verifyingEngine) View(fn func(txn Txn) error) error {
+	return e.Engine.View(func(txn Txn) error { return fn(verifyingTxn{txn}) })
+}
+
+func (t verifyingTxn) Put(key, value []byte) error {
+	// The engine may hold on to the slice until commit, so the caller's
+	// buffer is left alone
+	sealed := make([]byte, len(value)+checksumSize)
+	copy(sealed, value)
+	binary.LittleEndian.PutUint32(sealed[len(value):], valueChecksum(key, value))
+	return t.Txn.Put(key, sealed)
+}
+
+func (t verifyingTxn) Get(key []byte) ([]byte, error) {
+	value, err := t.Txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := checkValue(key, value)
+	if !ok {
+		return nil, fmt.Errorf("key %x: %w", key, errCorruptValue)
+	}
+	return value, nil
+}
+
+func (t verifyingTxn) NewIterator(ascending bool) (Iterator, error) {
+	return wrapVerifyingIterator(t.Txn.NewIterator(ascending))
+}
+
+func (t verifyingTxn) NewRangeIterator(start, end []byte, ascending bool) (Iterator, error) {
+	return wrapVerifyingIterator(t.Txn.NewRangeIterator(start, end, ascending))
+}
+
+func (t verifyingTxn) NewPrefixIterator(prefix []byte, ascending bool) (Iterator, error) {
+	return wrapVerifyingIterator(t.Txn.NewPrefixIterator(prefix, ascending))
+}
+
+func wrapVerifyingIterator(iter Iterator, err error) (Iterator, error) {
+	if err != nil {
+		return nil, err
+	}
+	return verifyingIterator{iter}, nil
+}
+
+// Next checks each value it steps over. Iterators cannot fail a step, so
+// corrupt values are only counted, and returned as read.
+func (it verifyingIterator) Next() ([]byte, []byte, bool) {
+	key, value, ok := it.Iterator.Next()
+	if !ok {
+		return nil, nil, false
+	}
+	if checked, valid := checkValue(key, value); valid {
+		value = checked
+	}
+	return key, value, true
+}
+
+// checkValue verifies the checksum trailer of a value read under -verify and
+// returns the value without it.
+func checkValue(key, value []byte) ([]byte, bool) {
+	atomic.AddInt64(&valueChecks.Checked, 1)
+
+	if len(value) < checksumSize {
+		atomic.AddInt64(&valueChecks.Corrupt, 1)
+		return value, false
+	}
+	n := len(value) - checksumSize
+	if binary.LittleEndian.Uint32(value[n:]) != valueChecksum(key, value[:n]) {
+		atomic.AddInt64(&valueChecks.Corrupt, 1)
+		return value, false
+	}
+	return value[:n], true
+}
+
+func printVerifyStats(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		v := result.Verify
+		if v == nil || v.Checked == 0 {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Value Verification\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %12s %12s\n", "Test", "Checked", "Corrupt")
+			printed = true
+		}
+
+		fmt.Printf("%-25s %12d %12d\n", result.Label(), v.Checked, v.Corrupt)
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// versionSide is one of the two builds the versions subcommand compares.
+type versionSide struct {
+	name    string // A or B
+	bin     string
+	wildcat string // WildcatDB version to build bin against, if any
+	results []*BenchmarkResult
+	version VersionInfo
+}
+
+// runVersions runs the versions subcommand, a driver that benchmarks two
+// builds of this tool, typically against different WildcatDB releases, on
+// the same workload and seed. The builds run in turn, A then B, for each
+// round so that drift over the session hits both alike, and the pooled runs
+// of each side are compared at the end. Arguments after the driver's own
+// flags (following "--") are passed to both builds unchanged.
+func runVersions(args []string) {
+	fs := flag.NewFlagSet("versions", flag.ExitOnError)
+	binA := fs.String("bin_a", "", "Benchmark binary for side A")
+	binB := fs.String("bin_b", "", "Benchmark binary for side B")
+	wildcatA := fs.String("wildcat_a", "", "Build side A from -src against this WildcatDB version, e.g. v2.3.5")
+	wildcatB := fs.String("wildcat_b", "", "Build side B from -src against this WildcatDB version, e.g. v2.4.0")
+	src := fs.String("src", ".", "Benchmark source tree to build -wildcat_a and -wildcat_b from")
+	rounds := fs.Int("rounds", 3, "Times each side runs the benchmarks, alternating A and B")
+	dbPath := fs.String("db", "/tmp/wildcat_bench", "Database path; side A uses <db>_a and side B <db>_b")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed given to both sides so they generate identical workloads")
+	outDir := fs.String("out", "", "Keep the builds and each run's results file in this directory (default: a temporary one)")
+	sigTest := fs.String("sig_test", "welch", "Significance test for throughput across runs: welch, mannwhitney")
+	sigAlpha := fs.Float64("sig_alpha", 0.05, "Significance level for -sig_test")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s versions [flags] -- [benchmark flags]\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	sides := []*versionSide{
+		{name: "A", bin: *binA, wildcat: *wildcatA},
+		{name: "B", bin: *binB, wildcat: *wildcatB},
+	}
+	for _, side := range sides {
+		if (side.bin == "") == (side.wildcat == "") {
+			log.Fatalf("Side %s needs exactly one of -bin_%s and -wildcat_%s", side.name, strings.ToLower(side.name), strings.ToLower(side.name))
+		}
+	}
+	if *rounds < 1 {
+		log.Fatalf("Invalid rounds: %d (must be at least 1)", *rounds)
+	}
+	if !slices.Contains(sigTests, *sigTest) {
+		log.Fatalf("Invalid significance test: %s (must be one of %s)", *sigTest, strings.Join(sigTests, ", "))
+	}
+
+	dir := *outDir
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "wildcat_versions")
+		if err != nil {
+			log.Fatalf("Failed to create work directory: %v", err)
+		}
+		defer func() {
+			_ = os.RemoveAll(tmp)
+		}()
+		dir = tmp
+	} else if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	for _, side := range sides {
+		if side.wildcat == "" {
+			continue
+		}
+		fmt.Printf("Building side %s against wildcat %s...\n", side.name, side.wildcat)
+		bin, err := buildWithWildcat(*src, side.wildcat, filepath.Join(dir, "build_"+strings.ToLower(side.name)))
+		if err != nil {
+			log.Fatalf("Failed to build side %s: %v", side.name, err)
+		}
+		side.bin = bin
+	}
+
+	for round := 1; round <= *rounds; round++ {
+		for _, side := range sides {
+			fmt.Printf("\n=== Round %d/%d, side %s: %s\n\n", round, *rounds, side.name, side.bin)
+
+			suffix := strings.ToLower(side.name)
+			resultsFile := filepath.Join(dir, fmt.Sprintf("%s_%d.json", suffix, round))
+
+			// The seed goes first so the benchmark flags can still override
+			// it; the database and results file go last so they cannot be.
+			childArgs := append([]string{fmt.Sprintf("-seed=%d", *seed)}, fs.Args()...)
+			childArgs = append(childArgs, "-db="+*dbPath+"_"+suffix, "-results_file="+resultsFile)
+
+			cmd := exec.Command(side.bin, childArgs...)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			if err := cmd.Run(); err != nil {
+				log.Fatalf("Side %s failed in round %d: %v", side.name, round, err)
+			}
+
+			rf, err := readResultsFile(resultsFile)
+			if err != nil {
+				log.Fatalf("Failed to load side %s results: %v", side.name, err)
+			}
+			side.results = append(side.results, rf.Results...)
+			side.version = rf.Version
+		}
+	}
+
+	nameA, nameB := sides[0].label(), sides[1].label()
+	if nameA == nameB {
+		nameA, nameB = "A", "B"
+	}
+
+	fmt.Printf("\nVersion Comparison (%d rounds, seed %d)\n", *rounds, *seed)
+	fmt.Printf("==================\n")
+	for _, side := range sides {
+		fmt.Printf("  %s: %s, wildcat %s, revision %q\n", side.name, side.bin, side.version.WildcatVersion, side.version.VCSRevision)
+	}
+	fmt.Printf("\n")
+
+	printComparison(nameA, nameB, sides[0].results, sides[1].results, *sigTest, *sigAlpha)
+}
+
+// label names the side in the comparison by what distinguishes it.
+func (s *versionSide) label() string {
+	if s.wildcat != "" {
+		return s.wildcat
+	}
+	return filepath.Base(s.bin)
+}
+
+// buildWithWildcat copies the benchmark sources in src to dir, points the
+// copy at the given WildcatDB version and builds it, returning the binary.
+func buildWithWildcat(src, version, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !(strings.HasSuffix(name, ".go") || name == "go.mod" || name == "go.sum") ||
+			strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(src, name))
+		if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return "", err
+		}
+	}
+
+	bin := filepath.Join(dir, "wildcat_bench")
+	for _, args := range [][]string{
+		{"get", "github.com/wildcatdb/wildcat/v2@" + version},
+		{"build", "-o", bin, "."},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("go %s: %w", strings.Join(args, " "), err)
+		}
+	}
+
+	return bin, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WALStats is what happened to WildcatDB's write-ahead log during a
+// benchmark. Every memtable has its own WAL file, named after an ID that
+// only ever grows, so rotations are exact; the bytes appended are summed
+// from samples of each file's size, and a WAL created and flushed between
+// two samples adds nothing to Written but is counted in Unsampled.
+type WALStats struct {
+	Rotations int64 // New WAL files, one per memtable rotation
+	Flushed   int64 // WAL files removed once their memtable was flushed
+	Written   int64 // Bytes appended to WAL files, as sampled
+	Unsampled int64 // Rotated WAL files never seen by a sample
+	Before    int64 // Total WAL size when the benchmark started
+	After     int64 // Total WAL size when it ended
+	Peak      int64 // Largest total WAL size sampled
+}
+
+// walFiles returns the size of each WAL file in a WildcatDB directory by
+// WAL ID. The WAL files sit at the top of the directory.
+func walFiles(dir string) map[int64]int64 {
+	files := make(map[int64]int64)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return files
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".wal") {
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimSuffix(name, ".wal"), 10, 64)
+		if err != nil {
+			continue
+		}
+		// A WAL flushed since the directory was read is skipped
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		files[id] = info.Size()
+	}
+
+	return files
+}
+
+// WALSampler follows the WAL files of a WildcatDB directory across a
+// benchmark. A nil *WALSampler, for the other engines, samples nothing.
+type WALSampler struct {
+	dir   string
+	start map[int64]int64
+	seen  map[int64]int64 // Largest size sampled of each WAL file
+	peak  int64
+	stop  chan bool
+	done  chan bool
+}
+
+// StartWALSampler lists the WAL files in dir now and then every interval,
+// which when zero leaves only the listings at the start and end.
+func StartWALSampler(dir string, interval time.Duration) *WALSampler {
+	ws := &WALSampler{
+		dir:  dir,
+		seen: make(map[int64]int64),
+		stop: make(chan bool),
+		done: make(chan bool),
+	}
+	ws.start = walFiles(dir)
+	ws.sample(ws.start)
+
+	go func() {
+		defer close(ws.done)
+		if interval <= 0 {
+			<-ws.stop
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ws.sample(walFiles(ws.dir))
+			case <-ws.stop:
+				return
+			}
+		}
+	}()
+
+	return ws
+}
+
+func (ws *WALSampler) sample(files map[int64]int64) int64 {
+	var total int64
+	for id, size := range files {
+		ws.seen[id] = max(ws.seen[id], size)
+		total += size
+	}
+	ws.peak = max(ws.peak, total)
+	return total
+}
+
+// Stop ends sampling and returns the WAL activity from the start of the
+// benchmark to now.
+func (ws *WALSampler) Stop() *WALStats {
+	if ws == nil {
+		return nil
+	}
+	ws.stop <- true
+	<-ws.done
+
+	var before int64
+	for _, size := range ws.start {
+		before += size
+	}
+	end := walFiles(ws.dir)
+	stats := &WALStats{Before: before, After: ws.sample(end), Peak: ws.peak}
+
+	lastStart, lastEnd := maxWALID(ws.start), maxWALID(end)
+	stats.Rotations = max(lastEnd-lastStart, 0)
+	stats.Flushed = max(int64(len(ws.start))+stats.Rotations-int64(len(end)), 0)
+
+	var sampled int64
+	for id, size := range ws.seen {
+		stats.Written += size - ws.start[id]
+		if id > lastStart {
+			sampled++
+		}
+	}
+	stats.Unsampled = max(stats.Rotations-sampled, 0)
+
+	return stats
+}
+
+func maxWALID(files map[int64]int64) int64 {
+	var last int64
+	for id := range files {
+		last = max(last, id)
+	}
+	return last
+}
+
+func printWALStats(results []*BenchmarkResult) {
+	printed := false
+	for _, result := range results {
+		w := result.WAL
+		if w == nil {
+			continue
+		}
+
+		if !printed {
+			fmt.Printf("Write-Ahead Log\n")
+			fmt.Printf("=========================\n")
+			fmt.Printf("%-25s %10s %10s %12s %10s %8s %12s %12s %12s\n",
+				"Test", "Rotations", "Flushed", "Written", "Bytes/op", "WAL amp", "Before", "After", "Peak")
+			printed = true
+		}
+
+		var perOp, amp float64
+		if result.Operations > 0 {
+			perOp = float64(w.Written) / float64(result.Operations)
+		}
+		if result.BytesWritten > 0 {
+			amp = float64(w.Written) / float64(result.BytesWritten)
+		}
+
+		fmt.Printf("%-25s %10d %10d %12s %10.1f %8.2f %12s %12s %12s\n",
+			result.Label(), w.Rotations, w.Flushed, formatBytes(w.Written), perOp, amp,
+			formatBytes(w.Before), formatBytes(w.After), formatBytes(w.Peak))
+		if w.Unsampled > 0 {
+			fmt.Printf("%-25s %d rotated WAL files were flushed between samples; set or lower -wal_interval to count their bytes\n",
+				"", w.Unsampled)
+		}
+	}
+
+	if printed {
+		fmt.Printf("\n")
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// warmCacheModes are the -warm_cache settings: leave the caches as the
+// previous benchmark left them, scan the whole database, read every
+// existing key, or drop the operating system's page cache.
+var warmCacheModes = []string{"none", "scan", "keys", "cold"}
+
+// warmCache brings the caches into the state -warm_cache asks for before a
+// read benchmark starts its clock. Other benchmarks are left alone, and
+// nothing done here counts towards the benchmark's results.
+func warmCache(db Engine, config *BenchmarkConfig, benchmarkName string) error {
+	if config.WarmCache == "none" || !slices.Contains(readOnlyBenchmarks, benchmarkName) {
+		return nil
+	}
+
+	start := time.Now()
+	var keys, bytes, errors int64
+
+	switch config.WarmCache {
+	case "scan":
+		err := db.View(func(txn Txn) error {
+			it, err := txn.NewIterator(true)
+			if err != nil {
+				return err
+			}
+			for {
+				key, value, ok := it.Next()
+				if !ok {
+					return nil
+				}
+				keys++
+				bytes += int64(len(key) + len(value))
+			}
+		})
+		if err != nil {
+			log.Printf("Cache warm-up scan failed: %v", err)
+		}
+
+	case "keys":
+		var wg sync.WaitGroup
+		for t := 0; t < config.NumThreads; t++ {
+			wg.Add(1)
+			go func(threadID int) {
+				defer wg.Done()
+
+				for i := int64(threadID); i < config.ExistingKeys; i += int64(config.NumThreads) {
+					key := generateKey(i, config.KeySize, config.KeyDistribution)
+					err := db.View(func(txn Txn) error {
+						value, err := txn.Get(key)
+						atomic.AddInt64(&bytes, int64(len(key)+len(value)))
+						return err
+					})
+					if err != nil {
+						atomic.AddInt64(&errors, 1)
+						continue
+					}
+					atomic.AddInt64(&keys, 1)
+				}
+			}(t)
+		}
+		wg.Wait()
+
+	case "cold":
+		// Dirty pages cannot be dropped, so they are written back first
+		syscall.Sync()
+		if err := os.WriteFile("/proc/sys/vm/drop_caches", []byte("3"), 0); err != nil {
+			return fmt.Errorf("failed to drop the page cache: %w (-warm_cache=cold needs root on Linux)", err)
+		}
+		fmt.Printf("Dropped the page cache in %s\n", formatDuration(time.Since(start)))
+		return nil
+	}
+
+	fmt.Printf("Warmed the cache (%s): read %d keys, %s in %s", config.WarmCache, keys, formatBytes(bytes),
+		formatDuration(time.Since(start)))
+	if errors > 0 {
+		fmt.Printf(", %d errors", errors)
+	}
+	fmt.Printf("\n")
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 WildcatDB Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Workload describes the settings loaded from a -config file. Settings are
+// keyed by flag name; nested sections only group them and are flattened.
+type Workload struct {
+	Settings   map[string]string
+	Benchmarks []BenchmarkSpec
+}
+
+// loadWorkloadFile reads a YAML, TOML or JSON workload file, choosing the
+// format by extension.
+func loadWorkloadFile(path string) (*Workload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		_, err = toml.Decode(string(data), &doc)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&doc)
+	default:
+		return nil, fmt.Errorf("%s: unknown workload file format (use .yaml, .yml, .toml or .json)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	w := &Workload{Settings: make(map[string]string)}
+	if err := w.collect(doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return w, nil
+}
+
+func (w *Workload) collect(section map[string]any) error {
+	keys := make([]string, 0, len(section))
+	for k := range section {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := section[key]
+
+		if key == "benchmarks" {
+			if w.Benchmarks != nil {
+				return fmt.Errorf("benchmarks set more than once")
+			}
+			specs, err := workloadBenchmarks(value)
+			if err != nil {
+				return err
+			}
+			w.Benchmarks = specs
+			continue
+		}
+
+		if sub, ok := value.(map[string]any); ok {
+			if err := w.collect(sub); err != nil {
+				return err
+			}
+			continue
+		}
+
+		s, err := workloadScalar(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if _, dup := w.Settings[key]; dup {
+			return fmt.Errorf("%s set more than once", key)
+		}
+		w.Settings[key] = s
+	}
+	return nil
+}
+
+// workloadBenchmarks accepts a comma-separated string or a list whose entries
+// are benchmark names or maps with a name and per-benchmark flag overrides.
+func workloadBenchmarks(value any) ([]BenchmarkSpec, error) {
+	if s, ok := value.(string); ok {
+		return parseBenchmarkSpecs(s)
+	}
+
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("benchmarks must be a list")
+	}
+
+	specs := make([]BenchmarkSpec, 0, len(list))
+	for i, item := range list {
+		switch item := item.(type) {
+		case string:
+			specs = append(specs, BenchmarkSpec{Name: item})
+		case map[string]any:
+			spec := BenchmarkSpec{Params: make(map[string]string)}
+			for k, v := range item {
+				s, err := workloadScalar(v)
+				if err != nil {
+					return nil, fmt.Errorf("benchmarks[%d].%s: %w", i, k, err)
+				}
+				if k == "name" {
+					spec.Name = s
+				} else {
+					spec.Params[k] = s
+				}
+			}
+			if spec.Name == "" {
+				return nil, fmt.Errorf("benchmarks[%d] has no name", i)
+			}
+			specs = append(specs, spec)
+		default:
+			return nil, fmt.Errorf("benchmarks[%d] must be a name or a table", i)
+		}
+	}
+	return specs, nil
+}
+
+// workloadScalar renders a value as the string a flag would receive. Lists
+// become comma-separated, matching the list-valued flags.
+func workloadScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			s, err := workloadScalar(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+// applyWorkloadFile loads path and applies every setting not already given
+// on the command line, so flags always override the file.
+func applyWorkloadFile(fs *flag.FlagSet, config *BenchmarkConfig, raw *rawFlags, path string) error {
+	w, err := loadWorkloadFile(path)
+	if err != nil {
+		return err
+	}
+
+	visited := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { visited[f.Name] = true })
+
+	names := make([]string, 0, len(w.Settings))
+	for name := range w.Settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "config" || fs.Lookup(name) == nil {
+			return fmt.Errorf("unknown setting %q", name)
+		}
+		if visited[name] {
+			continue
+		}
+		if err := setFlag(fs, name, w.Settings[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	if w.Benchmarks != nil && !visited["benchmarks"] {
+		config.Benchmarks = w.Benchmarks
+		*raw.benchmarks = formatBenchmarkSpecs(w.Benchmarks)
+	}
+
+	return nil
+}